@@ -0,0 +1,171 @@
+// inx-scaffold generates the skeleton of a new INX extension that is based on inx-app.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	templatesDir = "templates"
+
+	// inxAppModule is the go module path of inx-app, which the generated extension requires.
+	inxAppModule = "github.com/iotaledger/inx-app"
+
+	// componentPlaceholder is replaced with the package name of the extension component in the template paths.
+	componentPlaceholder = "__component__"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// scaffold contains the values that are passed to the templates.
+type scaffold struct {
+	// Name is the name of the extension, e.g. "inx-example".
+	Name string
+	// Module is the go module path of the extension.
+	Module string
+	// InxAppVersion is the version of inx-app that is required by the extension, e.g. "v1.0.0".
+	InxAppVersion string
+	// ComponentPackage is the package name of the extension component, e.g. "example".
+	ComponentPackage string
+	// ComponentName is the name of the extension component, e.g. "Example".
+	ComponentName string
+	// APIRoute is the route that is registered at the node, e.g. "example/v1".
+	APIRoute string
+}
+
+// buildInxAppVersion returns the version of inx-app the scaffold tool was built from,
+// or an empty string if it was not built from a released version, e.g. via "go run" in a local checkout.
+func buildInxAppVersion() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok || buildInfo.Main.Path != inxAppModule || buildInfo.Main.Version == "(devel)" {
+		return ""
+	}
+
+	return buildInfo.Main.Version
+}
+
+func newScaffold(name string, module string, inxAppVersion string) (*scaffold, error) {
+	if name == "" {
+		return nil, ierrors.New("the name of the extension must be specified")
+	}
+
+	if module == "" {
+		module = "github.com/iotaledger/" + name
+	}
+
+	if inxAppVersion == "" {
+		inxAppVersion = buildInxAppVersion()
+	}
+
+	switch {
+	case inxAppVersion == "":
+		return nil, ierrors.New("unable to determine the version of inx-app, it must be specified")
+	case !strings.HasPrefix(inxAppVersion, "v"):
+		return nil, ierrors.Errorf("invalid version of inx-app \"%s\", it must start with \"v\"", inxAppVersion)
+	}
+
+	componentPackage := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, strings.TrimPrefix(name, "inx-"))
+
+	if componentPackage == "" || !unicode.IsLetter(rune(componentPackage[0])) {
+		return nil, ierrors.Errorf("unable to derive a valid package name from \"%s\"", name)
+	}
+
+	return &scaffold{
+		Name:             name,
+		Module:           module,
+		InxAppVersion:    inxAppVersion,
+		ComponentPackage: componentPackage,
+		ComponentName:    strings.ToUpper(componentPackage[:1]) + componentPackage[1:],
+		APIRoute:         componentPackage + "/v1",
+	}, nil
+}
+
+// generate renders all templates into the output directory.
+func (s *scaffold) generate(outputDir string) error {
+	return fs.WalkDir(templates, templatesDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(templatesDir, path)
+		if err != nil {
+			return err
+		}
+		relativePath = strings.TrimSuffix(strings.ReplaceAll(relativePath, componentPlaceholder, s.ComponentPackage), ".tmpl")
+
+		tmpl, err := template.ParseFS(templates, path)
+		if err != nil {
+			return ierrors.Wrapf(err, "unable to parse template %s", path)
+		}
+
+		var content bytes.Buffer
+		if err := tmpl.Execute(&content, s); err != nil {
+			return ierrors.Wrapf(err, "unable to execute template %s", path)
+		}
+
+		targetPath := filepath.Join(outputDir, relativePath)
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
+			return err
+		}
+
+		//nolint:gosec // the generated source files are meant to be readable
+		return os.WriteFile(targetPath, content.Bytes(), 0o644)
+	})
+}
+
+func run() error {
+	name := flag.String("name", "", "the name of the extension, e.g. \"inx-example\"")
+	module := flag.String("module", "", "the go module path of the extension (default \"github.com/iotaledger/<name>\")")
+	outputDir := flag.String("out", "", "the output directory (default \"./<name>\")")
+	inxAppVersion := flag.String("inx-app-version", "", "the version of inx-app the extension requires, e.g. \"v1.0.0\" (default the version of inx-scaffold)")
+	flag.Parse()
+
+	s, err := newScaffold(*name, *module, *inxAppVersion)
+	if err != nil {
+		return err
+	}
+
+	if *outputDir == "" {
+		*outputDir = s.Name
+	}
+
+	if _, err := os.Stat(*outputDir); err == nil {
+		return ierrors.Errorf("output directory %s already exists", *outputDir)
+	}
+
+	if err := s.generate(*outputDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated %s in %s\n", s.Name, *outputDir)
+	fmt.Printf("Run \"go mod tidy\" in %s to fetch the dependencies.\n", *outputDir)
+
+	return nil
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+}
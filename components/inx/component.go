@@ -2,13 +2,16 @@ package inx
 
 import (
 	"context"
+	"strings"
 
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/app/shutdown"
 	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
 	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
 )
 
 const PriorityDisconnectINX = 0
@@ -27,6 +30,7 @@ type dependencies struct {
 	dig.In
 	NodeBridge      nodebridge.NodeBridge
 	ShutdownHandler *shutdown.ShutdownHandler
+	ShutdownHooks   *ShutdownHooks
 }
 
 var (
@@ -35,11 +39,49 @@ var (
 )
 
 func provide(c *dig.Container) error {
-	return c.Provide(func() (nodebridge.NodeBridge, error) {
-		nodeBridge := nodebridge.New(
-			Component.Logger,
-			nodebridge.WithTargetNetworkName(ParamsINX.TargetNetworkName),
-		)
+	// the output cache is nil if it is disabled
+	if err := c.Provide(func() *nodebridge.OutputCache {
+		if ParamsINX.OutputCacheSize <= 0 {
+			return nil
+		}
+
+		return nodebridge.NewOutputCache(ParamsINX.OutputCacheSize)
+	}); err != nil {
+		return err
+	}
+
+	if err := c.Provide(func(outputCache *nodebridge.OutputCache) (nodebridge.NodeBridge, error) {
+		newNodeBridge := func(logger log.Logger) nodebridge.NodeBridge {
+			return nodebridge.New(
+				logger,
+				nodebridge.WithTargetNetworkName(ParamsINX.TargetNetworkName),
+				nodebridge.WithStreamReconnect(ParamsINX.StreamReconnect.Interval, ParamsINX.StreamReconnect.MaxAttempts),
+				nodebridge.WithStreamReconnectBackoff(ParamsINX.StreamReconnect.MaxInterval),
+				nodebridge.WithStreamWatchdog(ParamsINX.StreamWatchdog.Interval, ParamsINX.StreamWatchdog.Restart),
+				nodebridge.WithOutputCache(outputCache),
+				nodebridge.WithSkipOutputProofVerification(ParamsINX.SkipOutputProofVerification),
+				nodebridge.WithKeepalive(ParamsINX.Keepalive.Time, ParamsINX.Keepalive.Timeout, ParamsINX.Keepalive.PermitWithoutStream),
+				nodebridge.WithCallTimeout(ParamsINX.CallTimeout),
+				nodebridge.WithDefaultTimeouts(ParamsINX.DefaultTimeouts.Read, ParamsINX.DefaultTimeouts.Write, ParamsINX.DefaultTimeouts.StreamEstablish),
+				nodebridge.WithMaxRecvMsgSize(ParamsINX.MaxRecvMsgSize),
+				nodebridge.WithMaxSendMsgSize(ParamsINX.MaxSendMsgSize),
+				nodebridge.WithProtocolParametersPollInterval(ParamsINX.ProtocolParametersPollInterval),
+				nodebridge.WithRequiredCapabilities(requiredCapabilities()...),
+			)
+		}
+
+		// multiple addresses enable the failover between the nodes
+		var nodeBridge nodebridge.NodeBridge
+		switch {
+		case strings.Contains(ParamsINX.Address, nodebridge.MultiNodeAddressSeparator):
+			nodeBridge = nodebridge.NewMultiNodeBridge(
+				Component.Logger,
+				nodebridge.WithNodeBridgeFactory(newNodeBridge),
+				nodebridge.WithHealthCheckInterval(ParamsINX.Failover.HealthCheckInterval),
+			)
+		default:
+			nodeBridge = newNodeBridge(Component.Logger)
+		}
 
 		if err := nodeBridge.Connect(
 			Component.Daemon().ContextStopped(),
@@ -50,9 +92,31 @@ func provide(c *dig.Container) error {
 		}
 
 		return nodeBridge, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := c.Provide(NewShutdownHooks); err != nil {
+		return err
+	}
+
+	return c.Provide(func(nodeBridge nodebridge.NodeBridge) *nodebridge.HealthyWaiter {
+		return nodebridge.NewHealthyWaiter(nodeBridge, ParamsINX.WaitForNodeHealthy)
 	})
 }
 
+// requiredCapabilities returns the capabilities of the parameters, empty entries are ignored.
+func requiredCapabilities() []nodebridge.Capability {
+	capabilities := make([]nodebridge.Capability, 0, len(ParamsINX.RequiredCapabilities))
+	for _, capability := range ParamsINX.RequiredCapabilities {
+		if capability = strings.TrimSpace(capability); capability != "" {
+			capabilities = append(capabilities, nodebridge.Capability(capability))
+		}
+	}
+
+	return capabilities
+}
+
 func run() error {
 	return Component.Daemon().BackgroundWorker("INX", func(ctx context.Context) {
 		Component.LogInfo("Starting NodeBridge ...")
@@ -60,7 +124,18 @@ func run() error {
 		Component.LogInfo("Stopped NodeBridge")
 
 		if !ierrors.Is(ctx.Err(), context.Canceled) {
-			deps.ShutdownHandler.SelfShutdown("INX connection to node dropped", true)
+			reason := &ShutdownReason{
+				Message:           "INX connection to node dropped",
+				LastProcessedSlot: deps.ShutdownHooks.LastProcessedSlot(),
+				LastCommitmentID:  iotago.EmptyCommitmentID,
+			}
+			if latestCommitment := deps.NodeBridge.LatestCommitment(); latestCommitment != nil {
+				reason.LastCommitmentID = latestCommitment.CommitmentID
+			}
+
+			// the hooks allow the extension to flush its state before the app is shut down
+			deps.ShutdownHooks.run(Component.Logger, reason, ParamsINX.ShutdownHookTimeout)
+			deps.ShutdownHandler.SelfShutdown(reason.String(), true)
 		}
 	}, PriorityDisconnectINX)
 }
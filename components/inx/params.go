@@ -1,13 +1,57 @@
 package inx
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
 type ParametersINX struct {
-	Address               string `default:"localhost:9029" usage:"the INX address to which to connect to"`
+	Address               string `default:"localhost:9029" usage:"the INX address to which to connect to (host:port or unix:///path/to/socket), multiple comma-separated addresses of nodes of the same network enable the failover between them"`
 	MaxConnectionAttempts uint   `default:"30" usage:"the amount of times the connection to INX will be attempted before it fails (1 attempt per second)"`
 	TargetNetworkName     string `default:"" usage:"the network name on which the node should operate on (optional)"`
+	WaitForNodeHealthy    bool   `default:"false" usage:"whether dependent workers should wait until the node is healthy before they start"`
+	OutputCacheSize       int    `default:"0" usage:"the maximum amount of outputs kept in the in-memory output cache (0 to disable)"`
+
+	RequiredCapabilities []string `default:"" usage:"the capabilities the node needs to provide, the connection fails if one of them is missing (baseToken, ledger, blockIssuance, indexer, mqtt, blockIssuer)"`
+
+	SkipOutputProofVerification bool `default:"false" usage:"whether the verification of output ID proofs is skipped, which saves CPU time if the node is trusted (e.g. a local node)"`
+
+	StreamReconnect struct {
+		Interval    time.Duration `default:"0s" usage:"the interval after which streams are re-subscribed if the connection to the node was lost (0 to disable)"`
+		MaxInterval time.Duration `default:"0s" usage:"the maximum interval between reconnect attempts, the interval doubles with every consecutive attempt up to this value (0 to disable the backoff)"`
+		MaxAttempts uint          `default:"0" usage:"the amount of consecutive reconnect attempts of a stream before it fails (0 for unlimited)"`
+	} `name:"streamReconnect"`
+
+	StreamWatchdog struct {
+		Interval time.Duration `default:"0s" usage:"the interval after which a block or commitment stream without items is considered stale if the node is healthy (0 to disable)"`
+		Restart  bool          `default:"false" usage:"whether stale streams are re-subscribed"`
+	} `name:"streamWatchdog"`
+
+	Keepalive struct {
+		Time                time.Duration `default:"0s" usage:"the time after which a keepalive ping is sent if there is no activity on the connection (0 to disable)"`
+		Timeout             time.Duration `default:"20s" usage:"the time after which the connection is closed if a keepalive ping is not acknowledged"`
+		PermitWithoutStream bool          `default:"false" usage:"whether keepalive pings are sent if there are no active streams"`
+	} `name:"keepalive"`
+
+	CallTimeout time.Duration `default:"0s" usage:"the default timeout of INX calls (0 to disable)"`
+
+	DefaultTimeouts struct {
+		Read            time.Duration `default:"0s" usage:"the default timeout of INX calls that read data, overrides the call timeout (0 to disable)"`
+		Write           time.Duration `default:"0s" usage:"the default timeout of INX calls that change the state of the node, e.g. submitting blocks, overrides the call timeout (0 to disable)"`
+		StreamEstablish time.Duration `default:"0s" usage:"the default timeout to establish INX streams, established streams are not affected (0 to disable)"`
+	} `name:"defaultTimeouts"`
+
+	MaxRecvMsgSize int `default:"67108864" usage:"the maximum size in bytes of messages received from the node"`
+	MaxSendMsgSize int `default:"67108864" usage:"the maximum size in bytes of messages sent to the node"`
+
+	Failover struct {
+		HealthCheckInterval time.Duration `default:"5s" usage:"the interval in which disconnected nodes are reconnected if multiple INX addresses are configured"`
+	} `name:"failover"`
+
+	ProtocolParametersPollInterval time.Duration `default:"1m" usage:"the interval in which the node configuration is polled for announced protocol parameters (0 to disable)"`
+
+	ShutdownHookTimeout time.Duration `default:"10s" usage:"the timeout of every shutdown hook that is executed before the app is shut down because the connection to the node dropped"`
 }
 
 var ParamsINX = &ParametersINX{}
@@ -0,0 +1,87 @@
+package inx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/log"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// ShutdownHookFunc is called before the INX component shuts down the app because the connection to the node dropped,
+// e.g. to flush the state of the extension. The context is canceled after the shutdown hook timeout.
+type ShutdownHookFunc func(ctx context.Context, reason *ShutdownReason) error
+
+// ShutdownReason contains the structured data of a self-shutdown of the INX component.
+type ShutdownReason struct {
+	// Message describes why the app is shut down.
+	Message string
+	// LastProcessedSlot is the last slot the extension reported as processed via ShutdownHooks.SetLastProcessedSlot.
+	LastProcessedSlot iotago.SlotIndex
+	// LastCommitmentID is the ID of the latest commitment the node reported before the connection dropped.
+	LastCommitmentID iotago.CommitmentID
+}
+
+// String returns the shutdown message including the structured data.
+func (r *ShutdownReason) String() string {
+	return fmt.Sprintf("%s (lastProcessedSlot: %d, lastCommitment: %s)", r.Message, r.LastProcessedSlot, r.LastCommitmentID)
+}
+
+// shutdownHook is a named ShutdownHookFunc.
+type shutdownHook struct {
+	name string
+	hook ShutdownHookFunc
+}
+
+// ShutdownHooks is the chain of hooks that is executed before the INX component shuts down the app.
+// The hooks are executed in the order of their registration, a failing hook does not stop the chain.
+type ShutdownHooks struct {
+	hooksMutex sync.Mutex
+	hooks      []*shutdownHook
+
+	lastProcessedSlot atomic.Uint32
+}
+
+// NewShutdownHooks creates a new ShutdownHooks.
+func NewShutdownHooks() *ShutdownHooks {
+	return &ShutdownHooks{}
+}
+
+// Register adds the hook with the given name to the end of the chain.
+func (h *ShutdownHooks) Register(name string, hook ShutdownHookFunc) {
+	h.hooksMutex.Lock()
+	defer h.hooksMutex.Unlock()
+
+	h.hooks = append(h.hooks, &shutdownHook{name: name, hook: hook})
+}
+
+// SetLastProcessedSlot sets the last slot the extension processed, which is included in the shutdown reason.
+func (h *ShutdownHooks) SetLastProcessedSlot(slot iotago.SlotIndex) {
+	h.lastProcessedSlot.Store(uint32(slot))
+}
+
+// LastProcessedSlot returns the last slot the extension processed.
+func (h *ShutdownHooks) LastProcessedSlot() iotago.SlotIndex {
+	return iotago.SlotIndex(h.lastProcessedSlot.Load())
+}
+
+// run executes the hooks in the order of their registration, every hook gets the given timeout.
+func (h *ShutdownHooks) run(logger log.Logger, reason *ShutdownReason, timeout time.Duration) {
+	h.hooksMutex.Lock()
+	hooks := append([]*shutdownHook(nil), h.hooks...)
+	h.hooksMutex.Unlock()
+
+	for _, hook := range hooks {
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if err := hook.hook(ctx, reason); err != nil {
+				logger.LogWarnf("shutdown hook \"%s\" failed: %s", hook.name, err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,98 @@
+package faucetsupport
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+// ErrInsufficientFunds is returned if the inputs of a batch do not cover the requested amounts and the remainder.
+var ErrInsufficientFunds = ierrors.New("insufficient funds")
+
+// Batch is a transaction that sends the funds of multiple requests at once.
+// The outputs of the requests are followed by the remainder output, which sends the remaining funds back to the faucet
+// and also stores the remaining mana after the mana for the block issuance was allotted.
+type Batch struct {
+	// Requests are the requests of the batch, the output of a request has the same index as the request.
+	Requests []*Request
+	// Inputs are the unspent outputs of the faucet that are consumed by the transaction.
+	Inputs []*nodebridge.Output
+	// TransactionBuilder is the builder of the unsigned transaction.
+	TransactionBuilder *builder.TransactionBuilder
+	// RemainderOutputIndex is the index of the remainder output in the transaction.
+	RemainderOutputIndex int
+
+	// Transaction is the signed transaction, it is set after the batch was submitted.
+	Transaction *iotago.SignedTransaction
+	// BlockID is the ID of the block that contains the transaction, it is set after the batch was submitted.
+	BlockID iotago.BlockID
+}
+
+// NewBatch builds the transaction that consumes the given inputs of the faucet address and sends the requested
+// amounts to the addresses of the requests. The commitment is referenced by the transaction, which is needed
+// to allot the mana for the block issuance, so it should be the latest commitment of the node.
+func NewBatch(api iotago.API, signer iotago.AddressSigner, faucetAddress iotago.Address, inputs []*nodebridge.Output, requests []*Request, commitmentID iotago.CommitmentID) (*Batch, error) {
+	if len(requests) == 0 {
+		return nil, ierrors.New("batch contains no requests")
+	}
+
+	txBuilder := builder.NewTransactionBuilder(api, signer)
+	txBuilder.AddCommitmentInput(&iotago.CommitmentInput{CommitmentID: commitmentID})
+
+	var inputAmount iotago.BaseToken
+	for _, input := range inputs {
+		txBuilder.AddInput(&builder.TxInput{
+			UnlockTarget: faucetAddress,
+			InputID:      input.OutputID,
+			Input:        input.Output,
+		})
+		inputAmount += input.Output.BaseTokenAmount()
+	}
+
+	var requestedAmount iotago.BaseToken
+	for _, request := range requests {
+		output, err := builder.NewBasicOutputBuilder(request.Address, request.Amount).Build()
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "failed to build the output for address %s", request.Address.Bech32(api.ProtocolParameters().Bech32HRP()))
+		}
+		txBuilder.AddOutput(output)
+		requestedAmount += request.Amount
+	}
+
+	if requestedAmount > inputAmount {
+		return nil, ierrors.Wrapf(ErrInsufficientFunds, "requested %d base tokens, but the inputs only contain %d", requestedAmount, inputAmount)
+	}
+
+	remainderOutput, err := builder.NewBasicOutputBuilder(faucetAddress, inputAmount-requestedAmount).Build()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to build the remainder output")
+	}
+
+	minDeposit, err := api.StorageScoreStructure().MinDeposit(remainderOutput)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to calculate the minimum deposit of the remainder output")
+	}
+	if remainderOutput.Amount < minDeposit {
+		return nil, ierrors.Wrapf(ErrInsufficientFunds, "remainder of %d base tokens does not cover the minimum deposit of %d", remainderOutput.Amount, minDeposit)
+	}
+	txBuilder.AddOutput(remainderOutput)
+
+	return &Batch{
+		Requests:             requests,
+		Inputs:               inputs,
+		TransactionBuilder:   txBuilder,
+		RemainderOutputIndex: len(requests),
+	}, nil
+}
+
+// MinRequestAmount returns the minimum amount of base tokens that can be sent to the address,
+// which is the minimum deposit of the output that is created for a request.
+func MinRequestAmount(api iotago.API, address iotago.Address) (iotago.BaseToken, error) {
+	output, err := builder.NewBasicOutputBuilder(address, 0).Build()
+	if err != nil {
+		return 0, err
+	}
+
+	return api.StorageScoreStructure().MinDeposit(output)
+}
@@ -0,0 +1,348 @@
+package faucetsupport
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultFaucetBatchSize is the default maximum amount of requests per batch,
+	// one output of the transaction is reserved for the remainder.
+	DefaultFaucetBatchSize = iotago.MaxOutputsCount - 1
+	// DefaultFaucetBatchInterval is the default interval in which the queued requests are batched.
+	DefaultFaucetBatchInterval = 2 * time.Second
+	// DefaultFaucetAcceptanceTimeout is the default time a batch may take to get accepted.
+	DefaultFaucetAcceptanceTimeout = 1 * time.Minute
+)
+
+var (
+	// ErrAmountTooLow is returned if the requested amount does not cover the minimum deposit of the output.
+	ErrAmountTooLow = ierrors.New("requested amount does not cover the minimum deposit")
+	// ErrBatchNotAccepted is returned if a batch was not accepted before the acceptance timeout.
+	ErrBatchNotAccepted = ierrors.New("batch was not accepted before the timeout")
+)
+
+// UnspentOutputsFunc returns the unspent outputs of the faucet address, e.g. from the ledger of the extension.
+// The outputs may lag behind the accepted batches, the Faucet excludes the outputs it already spent.
+type UnspentOutputsFunc func(ctx context.Context) ([]*nodebridge.Output, error)
+
+// FaucetEvents are the events of the Faucet.
+type FaucetEvents struct {
+	// BatchSubmitted is triggered with the ID of the block that contains the transaction of the batch.
+	BatchSubmitted *event.Event2[*Batch, iotago.BlockID]
+	// BatchAccepted is triggered with the ID of the transaction if the batch was accepted.
+	BatchAccepted *event.Event2[*Batch, iotago.TransactionID]
+	// BatchFailed is triggered if the batch could not be submitted or was not accepted, its requests are requeued.
+	// The batch is nil if it could not be built.
+	BatchFailed *event.Event2[*Batch, error]
+}
+
+// Faucet sends the funds of the requests in its queue in batches.
+// Every batch is submitted via the BlockIssuer plugin of the node and the next batch is only built
+// after the previous one was accepted, so the remainder of a batch can be spent by the next one right away.
+// If a batch is not accepted before the acceptance timeout, its requests are requeued and the next batches spend
+// the inputs of the timed out batch again, so only one of them can be accepted and the requests are not paid twice.
+// If the timed out batch is accepted later on, it is handled like an accepted batch.
+type Faucet struct {
+	log.Logger
+
+	Events *FaucetEvents
+
+	nodeBridge         nodebridge.NodeBridge
+	tangleListener     *nodebridge.TangleListener
+	queue              *RequestQueue
+	address            iotago.Address
+	signer             iotago.AddressSigner
+	unspentOutputsFunc UnspentOutputsFunc
+
+	batchSize         int
+	batchInterval     time.Duration
+	acceptanceTimeout time.Duration
+
+	// remainder is the remainder output of the last accepted batch, it is nil if no batch was accepted yet.
+	remainder *nodebridge.Output
+	// timedOutBatches are the batches that were not accepted before the acceptance timeout and did not fail yet.
+	// Every batch spends the inputs of the first one, so at most one of them can be accepted.
+	timedOutBatches []*Batch
+	// spentOutputs are the outputs that were spent by accepted batches with the slot they were spent in,
+	// since they may still be returned by the unspentOutputsFunc until the slot was committed.
+	spentOutputs map[iotago.OutputID]iotago.SlotIndex
+}
+
+// WithFaucetBatchSize sets the maximum amount of requests per batch.
+func WithFaucetBatchSize(batchSize int) options.Option[Faucet] {
+	return func(f *Faucet) {
+		f.batchSize = batchSize
+	}
+}
+
+// WithFaucetBatchInterval sets the interval in which the queued requests are batched.
+func WithFaucetBatchInterval(interval time.Duration) options.Option[Faucet] {
+	return func(f *Faucet) {
+		f.batchInterval = interval
+	}
+}
+
+// WithFaucetAcceptanceTimeout sets the time a batch may take to get accepted before its requests are requeued.
+func WithFaucetAcceptanceTimeout(timeout time.Duration) options.Option[Faucet] {
+	return func(f *Faucet) {
+		f.acceptanceTimeout = timeout
+	}
+}
+
+// NewFaucet creates a new Faucet that sends the funds of the given address, which are unlocked with the signer.
+// The TangleListener needs to be running to track the acceptance of the batches.
+func NewFaucet(logger log.Logger, nodeBridge nodebridge.NodeBridge, tangleListener *nodebridge.TangleListener, queue *RequestQueue, address iotago.Address, signer iotago.AddressSigner, unspentOutputsFunc UnspentOutputsFunc, opts ...options.Option[Faucet]) *Faucet {
+	return options.Apply(&Faucet{
+		Logger: logger,
+		Events: &FaucetEvents{
+			BatchSubmitted: event.New2[*Batch, iotago.BlockID](),
+			BatchAccepted:  event.New2[*Batch, iotago.TransactionID](),
+			BatchFailed:    event.New2[*Batch, error](),
+		},
+		nodeBridge:         nodeBridge,
+		tangleListener:     tangleListener,
+		queue:              queue,
+		address:            address,
+		signer:             signer,
+		unspentOutputsFunc: unspentOutputsFunc,
+		batchSize:          DefaultFaucetBatchSize,
+		batchInterval:      DefaultFaucetBatchInterval,
+		acceptanceTimeout:  DefaultFaucetAcceptanceTimeout,
+		spentOutputs:       make(map[iotago.OutputID]iotago.SlotIndex),
+	}, opts)
+}
+
+// Enqueue adds a request of the given amount for the address to the queue.
+// It returns ErrAmountTooLow if the amount does not cover the minimum deposit of the output,
+// and the errors of RequestQueue.Enqueue.
+func (f *Faucet) Enqueue(address iotago.Address, amount iotago.BaseToken) (*Request, error) {
+	minAmount, err := MinRequestAmount(f.nodeBridge.APIProvider().CommittedAPI(), address)
+	if err != nil {
+		return nil, err
+	}
+	if amount < minAmount {
+		return nil, ierrors.Wrapf(ErrAmountTooLow, "requested %d base tokens, minimum deposit is %d", amount, minAmount)
+	}
+
+	return f.queue.Enqueue(address, amount)
+}
+
+// Run batches the queued requests in the configured interval until the context is canceled.
+func (f *Faucet) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.batchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.processBatch(ctx); err != nil && ctx.Err() == nil {
+				f.LogWarnf("sending batch failed: %s", err)
+			}
+		}
+	}
+}
+
+// processBatch sends the funds of the next requests of the queue and waits until the batch was accepted.
+func (f *Faucet) processBatch(ctx context.Context) error {
+	if err := f.checkTimedOutBatches(ctx); err != nil {
+		return err
+	}
+
+	requests := f.queue.Dequeue(f.batchSize)
+	if len(requests) == 0 {
+		return nil
+	}
+
+	batch, transactionID, err := f.submitBatch(ctx, requests)
+	if err != nil {
+		f.queue.Requeue(requests...)
+		f.Events.BatchFailed.Trigger(batch, err)
+
+		return err
+	}
+
+	ctxAccepted, cancelAccepted := context.WithTimeout(ctx, f.acceptanceTimeout)
+	defer cancelAccepted()
+
+	if err := f.tangleListener.AwaitTransactionAccepted(ctxAccepted, transactionID); err != nil {
+		err = ierrors.Join(ierrors.Wrapf(ErrBatchNotAccepted, "transaction %s", transactionID), err)
+
+		f.timedOutBatches = append(f.timedOutBatches, batch)
+		f.queue.Requeue(requests...)
+		f.Events.BatchFailed.Trigger(batch, err)
+
+		return err
+	}
+
+	f.batchAccepted(batch, transactionID)
+
+	return nil
+}
+
+// checkTimedOutBatches checks whether one of the timed out batches was accepted or failed in the meantime.
+// As long as their state is unknown, the next batches keep spending their inputs.
+func (f *Faucet) checkTimedOutBatches(ctx context.Context) error {
+	pendingBatches := make([]*Batch, 0, len(f.timedOutBatches))
+	for i, batch := range f.timedOutBatches {
+		transactionID, err := batch.Transaction.Transaction.ID()
+		if err != nil {
+			return ierrors.Wrap(err, "failed to calculate the transaction ID of a timed out batch")
+		}
+
+		metadata, err := f.nodeBridge.TransactionMetadata(ctx, transactionID)
+		if err != nil {
+			if !ierrors.Is(err, nodebridge.ErrNotFound) {
+				f.timedOutBatches = append(pendingBatches, f.timedOutBatches[i:]...)
+
+				return ierrors.Wrapf(err, "failed to read the metadata of transaction %s", transactionID)
+			}
+
+			pendingBatches = append(pendingBatches, batch)
+
+			continue
+		}
+
+		switch metadata.TransactionState {
+		case api.TransactionStateAccepted, api.TransactionStateCommitted, api.TransactionStateFinalized:
+			// the other timed out batches conflict with the accepted one
+			f.LogInfof("timed out batch was accepted, transaction: %s", transactionID)
+			f.batchAccepted(batch, transactionID)
+
+			return nil
+
+		case api.TransactionStateFailed:
+			continue
+
+		default:
+			pendingBatches = append(pendingBatches, batch)
+		}
+	}
+	f.timedOutBatches = pendingBatches
+
+	return nil
+}
+
+// submitBatch builds the batch of the requests and submits it via the BlockIssuer plugin of the node.
+func (f *Faucet) submitBatch(ctx context.Context, requests []*Request) (*Batch, iotago.TransactionID, error) {
+	latestCommitment := f.nodeBridge.LatestCommitment()
+	if latestCommitment == nil {
+		return nil, iotago.EmptyTransactionID, ierrors.New("latest commitment unknown")
+	}
+
+	inputs, err := f.inputs(ctx, latestCommitment.CommitmentID.Slot())
+	if err != nil {
+		return nil, iotago.EmptyTransactionID, ierrors.Wrap(err, "failed to get the unspent outputs of the faucet")
+	}
+
+	batch, err := NewBatch(f.nodeBridge.APIProvider().CommittedAPI(), f.signer, f.address, inputs, requests, latestCommitment.CommitmentID)
+	if err != nil {
+		return nil, iotago.EmptyTransactionID, err
+	}
+
+	blockIssuer, err := f.nodeBridge.BlockIssuer(ctx)
+	if err != nil {
+		return batch, iotago.EmptyTransactionID, err
+	}
+
+	payload, blockCreatedResponse, err := blockIssuer.SendPayloadWithTransactionBuilder(ctx, batch.TransactionBuilder, batch.RemainderOutputIndex)
+	if err != nil {
+		return batch, iotago.EmptyTransactionID, ierrors.Wrap(err, "failed to send the transaction via the block issuer")
+	}
+
+	signedTransaction, ok := payload.(*iotago.SignedTransaction)
+	if !ok {
+		return batch, iotago.EmptyTransactionID, ierrors.Errorf("unexpected payload type %T", payload)
+	}
+
+	transactionID, err := signedTransaction.Transaction.ID()
+	if err != nil {
+		return batch, iotago.EmptyTransactionID, ierrors.Wrap(err, "failed to calculate the transaction ID")
+	}
+
+	batch.Transaction = signedTransaction
+	batch.BlockID = blockCreatedResponse.BlockID
+
+	f.LogDebugf("submitted batch with %d requests, transaction: %s, block: %s", len(requests), transactionID, blockCreatedResponse.BlockID)
+	f.Events.BatchSubmitted.Trigger(batch, blockCreatedResponse.BlockID)
+
+	return batch, transactionID, nil
+}
+
+// inputs returns the outputs the next batch can spend, which are the remainder of the last accepted batch
+// and the unspent outputs that were not spent by an accepted batch yet.
+func (f *Faucet) inputs(ctx context.Context, latestCommittedSlot iotago.SlotIndex) ([]*nodebridge.Output, error) {
+	unspentOutputs, err := f.unspentOutputsFunc(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the inputs of the timed out batches are spent again, so the batches conflict with each other
+	var inputs []*nodebridge.Output
+	if len(f.timedOutBatches) > 0 {
+		inputs = append(inputs, f.timedOutBatches[0].Inputs...)
+	} else if f.remainder != nil {
+		inputs = append(inputs, f.remainder)
+	}
+
+	alreadyAdded := make(map[iotago.OutputID]struct{}, len(inputs))
+	for _, input := range inputs {
+		alreadyAdded[input.OutputID] = struct{}{}
+	}
+
+	unspentOutputIDs := make(map[iotago.OutputID]struct{}, len(unspentOutputs))
+	for _, output := range unspentOutputs {
+		unspentOutputIDs[output.OutputID] = struct{}{}
+
+		if _, spent := f.spentOutputs[output.OutputID]; spent {
+			continue
+		}
+
+		if _, added := alreadyAdded[output.OutputID]; added {
+			continue
+		}
+
+		inputs = append(inputs, output)
+	}
+
+	// the spent outputs that were committed and are not returned anymore don't need to be tracked further
+	for outputID, spentSlot := range f.spentOutputs {
+		if _, unspent := unspentOutputIDs[outputID]; !unspent && spentSlot <= latestCommittedSlot {
+			delete(f.spentOutputs, outputID)
+		}
+	}
+
+	if len(inputs) > iotago.MaxInputsCount {
+		inputs = inputs[:iotago.MaxInputsCount]
+	}
+
+	return inputs, nil
+}
+
+// batchAccepted marks the inputs of the accepted batch as spent, keeps its remainder for the next batch
+// and marks its requests as done.
+func (f *Faucet) batchAccepted(batch *Batch, transactionID iotago.TransactionID) {
+	f.timedOutBatches = nil
+
+	for _, input := range batch.Inputs {
+		f.spentOutputs[input.OutputID] = batch.BlockID.Slot()
+	}
+
+	f.remainder = &nodebridge.Output{
+		OutputID: iotago.OutputIDFromTransactionIDAndIndex(transactionID, uint16(batch.RemainderOutputIndex)),
+		Output:   batch.Transaction.Transaction.Outputs[batch.RemainderOutputIndex],
+	}
+
+	f.queue.Done(batch.Requests...)
+	f.Events.BatchAccepted.Trigger(batch, transactionID)
+}
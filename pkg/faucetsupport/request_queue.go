@@ -0,0 +1,168 @@
+// Package faucetsupport provides the building blocks of faucet-like extensions, which send funds to requested addresses:
+// a request queue with deduplication, the batching of the requests into transactions, the submission via the
+// BlockIssuer plugin of the node and the tracking of the acceptance of the transactions via the TangleListener.
+package faucetsupport
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultRequestQueueMaxSize is the default maximum amount of pending requests.
+	DefaultRequestQueueMaxSize = 5000
+)
+
+var (
+	// ErrAddressAlreadyQueued is returned if there is already a pending request for the address.
+	ErrAddressAlreadyQueued = ierrors.New("address is already in the queue")
+	// ErrRequestQueueFull is returned if the maximum amount of pending requests is reached.
+	ErrRequestQueueFull = ierrors.New("request queue is full")
+)
+
+// Request is a request of funds for an address.
+type Request struct {
+	// Address is the address the funds are sent to.
+	Address iotago.Address
+	// Amount is the amount of base tokens that is sent to the address.
+	Amount iotago.BaseToken
+	// QueuedTime is the time the request was added to the queue.
+	QueuedTime time.Time
+}
+
+// RequestQueue is a FIFO queue of requests that contains at most one pending request per address.
+// A request is pending from the time it was enqueued until it was marked as done,
+// so an address can't request funds again while the funds are still being sent.
+type RequestQueue struct {
+	mutex   sync.Mutex
+	maxSize int
+	queue   []*Request
+	// pending contains the queued and the dequeued requests that are not done yet, keyed by the address.
+	pending map[string]*Request
+}
+
+// WithRequestQueueMaxSize sets the maximum amount of pending requests.
+func WithRequestQueueMaxSize(maxSize int) options.Option[RequestQueue] {
+	return func(q *RequestQueue) {
+		q.maxSize = maxSize
+	}
+}
+
+// NewRequestQueue creates a new RequestQueue.
+func NewRequestQueue(opts ...options.Option[RequestQueue]) *RequestQueue {
+	return options.Apply(&RequestQueue{
+		maxSize: DefaultRequestQueueMaxSize,
+		pending: make(map[string]*Request),
+	}, opts)
+}
+
+// Enqueue adds a request of the given amount for the address.
+// It returns ErrAddressAlreadyQueued if there is a pending request for the address.
+func (q *RequestQueue) Enqueue(address iotago.Address, amount iotago.BaseToken) (*Request, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, exists := q.pending[address.Key()]; exists {
+		return nil, ErrAddressAlreadyQueued
+	}
+
+	if len(q.pending) >= q.maxSize {
+		return nil, ErrRequestQueueFull
+	}
+
+	request := &Request{
+		Address:    address,
+		Amount:     amount,
+		QueuedTime: time.Now(),
+	}
+	q.queue = append(q.queue, request)
+	q.pending[address.Key()] = request
+
+	return request, nil
+}
+
+// Dequeue removes up to maxCount requests from the queue in the order they were enqueued.
+// The requests stay pending until they are passed to Done, or they can be put back via Requeue.
+func (q *RequestQueue) Dequeue(maxCount int) []*Request {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	count := min(maxCount, len(q.queue))
+
+	requests := make([]*Request, count)
+	copy(requests, q.queue[:count])
+
+	// the remaining requests are copied, so the dequeued ones can be garbage collected
+	q.queue = append([]*Request(nil), q.queue[count:]...)
+
+	return requests
+}
+
+// Requeue puts dequeued requests back to the front of the queue, e.g. if sending the funds failed.
+func (q *RequestQueue) Requeue(requests ...*Request) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	requeued := make([]*Request, 0, len(requests))
+	for _, request := range requests {
+		// requests that were marked as done in the meantime are not requeued
+		if q.pending[request.Address.Key()] == request {
+			requeued = append(requeued, request)
+		}
+	}
+
+	q.queue = append(requeued, q.queue...)
+}
+
+// Done marks requests as done, so their addresses can request funds again.
+// Requests that were requeued in the meantime are removed from the queue.
+func (q *RequestQueue) Done(requests ...*Request) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	done := make(map[*Request]struct{}, len(requests))
+	for _, request := range requests {
+		if q.pending[request.Address.Key()] == request {
+			delete(q.pending, request.Address.Key())
+			done[request] = struct{}{}
+		}
+	}
+
+	queue := q.queue[:0]
+	for _, request := range q.queue {
+		if _, isDone := done[request]; !isDone {
+			queue = append(queue, request)
+		}
+	}
+	q.queue = queue
+}
+
+// IsPending returns true if there is a pending request for the address.
+func (q *RequestQueue) IsPending(address iotago.Address) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	_, exists := q.pending[address.Key()]
+
+	return exists
+}
+
+// Len returns the amount of requests in the queue, without the dequeued ones.
+func (q *RequestQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.queue)
+}
+
+// PendingLen returns the amount of pending requests, including the dequeued ones that are not done yet.
+func (q *RequestQueue) PendingLen() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.pending)
+}
@@ -0,0 +1,205 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// RouteAdminLogLevels is the route to get and set the log levels of the extension.
+	RouteAdminLogLevels = "/log/levels"
+	// RouteAdminStatus is the route to get the status of the node connection and the components of the extension.
+	RouteAdminStatus = "/status"
+	// RouteAdminProfile is the route to get a runtime profile, e.g. "goroutine", "heap" or "profile" (CPU profile).
+	RouteAdminProfile = "/debug/pprof/:profile"
+
+	// ParameterProfile is the name of the profile parameter of RouteAdminProfile.
+	ParameterProfile = "profile"
+)
+
+// AdminComponentStatusFunc returns the status of a component of the extension, which is serialized as JSON.
+type AdminComponentStatusFunc func() any
+
+// LogLevelResponse defines the response of the log levels route.
+type LogLevelResponse struct {
+	// Levels are the log levels by the name of the logger.
+	Levels map[string]string `json:"levels"`
+}
+
+// LogLevelRequest defines the request to change the log level of a logger.
+type LogLevelRequest struct {
+	// Logger is the name of the logger, the level of all loggers is changed if it is empty.
+	Logger string `json:"logger,omitempty"`
+	// Level is the new log level, e.g. "debug".
+	Level string `json:"level"`
+}
+
+// AdminStatusResponse defines the response of the admin status route.
+type AdminStatusResponse struct {
+	// NodeHealthy is true if the node is healthy.
+	NodeHealthy bool `json:"nodeHealthy"`
+	// NodeSynced is true if the node is synced.
+	NodeSynced bool `json:"nodeSynced"`
+	// LastAcceptedBlockSlot is the slot of the last block that was accepted by the node.
+	LastAcceptedBlockSlot uint32 `json:"lastAcceptedBlockSlot"`
+	// LastConfirmedBlockSlot is the slot of the last block that was confirmed by the node.
+	LastConfirmedBlockSlot uint32 `json:"lastConfirmedBlockSlot"`
+	// PruningEpoch is the last epoch that was pruned by the node.
+	PruningEpoch uint32 `json:"pruningEpoch"`
+	// Components are the status of the components of the extension by their name.
+	Components map[string]any `json:"components,omitempty"`
+}
+
+// AdminRoutes contains the options of the admin routes.
+type AdminRoutes struct {
+	loggers          map[string]log.Logger
+	componentsStatus map[string]AdminComponentStatusFunc
+	profilingEnabled bool
+	allow            JWTAuthAllowFunc
+}
+
+// WithAdminLoggers sets the loggers whose level can be changed via the admin routes.
+// The loggers are identified by their name.
+func WithAdminLoggers(loggers ...log.Logger) options.Option[AdminRoutes] {
+	return func(a *AdminRoutes) {
+		for _, logger := range loggers {
+			a.loggers[logger.LogName()] = logger
+		}
+	}
+}
+
+// WithAdminComponentStatus adds the status of a component to the admin status route.
+func WithAdminComponentStatus(name string, statusFunc AdminComponentStatusFunc) options.Option[AdminRoutes] {
+	return func(a *AdminRoutes) {
+		a.componentsStatus[name] = statusFunc
+	}
+}
+
+// WithAdminProfiling enables or disables the pprof profiles. They are enabled by default.
+func WithAdminProfiling(enabled bool) options.Option[AdminRoutes] {
+	return func(a *AdminRoutes) {
+		a.profilingEnabled = enabled
+	}
+}
+
+// WithAdminAllowFunc sets the function that decides whether the claims of a JWT grant access to the admin routes.
+// By default, only tokens that are valid for the API are accepted.
+func WithAdminAllowFunc(allow JWTAuthAllowFunc) options.Option[AdminRoutes] {
+	return func(a *AdminRoutes) {
+		a.allow = allow
+	}
+}
+
+// RegisterAdminRoutes registers the admin routes below the given prefix, e.g. "/admin".
+// All admin routes require a valid JWT, they expose:
+//   - the log levels of the loggers, which can be changed at runtime via a PUT request,
+//   - the status of the node and the components of the extension,
+//   - the pprof profiles of the extension, e.g. goroutine and heap profiles.
+//
+// The admin routes are meant for the operators of an extension and must not be exposed via the API route of the node.
+func RegisterAdminRoutes(e *echo.Echo, prefix string, jwtAuth *JWTAuth, nodeHealthProvider NodeHealthProvider, opts ...options.Option[AdminRoutes]) (*echo.Group, error) {
+	adminRoutes := options.Apply(&AdminRoutes{
+		loggers:          make(map[string]log.Logger),
+		componentsStatus: make(map[string]AdminComponentStatusFunc),
+		profilingEnabled: true,
+	}, opts)
+
+	protectedRoutes, err := NewRouteMatcher([]string{prefix, prefix + "/*"})
+	if err != nil {
+		return nil, ierrors.Wrap(err, "invalid admin route prefix")
+	}
+
+	group := e.Group(prefix, jwtAuth.Middleware(nil, protectedRoutes, adminRoutes.allow))
+
+	group.GET(RouteAdminLogLevels, func(c echo.Context) error {
+		return JSONResponse(c, http.StatusOK, adminRoutes.logLevelResponse())
+	})
+
+	group.PUT(RouteAdminLogLevels, func(c echo.Context) error {
+		request := &LogLevelRequest{}
+		if err := c.Bind(request); err != nil {
+			return ierrors.Wrapf(ErrInvalidParameter, "invalid request, error: %s", err)
+		}
+
+		level, err := log.LevelFromString(request.Level)
+		if err != nil {
+			return ierrors.Wrapf(ErrInvalidParameter, "invalid log level, error: %s", err)
+		}
+
+		if request.Logger == "" {
+			for _, logger := range adminRoutes.loggers {
+				logger.SetLogLevel(level)
+			}
+
+			return JSONResponse(c, http.StatusOK, adminRoutes.logLevelResponse())
+		}
+
+		logger, exists := adminRoutes.loggers[request.Logger]
+		if !exists {
+			return ierrors.Wrapf(echo.ErrNotFound, "logger %s not found", request.Logger)
+		}
+		logger.SetLogLevel(level)
+
+		return JSONResponse(c, http.StatusOK, adminRoutes.logLevelResponse())
+	})
+
+	group.GET(RouteAdminStatus, func(c echo.Context) error {
+		nodeStatus := nodeHealthProvider.NodeStatus()
+
+		response := &AdminStatusResponse{
+			NodeHealthy:            nodeHealthProvider.IsNodeHealthy(),
+			NodeSynced:             nodeStatus.GetIsBootstrapped(),
+			LastAcceptedBlockSlot:  nodeStatus.GetLastAcceptedBlockSlot(),
+			LastConfirmedBlockSlot: nodeStatus.GetLastConfirmedBlockSlot(),
+			PruningEpoch:           nodeStatus.GetPruningEpoch(),
+		}
+
+		if len(adminRoutes.componentsStatus) > 0 {
+			response.Components = make(map[string]any, len(adminRoutes.componentsStatus))
+			for name, statusFunc := range adminRoutes.componentsStatus {
+				response.Components[name] = statusFunc()
+			}
+		}
+
+		return JSONResponse(c, http.StatusOK, response)
+	})
+
+	if adminRoutes.profilingEnabled {
+		group.GET(RouteAdminProfile, func(c echo.Context) error {
+			switch name := c.Param(ParameterProfile); name {
+			case "profile":
+				pprof.Profile(c.Response(), c.Request())
+			case "trace":
+				pprof.Trace(c.Response(), c.Request())
+			default:
+				pprof.Handler(name).ServeHTTP(c.Response(), c.Request())
+			}
+
+			return nil
+		})
+	}
+
+	return group, nil
+}
+
+func (a *AdminRoutes) logLevelResponse() *LogLevelResponse {
+	names := make([]string, 0, len(a.loggers))
+	for name := range a.loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	levels := make(map[string]string, len(names))
+	for _, name := range names {
+		levels[name] = log.LevelName(a.loggers[name].LogLevel())
+	}
+
+	return &LogLevelResponse{Levels: levels}
+}
@@ -0,0 +1,157 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// APIRoutePrefix is the prefix of the routes that are registered at the node.
+	APIRoutePrefix = "/api/"
+
+	// DefaultAPIRouteRegisterTimeout is the default timeout for registering the route at the node.
+	DefaultAPIRouteRegisterTimeout = 5 * time.Second
+	// DefaultAPIRouteShutdownTimeout is the default timeout for unregistering the route and shutting down the server.
+	DefaultAPIRouteShutdownTimeout = 5 * time.Second
+)
+
+// APIRouteRegistrar registers the routes of an extension at the node, e.g. the NodeBridge.
+type APIRouteRegistrar interface {
+	// RegisterAPIRoute registers the given API route.
+	// The address is the address on which the node reaches the extension.
+	RegisterAPIRoute(ctx context.Context, route string, address string, path string) error
+	// UnregisterAPIRoute unregisters the given API route.
+	UnregisterAPIRoute(ctx context.Context, route string) error
+}
+
+// APIRoute serves the routes of an extension below "/api/<route>" and registers the route at the node,
+// so the node proxies the requests to the extension.
+type APIRoute struct {
+	echo        *echo.Echo
+	group       *echo.Group
+	registrar   APIRouteRegistrar
+	route       string
+	bindAddress string
+
+	externalAddress string
+	registerTimeout time.Duration
+	shutdownTimeout time.Duration
+}
+
+// WithAPIRouteExternalAddress sets the address on which the node reaches the server, if it differs from the bind address,
+// e.g. if the server is behind a reverse proxy or listens on all interfaces.
+// The address is either a "host:port" pair or a URL, see nodebridge.ParseAPIRouteAddress.
+// An empty address is ignored.
+func WithAPIRouteExternalAddress(address string) options.Option[APIRoute] {
+	return func(r *APIRoute) {
+		if address != "" {
+			r.externalAddress = address
+		}
+	}
+}
+
+// WithAPIRouteRegisterTimeout sets the timeout for registering the route at the node.
+func WithAPIRouteRegisterTimeout(timeout time.Duration) options.Option[APIRoute] {
+	return func(r *APIRoute) {
+		r.registerTimeout = timeout
+	}
+}
+
+// WithAPIRouteShutdownTimeout sets the timeout for unregistering the route at the node and shutting down the server.
+func WithAPIRouteShutdownTimeout(timeout time.Duration) options.Option[APIRoute] {
+	return func(r *APIRoute) {
+		r.shutdownTimeout = timeout
+	}
+}
+
+// NewAPIRoute creates a new APIRoute that serves the given Echo instance on the bind address
+// and registers the route at the node via the registrar.
+func NewAPIRoute(e *echo.Echo, registrar APIRouteRegistrar, route string, bindAddress string, opts ...options.Option[APIRoute]) *APIRoute {
+	return options.Apply(&APIRoute{
+		echo:            e,
+		group:           e.Group(APIRoutePath(route)),
+		registrar:       registrar,
+		route:           route,
+		bindAddress:     bindAddress,
+		externalAddress: bindAddress,
+		registerTimeout: DefaultAPIRouteRegisterTimeout,
+		shutdownTimeout: DefaultAPIRouteShutdownTimeout,
+	}, opts)
+}
+
+// APIRoutePath returns the path below which the node proxies the requests of the given route.
+func APIRoutePath(route string) string {
+	return APIRoutePrefix + route
+}
+
+// Group returns the route group below "/api/<route>" to add the routes of the extension to.
+func (r *APIRoute) Group() *echo.Group {
+	return r.group
+}
+
+// Path returns the path of the route group.
+func (r *APIRoute) Path() string {
+	return APIRoutePath(r.route)
+}
+
+// Run starts the HTTP server, registers the route at the node and blocks until the context is canceled.
+// Afterwards the route is unregistered at the node and the server is shut down gracefully.
+// It returns an error if the server could not be started, the route could not be registered
+// or the server stopped unexpectedly. Errors during the shutdown are returned as well.
+func (r *APIRoute) Run(ctx context.Context) error {
+	serverErrChan := make(chan error, 1)
+	go func() {
+		if err := r.echo.Start(r.bindAddress); err != nil && !ierrors.Is(err, http.ErrServerClosed) {
+			serverErrChan <- err
+		}
+		close(serverErrChan)
+	}()
+
+	ctxRegister, cancelRegister := context.WithTimeout(ctx, r.registerTimeout)
+	defer cancelRegister()
+
+	if err := r.registrar.RegisterAPIRoute(ctxRegister, r.route, r.externalAddress, r.Path()); err != nil {
+		//nolint:contextcheck // the server needs to be shut down even if the context is already canceled
+		return ierrors.Join(ierrors.Wrapf(err, "failed to register API route %s", r.route), r.shutdownServer())
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+	case err, ok := <-serverErrChan:
+		if ok {
+			runErr = ierrors.Wrapf(err, "API server on %s stopped", r.bindAddress)
+		}
+	}
+
+	//nolint:contextcheck // the route needs to be unregistered even if the context is already canceled
+	return ierrors.Join(runErr, r.unregisterRoute(), r.shutdownServer())
+}
+
+func (r *APIRoute) unregisterRoute() error {
+	ctxUnregister, cancelUnregister := context.WithTimeout(context.Background(), r.shutdownTimeout)
+	defer cancelUnregister()
+
+	if err := r.registrar.UnregisterAPIRoute(ctxUnregister, r.route); err != nil {
+		return ierrors.Wrapf(err, "failed to unregister API route %s", r.route)
+	}
+
+	return nil
+}
+
+func (r *APIRoute) shutdownServer() error {
+	ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), r.shutdownTimeout)
+	defer cancelShutdown()
+
+	if err := r.echo.Shutdown(ctxShutdown); err != nil {
+		return ierrors.Wrapf(err, "failed to shut down API server on %s", r.bindAddress)
+	}
+
+	return nil
+}
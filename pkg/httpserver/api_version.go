@@ -0,0 +1,96 @@
+package httpserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderDeprecation is the header that marks a deprecated API version.
+	HeaderDeprecation = "Deprecation"
+	// HeaderSunset is the header that contains the date after which an API version will be removed.
+	HeaderSunset = "Sunset"
+
+	// RouteAPIVersions is the route to list the available API versions.
+	RouteAPIVersions = "/versions"
+)
+
+// APIVersion describes a version of an extension API.
+type APIVersion struct {
+	// Version is the name of the version, e.g. "v1".
+	Version string
+	// Deprecated marks the version as deprecated.
+	Deprecated bool
+	// Sunset is the time after which the version will be removed.
+	// It is ignored if it is zero.
+	Sunset time.Time
+}
+
+// APIVersionResponse defines a single API version in the APIVersionsResponse.
+type APIVersionResponse struct {
+	Version    string `json:"version"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+	Sunset     string `json:"sunset,omitempty"`
+}
+
+// APIVersionsResponse defines the response of the API versions route.
+type APIVersionsResponse struct {
+	Versions []*APIVersionResponse `json:"versions"`
+}
+
+// VersionedAPI mounts versioned route groups below a common prefix, e.g. "/api/<ext>/v1".
+type VersionedAPI struct {
+	group    *echo.Group
+	versions []*APIVersion
+}
+
+// NewVersionedAPI creates a new VersionedAPI below the given prefix and
+// registers the route that lists the available API versions.
+func NewVersionedAPI(e *echo.Echo, prefix string) *VersionedAPI {
+	v := &VersionedAPI{
+		group:    e.Group(prefix),
+		versions: make([]*APIVersion, 0),
+	}
+
+	v.group.GET(RouteAPIVersions, func(c echo.Context) error {
+		versions := make([]*APIVersionResponse, 0, len(v.versions))
+		for _, version := range v.versions {
+			versionResponse := &APIVersionResponse{
+				Version:    version.Version,
+				Deprecated: version.Deprecated,
+			}
+			if !version.Sunset.IsZero() {
+				versionResponse.Sunset = version.Sunset.UTC().Format(http.TimeFormat)
+			}
+
+			versions = append(versions, versionResponse)
+		}
+
+		return JSONResponse(c, http.StatusOK, &APIVersionsResponse{Versions: versions})
+	})
+
+	return v
+}
+
+// Version mounts a route group for the given API version.
+// Responses of deprecated versions contain the Deprecation and Sunset headers.
+func (v *VersionedAPI) Version(version *APIVersion) *echo.Group {
+	v.versions = append(v.versions, version)
+
+	if !version.Deprecated {
+		return v.group.Group("/" + version.Version)
+	}
+
+	return v.group.Group("/"+version.Version, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(HeaderDeprecation, "true")
+			if !version.Sunset.IsZero() {
+				c.Response().Header().Set(HeaderSunset, version.Sunset.UTC().Format(http.TimeFormat))
+			}
+
+			return next(c)
+		}
+	})
+}
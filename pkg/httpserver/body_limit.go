@@ -0,0 +1,69 @@
+package httpserver
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// ParametersRequestLimits contains the definition of the request limit parameters.
+// It can be embedded into the parameters of the REST API component of an extension.
+type ParametersRequestLimits struct {
+	// MaxBodySize defines the maximum size of request bodies in bytes.
+	MaxBodySize int64 `default:"1048576" usage:"the maximum size of request bodies in bytes (0 to disable)"`
+	// DecompressionEnabled defines whether gzip and deflate encoded request bodies are decompressed.
+	DecompressionEnabled bool `default:"false" usage:"whether gzip and deflate encoded request bodies are decompressed"`
+	// MaxDecompressedBodySize defines the maximum size of decompressed request bodies in bytes.
+	MaxDecompressedBodySize int64 `default:"4194304" usage:"the maximum size of decompressed request bodies in bytes"`
+}
+
+// BodyLimitMiddleware returns a middleware that rejects requests with bodies larger than limit bytes
+// with ErrRequestEntityTooLarge. Requests without a content length are limited while the body is read.
+func BodyLimitMiddleware(limit int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Body == nil {
+				return next(c)
+			}
+
+			if req.ContentLength > limit {
+				return ErrRequestEntityTooLarge
+			}
+
+			req.Body = newLimitedReadCloser(req.Body, req.Body, limit)
+
+			return next(c)
+		}
+	}
+}
+
+// WithBodyLimit rejects requests with bodies larger than limit bytes.
+// The limit applies to the body as it was sent, so compressed bodies are checked before they are decompressed.
+func WithBodyLimit(limit int64) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.bodyLimit = limit
+	}
+}
+
+// WithDecompression decompresses gzip and deflate encoded request bodies,
+// reading more than maxDecompressedSize bytes from a decompressed body results in ErrRequestEntityTooLarge.
+func WithDecompression(maxDecompressedSize int64) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.maxDecompressedSize = maxDecompressedSize
+	}
+}
+
+// WithRequestLimitsParameters configures the body limit and the decompression of request bodies by the given parameters.
+func WithRequestLimitsParameters(params *ParametersRequestLimits) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		if params == nil {
+			return
+		}
+
+		o.bodyLimit = params.MaxBodySize
+		if params.DecompressionEnabled {
+			o.maxDecompressedSize = params.MaxDecompressedBodySize
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package httpserver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotaapi "github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultCompressionMinBinarySize is the default size in bytes below which binary serializer responses are not compressed.
+	DefaultCompressionMinBinarySize = 1024
+)
+
+// DefaultCompressionSkippedContentTypes are the content types (or prefixes of content types)
+// of responses that are never compressed, because they are already compressed or streamed as events.
+var DefaultCompressionSkippedContentTypes = []string{
+	"text/event-stream",
+	"application/gzip",
+	"application/zip",
+	"image/",
+	"audio/",
+	"video/",
+}
+
+// CompressionOptions are the options of CompressMiddleware.
+type CompressionOptions struct {
+	// level is the compression level of gzip and deflate.
+	level int
+	// minBinarySize is the size in bytes below which binary serializer responses are not compressed.
+	minBinarySize int
+	// encodings are the supported content encodings in the order of preference.
+	encodings []string
+	// skippedContentTypes are the content types (or prefixes of content types) of responses that are never compressed.
+	skippedContentTypes []string
+}
+
+// WithCompressionLevel sets the compression level of gzip and deflate, e.g. gzip.BestSpeed.
+func WithCompressionLevel(level int) options.Option[CompressionOptions] {
+	return func(o *CompressionOptions) {
+		o.level = level
+	}
+}
+
+// WithCompressionMinBinarySize sets the size in bytes below which binary serializer responses are not compressed.
+// Binary serializer responses are already compact, so compressing small ones only costs CPU time.
+func WithCompressionMinBinarySize(size int) options.Option[CompressionOptions] {
+	return func(o *CompressionOptions) {
+		o.minBinarySize = size
+	}
+}
+
+// WithCompressionEncodings sets the supported content encodings in the order of preference.
+// Supported encodings: EncodingGzip, EncodingDeflate.
+func WithCompressionEncodings(encodings ...string) options.Option[CompressionOptions] {
+	return func(o *CompressionOptions) {
+		o.encodings = encodings
+	}
+}
+
+// WithCompressionSkippedContentTypes sets the content types (or prefixes of content types) of responses that are never compressed.
+func WithCompressionSkippedContentTypes(contentTypes ...string) options.Option[CompressionOptions] {
+	return func(o *CompressionOptions) {
+		o.skippedContentTypes = contentTypes
+	}
+}
+
+// WithCompression compresses the responses of all routes with gzip or deflate,
+// depending on the accept encoding header of the request.
+// Use CompressMiddleware to compress the responses of single route groups instead.
+func WithCompression(opts ...options.Option[CompressionOptions]) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.compressionOptions = opts
+		o.compressionEnabled = true
+	}
+}
+
+// CompressMiddleware returns a middleware that compresses responses with gzip or deflate,
+// depending on the accept encoding header of the request.
+// The middleware can be added to single route groups, so every group can use its own options.
+//
+// JSON and other text responses are always compressed, binary serializer responses only if they reach
+// the minimum size, and responses with a skipped content type or an existing content encoding never.
+// It panics if the compression level or an encoding is invalid.
+func CompressMiddleware(opts ...options.Option[CompressionOptions]) echo.MiddlewareFunc {
+	compressionOpts := options.Apply(&CompressionOptions{
+		level:               gzip.DefaultCompression,
+		minBinarySize:       DefaultCompressionMinBinarySize,
+		encodings:           []string{EncodingGzip, EncodingDeflate},
+		skippedContentTypes: DefaultCompressionSkippedContentTypes,
+	}, opts)
+
+	compressorPools := make(map[string]*sync.Pool, len(compressionOpts.encodings))
+	for _, encoding := range compressionOpts.encodings {
+		pool, err := newCompressorPool(encoding, compressionOpts.level)
+		if err != nil {
+			panic(err)
+		}
+		compressorPools[encoding] = pool
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			res := c.Response()
+
+			// protocol upgrades (e.g. websockets) take over the connection
+			if req.Header.Get(echo.HeaderUpgrade) != "" {
+				return next(c)
+			}
+
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			encoding := negotiateEncoding(req.Header.Get(echo.HeaderAcceptEncoding), compressionOpts.encodings)
+			if encoding == "" {
+				return next(c)
+			}
+
+			writer := &compressResponseWriter{
+				ResponseWriter: res.Writer,
+				options:        compressionOpts,
+				encoding:       encoding,
+				compressorPool: compressorPools[encoding],
+			}
+
+			originalWriter := res.Writer
+			res.Writer = writer
+			defer func() {
+				_ = writer.close()
+				res.Writer = originalWriter
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// compressor is a compressing writer of the gzip or zlib package.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+func newCompressorPool(encoding string, level int) (*sync.Pool, error) {
+	var newCompressor func() (compressor, error)
+	switch encoding {
+	case EncodingGzip:
+		newCompressor = func() (compressor, error) { return gzip.NewWriterLevel(io.Discard, level) }
+	case EncodingDeflate:
+		newCompressor = func() (compressor, error) { return zlib.NewWriterLevel(io.Discard, level) }
+	default:
+		return nil, ierrors.Errorf("unsupported content encoding: %s", encoding)
+	}
+
+	// check the compression level once, so the pool can't fail later
+	if _, err := newCompressor(); err != nil {
+		return nil, ierrors.Wrapf(err, "invalid compression level for %s: %d", encoding, level)
+	}
+
+	return &sync.Pool{
+		New: func() any {
+			c, _ := newCompressor()
+			return c
+		},
+	}, nil
+}
+
+// negotiateEncoding returns the first of the supported encodings that is accepted by the given accept encoding header,
+// or an empty string if none of them is accepted.
+func negotiateEncoding(acceptEncoding string, supportedEncodings []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := make(map[string]struct{})
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		encoding, params, _ := strings.Cut(part, ";")
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+
+		// an encoding with a quality value of 0 is not acceptable
+		quality := strings.ReplaceAll(strings.ToLower(params), " ", "")
+		if strings.HasPrefix(quality, "q=0") && strings.Trim(strings.TrimPrefix(quality, "q=0"), ".0") == "" {
+			continue
+		}
+
+		accepted[encoding] = struct{}{}
+	}
+
+	for _, encoding := range supportedEncodings {
+		if _, exists := accepted[encoding]; exists {
+			return encoding
+		}
+	}
+
+	if _, exists := accepted["*"]; exists && len(supportedEncodings) > 0 {
+		return supportedEncodings[0]
+	}
+
+	return ""
+}
+
+// compressMode is the way a response is written by the compressResponseWriter.
+type compressMode int
+
+const (
+	// compressModeUndecided means that nothing was written yet.
+	compressModeUndecided compressMode = iota
+	// compressModePassthrough writes the response uncompressed.
+	compressModePassthrough
+	// compressModeBuffer buffers a binary serializer response until it reaches the minimum size.
+	compressModeBuffer
+	// compressModeCompress writes the response compressed.
+	compressModeCompress
+)
+
+// compressResponseWriter decides on the first write, based on the headers of the response, whether it is compressed.
+type compressResponseWriter struct {
+	http.ResponseWriter
+
+	options        *CompressionOptions
+	encoding       string
+	compressorPool *sync.Pool
+
+	mode          compressMode
+	statusCode    int
+	headerWritten bool
+	buffer        []byte
+	compressor    compressor
+}
+
+func (w *compressResponseWriter) WriteHeader(statusCode int) {
+	// the header is written once it is known whether the response is compressed
+	w.statusCode = statusCode
+}
+
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if w.mode == compressModeUndecided {
+		w.mode = w.decideMode()
+		if w.mode == compressModeCompress {
+			w.startCompression()
+		}
+	}
+
+	switch w.mode {
+	case compressModeBuffer:
+		w.buffer = append(w.buffer, b...)
+		if len(w.buffer) < w.options.minBinarySize {
+			return len(b), nil
+		}
+
+		w.startCompression()
+		if _, err := w.compressor.Write(w.buffer); err != nil {
+			return 0, err
+		}
+		w.buffer = nil
+
+		return len(b), nil
+
+	case compressModeCompress:
+		return w.compressor.Write(b)
+
+	default:
+		w.writeHeader()
+
+		return w.ResponseWriter.Write(b)
+	}
+}
+
+// decideMode decides how the response is written based on its status code and headers.
+func (w *compressResponseWriter) decideMode() compressMode {
+	header := w.Header()
+
+	if w.statusCode == http.StatusNoContent || w.statusCode == http.StatusNotModified || header.Get(echo.HeaderContentEncoding) != "" {
+		return compressModePassthrough
+	}
+
+	contentType := strings.ToLower(header.Get(echo.HeaderContentType))
+	for _, skippedContentType := range w.options.skippedContentTypes {
+		if strings.HasPrefix(contentType, skippedContentType) {
+			return compressModePassthrough
+		}
+	}
+
+	if strings.HasPrefix(contentType, iotaapi.MIMEApplicationVendorIOTASerializerV2) && w.options.minBinarySize > 0 {
+		return compressModeBuffer
+	}
+
+	return compressModeCompress
+}
+
+func (w *compressResponseWriter) startCompression() {
+	w.mode = compressModeCompress
+
+	header := w.Header()
+	header.Set(echo.HeaderContentEncoding, w.encoding)
+	header.Del(echo.HeaderContentLength)
+	w.writeHeader()
+
+	//nolint:forcetypeassert // the pool only contains compressors
+	w.compressor = w.compressorPool.Get().(compressor)
+	w.compressor.Reset(w.ResponseWriter)
+}
+
+func (w *compressResponseWriter) writeHeader() {
+	if w.headerWritten {
+		return
+	}
+	w.headerWritten = true
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.statusCode)
+}
+
+// flushBuffer writes a buffered response that didn't reach the minimum size uncompressed.
+func (w *compressResponseWriter) flushBuffer() error {
+	w.mode = compressModePassthrough
+	w.writeHeader()
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	_, err := w.ResponseWriter.Write(buffer)
+
+	return err
+}
+
+func (w *compressResponseWriter) Flush() {
+	//nolint:exhaustive // nothing is pending in the other modes
+	switch w.mode {
+	case compressModeBuffer:
+		// the handler wants the data to be sent now, so there is no point in waiting for the minimum size
+		_ = w.flushBuffer()
+	case compressModeCompress:
+		_ = w.compressor.Flush()
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, ierrors.New("response writer does not support hijacking")
+	}
+
+	return hijacker.Hijack()
+}
+
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close finishes the response after the handler returned.
+func (w *compressResponseWriter) close() error {
+	switch w.mode {
+	case compressModeUndecided:
+		// only the status code was set, or nothing at all
+		if w.statusCode != 0 {
+			w.writeHeader()
+		}
+
+		return nil
+
+	case compressModeBuffer:
+		return w.flushBuffer()
+
+	case compressModeCompress:
+		err := w.compressor.Close()
+		w.compressor.Reset(io.Discard)
+		w.compressorPool.Put(w.compressor)
+		w.compressor = nil
+
+		return err
+
+	default:
+		return nil
+	}
+}
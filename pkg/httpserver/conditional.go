@@ -0,0 +1,103 @@
+package httpserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// ETagForCommitmentID returns a weak ETag for responses that only change if the given commitment changes.
+func ETagForCommitmentID(commitmentID iotago.CommitmentID) string {
+	return `W/"` + commitmentID.ToHex() + `"`
+}
+
+// ETagForSlot returns a weak ETag for responses that only change if the given slot changes.
+func ETagForSlot(slot iotago.SlotIndex) string {
+	return `W/"slot-` + strconv.FormatUint(uint64(slot), 10) + `"`
+}
+
+// LastModifiedForSlot returns the end time of the given slot, which can be used
+// as the Last-Modified time of responses that belong to a committed slot.
+func LastModifiedForSlot(api iotago.API, slot iotago.SlotIndex) time.Time {
+	return api.TimeProvider().SlotEndTime(slot)
+}
+
+// CheckConditionalRequest sets the ETag and Last-Modified headers of the response
+// and returns true if the representation cached by the client is still valid,
+// so the response can be answered with 304 Not Modified.
+// Empty etags and zero lastModified times are ignored.
+// If-None-Match takes precedence over If-Modified-Since (RFC 9110).
+func CheckConditionalRequest(c echo.Context, etag string, lastModified time.Time) bool {
+	header := c.Response().Header()
+	header.Add(echo.HeaderVary, echo.HeaderAccept)
+
+	if etag != "" {
+		header.Set(headerETag, etag)
+	}
+	if !lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	request := c.Request()
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := request.Header.Get(headerIfNoneMatch); ifNoneMatch != "" {
+		return etag != "" && etagMatches(ifNoneMatch, etag)
+	}
+
+	if ifModifiedSince := request.Header.Get(echo.HeaderIfModifiedSince); ifModifiedSince != "" && !lastModified.IsZero() {
+		modifiedSince, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+
+		// the header only has a precision of seconds
+		return !lastModified.Truncate(time.Second).After(modifiedSince)
+	}
+
+	return false
+}
+
+// SendConditionalResponseByHeader answers the request with 304 Not Modified if the representation cached by the client
+// is still valid, otherwise it sends the object returned by objFunc based on the MIME type in the accept header.
+// The object is only loaded if it needs to be sent.
+func SendConditionalResponseByHeader(c echo.Context, api iotago.API, etag string, lastModified time.Time, objFunc func() (any, error)) error {
+	if CheckConditionalRequest(c, etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	obj, err := objFunc()
+	if err != nil {
+		return err
+	}
+
+	return SendResponseByHeader(c, api, obj)
+}
+
+// etagMatches returns true if the If-None-Match header contains the given ETag.
+// ETags are compared with the weak comparison function.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package httpserver
+
+import (
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// ParametersCORS contains the definition of the CORS parameters.
+// It can be embedded into the parameters of the REST API component of an extension.
+type ParametersCORS struct {
+	// Enabled defines whether the CORS middleware is enabled.
+	Enabled bool `default:"false" usage:"whether the CORS middleware is enabled"`
+	// AllowOrigins defines the origins that are allowed to access the API.
+	AllowOrigins []string `default:"*" usage:"the origins that are allowed to access the API"`
+	// AllowMethods defines the methods that are allowed to access the API.
+	AllowMethods []string `default:"GET,HEAD,PUT,PATCH,POST,DELETE" usage:"the methods that are allowed to access the API"`
+	// AllowHeaders defines the request headers that are allowed to be used.
+	AllowHeaders []string `default:"" usage:"the request headers that are allowed to be used (empty to allow the headers of the preflight request)"`
+	// AllowCredentials defines whether the response can be exposed if the request contains credentials.
+	AllowCredentials bool `default:"false" usage:"whether the response can be exposed if the request contains credentials"`
+	// MaxAge defines how long (in seconds) the result of a preflight request can be cached.
+	MaxAge int `default:"0" usage:"how long (in seconds) the result of a preflight request can be cached"`
+}
+
+// WithCORS adds the CORS middleware with the given config to the Echo instance.
+func WithCORS(config middleware.CORSConfig) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.corsConfig = &config
+	}
+}
+
+// WithCORSParameters adds the CORS middleware configured by the given parameters to the Echo instance.
+// The middleware is only added if it is enabled in the parameters.
+func WithCORSParameters(params *ParametersCORS) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		if params == nil || !params.Enabled {
+			return
+		}
+
+		o.corsConfig = &middleware.CORSConfig{
+			AllowOrigins:     params.AllowOrigins,
+			AllowMethods:     params.AllowMethods,
+			AllowHeaders:     params.AllowHeaders,
+			AllowCredentials: params.AllowCredentials,
+			MaxAge:           params.MaxAge,
+		}
+	}
+}
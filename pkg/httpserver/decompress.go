@@ -0,0 +1,115 @@
+package httpserver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// EncodingGzip is the gzip content encoding.
+	EncodingGzip = "gzip"
+	// EncodingDeflate is the deflate content encoding.
+	EncodingDeflate = "deflate"
+)
+
+// ErrRequestEntityTooLarge defines the request entity too large error.
+var ErrRequestEntityTooLarge = echo.NewHTTPError(http.StatusRequestEntityTooLarge, "request entity too large")
+
+// limitedReadCloser returns ErrRequestEntityTooLarge if more than limit bytes are read.
+type limitedReadCloser struct {
+	reader    io.Reader
+	closer    io.Closer
+	remaining int64
+}
+
+func newLimitedReadCloser(reader io.Reader, closer io.Closer, limit int64) *limitedReadCloser {
+	return &limitedReadCloser{
+		reader: reader,
+		closer: closer,
+		// we allow to read one more byte to detect if the limit was exceeded
+		remaining: limit + 1,
+	}
+}
+
+func (r *limitedReadCloser) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining <= 0 {
+		return n, ErrRequestEntityTooLarge
+	}
+
+	return n, err
+}
+
+func (r *limitedReadCloser) Close() error {
+	return r.closer.Close()
+}
+
+// DecompressMiddleware returns a middleware that decompresses gzip and deflate encoded request bodies.
+// Reading more than maxDecompressedSize bytes from the decompressed body results in ErrRequestEntityTooLarge,
+// which protects against decompression bombs.
+func DecompressMiddleware(maxDecompressedSize int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			encoding := strings.ToLower(strings.TrimSpace(req.Header.Get(echo.HeaderContentEncoding)))
+			if encoding == "" || encoding == "identity" || req.Body == nil {
+				return next(c)
+			}
+
+			if encoding != EncodingGzip && encoding != EncodingDeflate {
+				return ierrors.Wrapf(echo.ErrUnsupportedMediaType, "unsupported content encoding: %s", encoding)
+			}
+
+			compressedBody := bufio.NewReader(req.Body)
+			if _, err := compressedBody.Peek(1); ierrors.Is(err, io.EOF) {
+				// empty body
+				return next(c)
+			}
+
+			var decompressed io.ReadCloser
+			switch encoding {
+			case EncodingGzip:
+				gzipReader, err := gzip.NewReader(compressedBody)
+				if err != nil {
+					return ierrors.Errorf("%w: invalid gzip encoded request body: %w", ErrInvalidParameter, err)
+				}
+				decompressed = gzipReader
+
+			case EncodingDeflate:
+				// the "deflate" content encoding is the zlib format, not a raw deflate stream (RFC 9110)
+				zlibReader, err := zlib.NewReader(compressedBody)
+				if err != nil {
+					return ierrors.Errorf("%w: invalid deflate encoded request body: %w", ErrInvalidParameter, err)
+				}
+				decompressed = zlibReader
+			}
+
+			originalBody := req.Body
+			defer func() {
+				_ = decompressed.Close()
+				_ = originalBody.Close()
+			}()
+
+			req.Body = newLimitedReadCloser(decompressed, decompressed, maxDecompressedSize)
+			req.Header.Del(echo.HeaderContentEncoding)
+			req.Header.Del(echo.HeaderContentLength)
+			req.ContentLength = -1
+
+			return next(c)
+		}
+	}
+}
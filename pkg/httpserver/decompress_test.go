@@ -0,0 +1,178 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+const testMaxDecompressedSize = 1024
+
+func compressTestData(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var compressed bytes.Buffer
+
+	var writer io.WriteCloser
+	switch encoding {
+	case httpserver.EncodingGzip:
+		writer = gzip.NewWriter(&compressed)
+	case httpserver.EncodingDeflate:
+		writer = zlib.NewWriter(&compressed)
+	default:
+		// raw deflate stream without the zlib header
+		flateWriter, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+		require.NoError(t, err)
+		writer = flateWriter
+	}
+
+	_, err := writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return compressed.Bytes()
+}
+
+func TestDecompressMiddleware(t *testing.T) {
+	payload := []byte(`{"hello":"world"}`)
+	limitPayload := bytes.Repeat([]byte{'a'}, testMaxDecompressedSize)
+	// a small body that expands far beyond the limit
+	bombPayload := bytes.Repeat([]byte{0}, 1<<20)
+
+	tests := []struct {
+		name        string
+		encoding    string
+		body        []byte
+		wantBody    []byte
+		wantErr     error
+		wantReadErr error
+	}{
+		{
+			name:     "uncompressed body",
+			body:     payload,
+			wantBody: payload,
+		},
+		{
+			name:     "identity encoding",
+			encoding: "identity",
+			body:     payload,
+			wantBody: payload,
+		},
+		{
+			name:     "gzip encoding",
+			encoding: httpserver.EncodingGzip,
+			body:     compressTestData(t, httpserver.EncodingGzip, payload),
+			wantBody: payload,
+		},
+		{
+			name:     "deflate encoding",
+			encoding: httpserver.EncodingDeflate,
+			body:     compressTestData(t, httpserver.EncodingDeflate, payload),
+			wantBody: payload,
+		},
+		{
+			name:     "encoding is case insensitive",
+			encoding: " GZIP ",
+			body:     compressTestData(t, httpserver.EncodingGzip, payload),
+			wantBody: payload,
+		},
+		{
+			name:     "empty gzip body",
+			encoding: httpserver.EncodingGzip,
+			body:     []byte{},
+			wantBody: []byte{},
+		},
+		{
+			name:     "empty deflate body",
+			encoding: httpserver.EncodingDeflate,
+			body:     []byte{},
+			wantBody: []byte{},
+		},
+		{
+			name:     "gzip body at the limit",
+			encoding: httpserver.EncodingGzip,
+			body:     compressTestData(t, httpserver.EncodingGzip, limitPayload),
+			wantBody: limitPayload,
+		},
+		{
+			name:        "gzip body above the limit",
+			encoding:    httpserver.EncodingGzip,
+			body:        compressTestData(t, httpserver.EncodingGzip, append(limitPayload, 'a')),
+			wantReadErr: httpserver.ErrRequestEntityTooLarge,
+		},
+		{
+			name:        "gzip bomb",
+			encoding:    httpserver.EncodingGzip,
+			body:        compressTestData(t, httpserver.EncodingGzip, bombPayload),
+			wantReadErr: httpserver.ErrRequestEntityTooLarge,
+		},
+		{
+			name:        "deflate bomb",
+			encoding:    httpserver.EncodingDeflate,
+			body:        compressTestData(t, httpserver.EncodingDeflate, bombPayload),
+			wantReadErr: httpserver.ErrRequestEntityTooLarge,
+		},
+		{
+			name:     "invalid gzip body",
+			encoding: httpserver.EncodingGzip,
+			body:     payload,
+			wantErr:  httpserver.ErrInvalidParameter,
+		},
+		{
+			name:     "raw deflate body without zlib header",
+			encoding: httpserver.EncodingDeflate,
+			body:     compressTestData(t, "", payload),
+			wantErr:  httpserver.ErrInvalidParameter,
+		},
+		{
+			name:     "unsupported encoding",
+			encoding: "br",
+			body:     payload,
+			wantErr:  echo.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(test.body))
+			if test.encoding != "" {
+				req.Header.Set(echo.HeaderContentEncoding, test.encoding)
+			}
+
+			var body []byte
+			var readErr error
+			err := httpserver.DecompressMiddleware(testMaxDecompressedSize)(func(c echo.Context) error {
+				body, readErr = io.ReadAll(c.Request().Body)
+
+				return nil
+			})(echo.New().NewContext(req, httptest.NewRecorder()))
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+
+			if test.wantReadErr != nil {
+				require.ErrorIs(t, readErr, test.wantReadErr)
+				require.LessOrEqual(t, len(body), testMaxDecompressedSize+1)
+
+				return
+			}
+
+			require.NoError(t, readErr)
+			require.Equal(t, test.wantBody, body)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package httpserver
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// ErrorCode is a stable, machine-readable code of an API error,
+// which is returned in the code field of the HTTPErrorResponse.
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidParameter      ErrorCode = "invalid_parameter"
+	ErrorCodeNotFound              ErrorCode = "not_found"
+	ErrorCodeNotFoundBlock         ErrorCode = "not_found_block"
+	ErrorCodeNotFoundTransaction   ErrorCode = "not_found_transaction"
+	ErrorCodeNotFoundOutput        ErrorCode = "not_found_output"
+	ErrorCodeNotFoundCommitment    ErrorCode = "not_found_commitment"
+	ErrorCodeNotFoundAccount       ErrorCode = "not_found_account"
+	ErrorCodeMethodNotAllowed      ErrorCode = "method_not_allowed"
+	ErrorCodeNotAcceptable         ErrorCode = "not_acceptable"
+	ErrorCodeUnsupportedMediaType  ErrorCode = "unsupported_media_type"
+	ErrorCodeRequestEntityTooLarge ErrorCode = "request_entity_too_large"
+	ErrorCodeUnauthorized          ErrorCode = "unauthorized"
+	ErrorCodeForbidden             ErrorCode = "forbidden"
+	ErrorCodeTooManyRequests       ErrorCode = "too_many_requests"
+	ErrorCodeLedgerNotSynced       ErrorCode = "ledger_not_synced"
+	ErrorCodeNodeNotSynced         ErrorCode = "node_not_synced"
+	ErrorCodeServiceUnavailable    ErrorCode = "service_unavailable"
+	ErrorCodeInternalError         ErrorCode = "internal_error"
+)
+
+// APIError is an API error with a status code and a machine-readable error code.
+// It can be returned by handlers directly, or wrapped with ierrors.Wrap to add context.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the machine-readable error code.
+	Code ErrorCode
+	// Message is the human-readable error message.
+	Message string
+}
+
+// NewAPIError creates a new APIError.
+func NewAPIError(statusCode int, code ErrorCode, message string) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Code:       code,
+		Message:    message,
+	}
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+var (
+	// ErrLedgerNotSynced is returned if the ledger of the extension is not synced to the node yet.
+	ErrLedgerNotSynced = NewAPIError(http.StatusServiceUnavailable, ErrorCodeLedgerNotSynced, "ledger not synced")
+	// ErrNodeNotSynced is returned if the node is not synced.
+	ErrNodeNotSynced = NewAPIError(http.StatusServiceUnavailable, ErrorCodeNodeNotSynced, "node not synced")
+)
+
+// errorCodeMapping maps errors that are not APIErrors to error codes.
+type errorCodeMapping struct {
+	err  error
+	code ErrorCode
+}
+
+var (
+	errorCodeMappingsMutex sync.RWMutex
+	errorCodeMappings      = []*errorCodeMapping{
+		{err: echo.ErrNotFound, code: ErrorCodeNotFound},
+		{err: echo.ErrMethodNotAllowed, code: ErrorCodeMethodNotAllowed},
+		{err: echo.ErrUnsupportedMediaType, code: ErrorCodeUnsupportedMediaType},
+		{err: echo.ErrStatusRequestEntityTooLarge, code: ErrorCodeRequestEntityTooLarge},
+		{err: ErrInvalidParameter, code: ErrorCodeInvalidParameter},
+		{err: ErrNotAcceptable, code: ErrorCodeNotAcceptable},
+		{err: ErrRequestEntityTooLarge, code: ErrorCodeRequestEntityTooLarge},
+		{err: ErrTooManyRequests, code: ErrorCodeTooManyRequests},
+		{err: ErrJWTMissing, code: ErrorCodeUnauthorized},
+		{err: ErrJWTInvalid, code: ErrorCodeUnauthorized},
+		{err: ErrJWTInvalidClaims, code: ErrorCodeUnauthorized},
+		{err: ErrRouteNotAccessible, code: ErrorCodeForbidden},
+		{err: ErrIPNotAllowed, code: ErrorCodeForbidden},
+	}
+)
+
+// RegisterErrorCode maps the given error, and all errors wrapping it, to the given error code.
+// Mappings that were registered later take precedence, so extensions can override the default mappings
+// or map errors of other packages (e.g. nodebridge.ErrNotFound) to their own codes.
+func RegisterErrorCode(err error, code ErrorCode) {
+	errorCodeMappingsMutex.Lock()
+	defer errorCodeMappingsMutex.Unlock()
+
+	errorCodeMappings = append(errorCodeMappings, &errorCodeMapping{err: err, code: code})
+}
+
+// ErrorCodeFromError returns the error code of the given error.
+// The code of an APIError in the chain takes precedence over the registered mappings.
+func ErrorCodeFromError(err error) (ErrorCode, bool) {
+	var apiErr *APIError
+	if ierrors.As(err, &apiErr) {
+		return apiErr.Code, true
+	}
+
+	errorCodeMappingsMutex.RLock()
+	defer errorCodeMappingsMutex.RUnlock()
+
+	for i := len(errorCodeMappings) - 1; i >= 0; i-- {
+		if ierrors.Is(err, errorCodeMappings[i].err) {
+			return errorCodeMappings[i].code, true
+		}
+	}
+
+	return "", false
+}
+
+// errorWithDetails adds details to an error.
+type errorWithDetails struct {
+	error
+	details map[string]any
+}
+
+func (e *errorWithDetails) Unwrap() error {
+	return e.error
+}
+
+// WithErrorDetails adds the given details to the error, they are returned in the details field of the HTTPErrorResponse.
+// If details are added multiple times, the outermost details are returned.
+func WithErrorDetails(err error, details map[string]any) error {
+	return &errorWithDetails{
+		error:   err,
+		details: details,
+	}
+}
+
+// ErrorDetailsFromError returns the details that were added to the error via WithErrorDetails.
+func ErrorDetailsFromError(err error) map[string]any {
+	var detailsErr *errorWithDetails
+	if ierrors.As(err, &detailsErr) {
+		return detailsErr.details
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	inx "github.com/iotaledger/inx/go"
+)
+
+const (
+	// RouteHealth is the route of the health probe.
+	RouteHealth = "/health"
+	// RouteReady is the route of the readiness probe.
+	RouteReady = "/ready"
+
+	// HealthStatusOK is the status of a successful probe.
+	HealthStatusOK = "ok"
+	// HealthStatusUnavailable is the status of a failed probe.
+	HealthStatusUnavailable = "unavailable"
+)
+
+// NodeHealthProvider provides the health and the sync status of the node, e.g. the NodeBridge.
+type NodeHealthProvider interface {
+	// IsNodeHealthy returns true if the node is healthy.
+	IsNodeHealthy() bool
+	// NodeStatus returns the current node status.
+	NodeStatus() *inx.NodeStatus
+}
+
+// ReadinessFunc returns an error if the extension is not ready to serve requests yet,
+// e.g. because its ledger is still being synced.
+type ReadinessFunc func(ctx context.Context) error
+
+// HealthResponse defines the response of the health and readiness probes.
+type HealthResponse struct {
+	// Status is either HealthStatusOK or HealthStatusUnavailable.
+	Status string `json:"status"`
+	// NodeHealthy is true if the node is healthy.
+	NodeHealthy bool `json:"nodeHealthy"`
+	// NodeSynced is true if the node is synced.
+	NodeSynced bool `json:"nodeSynced"`
+	// Error is the reason why the extension is not ready, it is only set by the readiness probe.
+	Error string `json:"error,omitempty"`
+}
+
+// RegisterHealthRoutes registers the health and readiness probes.
+// The health probe returns 200 if the node is healthy, and 503 otherwise.
+// The readiness probe additionally requires the node to be synced and the readinessFunc (if set) to succeed.
+func RegisterHealthRoutes(e *echo.Echo, nodeHealthProvider NodeHealthProvider, readinessFunc ReadinessFunc) {
+	e.GET(RouteHealth, func(c echo.Context) error {
+		response := nodeHealthResponse(nodeHealthProvider)
+
+		return healthResponse(c, response, response.NodeHealthy)
+	})
+
+	e.GET(RouteReady, func(c echo.Context) error {
+		response := nodeHealthResponse(nodeHealthProvider)
+		if !response.NodeHealthy || !response.NodeSynced {
+			return healthResponse(c, response, false)
+		}
+
+		if readinessFunc != nil {
+			if err := readinessFunc(c.Request().Context()); err != nil {
+				response.Error = err.Error()
+
+				return healthResponse(c, response, false)
+			}
+		}
+
+		return healthResponse(c, response, true)
+	})
+}
+
+func nodeHealthResponse(nodeHealthProvider NodeHealthProvider) *HealthResponse {
+	return &HealthResponse{
+		NodeHealthy: nodeHealthProvider.IsNodeHealthy(),
+		NodeSynced:  nodeHealthProvider.NodeStatus().GetIsBootstrapped(),
+	}
+}
+
+func healthResponse(c echo.Context, response *HealthResponse, ok bool) error {
+	// probes must never be answered from a cache
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	if !ok {
+		response.Status = HealthStatusUnavailable
+
+		return c.JSON(http.StatusServiceUnavailable, response)
+	}
+
+	response.Status = HealthStatusOK
+
+	return c.JSON(http.StatusOK, response)
+}
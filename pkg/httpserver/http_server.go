@@ -17,7 +17,7 @@ import (
 
 	"github.com/iotaledger/hive.go/ierrors"
 	"github.com/iotaledger/hive.go/log"
-	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	"github.com/iotaledger/hive.go/runtime/options"
 	iotago "github.com/iotaledger/iota.go/v4"
 	iotaapi "github.com/iotaledger/iota.go/v4/api"
 	"github.com/iotaledger/iota.go/v4/hexutil"
@@ -45,8 +45,14 @@ func JSONResponse(c echo.Context, statusCode int, result interface{}) error {
 
 // HTTPErrorResponse defines the error struct for the HTTPErrorResponseEnvelope.
 type HTTPErrorResponse struct {
+	// Code is the machine-readable ErrorCode of the error,
+	// or the HTTP status code if no ErrorCode is known for the error.
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// Details are optional details of the error, see WithErrorDetails.
+	Details map[string]any `json:"details,omitempty"`
+	// RequestID is the ID of the request, see WithRequestID.
+	RequestID string `json:"requestId,omitempty"`
 }
 
 // HTTPErrorResponseEnvelope defines the error response schema for node API responses.
@@ -54,31 +60,103 @@ type HTTPErrorResponseEnvelope struct {
 	Error HTTPErrorResponse `json:"error"`
 }
 
+// resolveError returns the status code, the message and the ErrorCode of the error.
+// The ErrorCode is empty if no ErrorCode is known for the error.
+func resolveError(err error) (int, string, ErrorCode) {
+	var statusCode int
+	var message string
+	var code ErrorCode
+
+	var apiErr *APIError
+	var e *echo.HTTPError
+	switch {
+	case ierrors.As(err, &apiErr):
+		statusCode = apiErr.StatusCode
+		message = fmt.Sprintf("%s, error: %s", apiErr.Message, err)
+	case ierrors.As(err, &e):
+		statusCode = e.Code
+		message = fmt.Sprintf("%s, error: %s", e.Message, err)
+	default:
+		statusCode = http.StatusInternalServerError
+		message = fmt.Sprintf("internal server error. error: %s", err)
+		code = ErrorCodeInternalError
+	}
+
+	if errorCode, exists := ErrorCodeFromError(err); exists {
+		code = errorCode
+	}
+
+	return statusCode, message, code
+}
+
 func errorHandler() func(error, echo.Context) {
 	return func(err error, c echo.Context) {
-		var statusCode int
-		var message string
+		if c.Response().Committed {
+			// the response was already (partially) sent, e.g. by a streamed response
+			return
+		}
 
-		var e *echo.HTTPError
-		if ierrors.As(err, &e) {
-			statusCode = e.Code
-			message = fmt.Sprintf("%s, error: %s", e.Message, err)
-		} else {
-			statusCode = http.StatusInternalServerError
-			message = fmt.Sprintf("internal server error. error: %s", err)
+		statusCode, message, errorCode := resolveError(err)
+
+		code := string(errorCode)
+		if code == "" {
+			code = strconv.Itoa(statusCode)
 		}
 
-		_ = c.JSON(statusCode, HTTPErrorResponseEnvelope{Error: HTTPErrorResponse{Code: strconv.Itoa(statusCode), Message: message}})
+		_ = c.JSON(statusCode, HTTPErrorResponseEnvelope{Error: HTTPErrorResponse{Code: code, Message: message, Details: ErrorDetailsFromError(err), RequestID: RequestID(c)}})
+	}
+}
+
+// echoOptions are the options of NewEcho.
+type echoOptions struct {
+	jsonSerializer echo.JSONSerializer
+	corsConfig     *middleware.CORSConfig
+	// requestID defines whether a request ID is assigned to every request.
+	requestID bool
+	// requestTracingHook is called for every request, nil disables the tracing.
+	requestTracingHook RequestTracingHook
+	// ipFilter restricts the access to the API to allowed IP addresses, nil disables the filter.
+	ipFilter *IPFilter
+	// bodyLimit is the maximum size of request bodies in bytes, 0 disables the limit.
+	bodyLimit int64
+	// maxDecompressedSize is the maximum size of decompressed request bodies in bytes, 0 disables the decompression.
+	maxDecompressedSize int64
+	// compressionEnabled defines whether the responses of all routes are compressed.
+	compressionEnabled bool
+	// compressionOptions are the options of the response compression.
+	compressionOptions []options.Option[CompressionOptions]
+	// problemJSONErrors defines whether errors are answered with application/problem+json instead of the error envelope.
+	problemJSONErrors bool
+	// problemTypeBaseURI is the base URI of the problem types, the ErrorCode is appended to it.
+	problemTypeBaseURI string
+}
+
+// WithJSONCodec sets the JSON implementation that is used by JSONResponse and the error handler.
+func WithJSONCodec(codec JSONCodec) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.jsonSerializer = NewJSONSerializer(codec)
 	}
 }
 
 // NewEcho returns a new Echo instance.
 // It hides the banner, adds a default HTTPErrorHandler and the Recover middleware.
-func NewEcho(logger log.Logger, onHTTPError func(err error, c echo.Context), debugRequestLoggerEnabled bool) *echo.Echo {
+// Violations of the IP filter, the body limit and the decompression limit are answered with the standard error envelope,
+// or with application/problem+json if WithProblemJSONErrors is set.
+// The request ID and the tracing are set up before the other middlewares, so rejected requests are correlated and traced too.
+func NewEcho(logger log.Logger, onHTTPError func(err error, c echo.Context), debugRequestLoggerEnabled bool, opts ...options.Option[echoOptions]) *echo.Echo {
+	echoOpts := options.Apply(&echoOptions{}, opts)
+
 	e := echo.New()
 	e.HideBanner = true
 
+	if echoOpts.jsonSerializer != nil {
+		e.JSONSerializer = echoOpts.jsonSerializer
+	}
+
 	apiErrorHandler := errorHandler()
+	if echoOpts.problemJSONErrors {
+		apiErrorHandler = problemJSONErrorHandler(echoOpts.problemTypeBaseURI)
+	}
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
 		if onHTTPError != nil {
 			onHTTPError(err, c)
@@ -88,11 +166,39 @@ func NewEcho(logger log.Logger, onHTTPError func(err error, c echo.Context), deb
 
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		LogErrorFunc: func(c echo.Context, err error, _ []byte) error {
-			logger.LogErrorf("Internal Server Error: %s \nrequestURI: %s\n %s", err.Error(), c.Request().RequestURI, string(debug.Stack()))
+			logger.LogErrorf("Internal Server Error: %s \nrequestURI: %s%s\n %s", err.Error(), c.Request().RequestURI, requestIDLogSuffix(c), string(debug.Stack()))
 			return err
 		},
 	}))
 
+	if echoOpts.requestID {
+		e.Use(RequestIDMiddleware())
+	}
+
+	if echoOpts.requestTracingHook != nil {
+		e.Use(RequestTracingMiddleware(echoOpts.requestTracingHook))
+	}
+
+	if echoOpts.ipFilter != nil {
+		e.Use(echoOpts.ipFilter.Middleware())
+	}
+
+	if echoOpts.corsConfig != nil {
+		e.Use(middleware.CORSWithConfig(*echoOpts.corsConfig))
+	}
+
+	if echoOpts.bodyLimit > 0 {
+		e.Use(BodyLimitMiddleware(echoOpts.bodyLimit))
+	}
+
+	if echoOpts.maxDecompressedSize > 0 {
+		e.Use(DecompressMiddleware(echoOpts.maxDecompressedSize))
+	}
+
+	if echoOpts.compressionEnabled {
+		e.Use(CompressMiddleware(echoOpts.compressionOptions...))
+	}
+
 	if debugRequestLoggerEnabled {
 		e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 			LogLatency:      true,
@@ -103,13 +209,13 @@ func NewEcho(logger log.Logger, onHTTPError func(err error, c echo.Context), deb
 			LogStatus:       true,
 			LogError:        true,
 			LogResponseSize: true,
-			LogValuesFunc: func(_ echo.Context, v middleware.RequestLoggerValues) error {
+			LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
 				errString := ""
 				if v.Error != nil {
 					errString = fmt.Sprintf("error: \"%s\", ", v.Error.Error())
 				}
 
-				logger.LogDebugf("%d %s \"%s\", %sagent: \"%s\", remoteIP: %s, responseSize: %s, took: %v", v.Status, v.Method, v.URI, errString, v.UserAgent, v.RemoteIP, humanize.Bytes(uint64(v.ResponseSize)), v.Latency.Truncate(time.Millisecond))
+				logger.LogDebugf("%d %s \"%s\", %sagent: \"%s\", remoteIP: %s, responseSize: %s, took: %v%s", v.Status, v.Method, v.URI, errString, v.UserAgent, v.RemoteIP, humanize.Bytes(uint64(v.ResponseSize)), v.Latency.Truncate(time.Millisecond), requestIDLogSuffix(c))
 
 				return nil
 			},
@@ -119,6 +225,17 @@ func NewEcho(logger log.Logger, onHTTPError func(err error, c echo.Context), deb
 	return e
 }
 
+// requestIDLogSuffix returns the request ID formatted as suffix of a log message,
+// or an empty string if the request has no request ID.
+func requestIDLogSuffix(c echo.Context) string {
+	id := RequestID(c)
+	if id == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(", requestID: %s", id)
+}
+
 func GetAcceptHeaderContentType(c echo.Context, supportedContentTypes ...string) (string, error) {
 	ctype := c.Request().Header.Get(echo.HeaderAccept)
 	for _, supportedContentType := range supportedContentTypes {
@@ -143,9 +260,15 @@ func GetRequestContentType(c echo.Context, supportedContentTypes ...string) (str
 
 // ParseRequestByHeader parses the request based on the MIME type in the content header.
 // Supported MIME types: IOTASerializerV2, JSON.
-func ParseRequestByHeader[T any](c echo.Context, api iotago.API, binaryParserFunc func(bytes []byte) (T, int, error)) (T, error) {
+// The objects are validated according to the ValidationMode of the call or the route, see ValidationMiddleware.
+// If binaryParserFunc is nil, binary data is decoded via the API with the validation mode,
+// otherwise binaryParserFunc is responsible for the validation of binary data.
+func ParseRequestByHeader[T any](c echo.Context, api iotago.API, binaryParserFunc func(bytes []byte) (T, int, error), opts ...options.Option[RequestParseOptions]) (T, error) {
 	var obj T
 
+	parseOptions := options.Apply(&RequestParseOptions{}, opts)
+	validationMode := parseOptions.requestValidationMode(c)
+
 	mimeType, err := GetRequestContentType(c, iotaapi.MIMEApplicationVendorIOTASerializerV2, echo.MIMEApplicationJSON)
 	if err != nil {
 		return obj, ierrors.Join(ErrInvalidParameter, err)
@@ -158,7 +281,11 @@ func ParseRequestByHeader[T any](c echo.Context, api iotago.API, binaryParserFun
 
 	bytes, err := io.ReadAll(c.Request().Body)
 	if err != nil {
-		return obj, ierrors.Wrapf(ErrInvalidParameter, "failed to read request body, error: %w", err)
+		if ierrors.Is(err, ErrRequestEntityTooLarge) {
+			return obj, err
+		}
+
+		return obj, ierrors.Errorf("failed to read request body, error: %w: %w", err, ErrInvalidParameter)
 	}
 
 	switch mimeType {
@@ -171,31 +298,63 @@ func ParseRequestByHeader[T any](c echo.Context, api iotago.API, binaryParserFun
 			// create a new instance of the type and decode into it
 			//nolint:forcetypeassert // we know that obj is a pointer type
 			obj = reflect.New(reflectType.Elem()).Interface().(T)
-			err = api.JSONDecode(bytes, obj, serix.WithValidation())
+			err = api.JSONDecode(bytes, obj, validationMode.serixOptions()...)
 		} else {
-			err = api.JSONDecode(bytes, &obj, serix.WithValidation())
+			err = api.JSONDecode(bytes, &obj, validationMode.serixOptions()...)
 		}
 
 		if err != nil {
-			return obj, ierrors.Wrapf(ErrInvalidParameter, "failed to decode json data, error: %w", err)
+			return obj, ierrors.Errorf("failed to decode json data, error: %w: %w", err, ErrInvalidParameter)
 		}
 
 	case iotaapi.MIMEApplicationVendorIOTASerializerV2:
-		obj, _, err = binaryParserFunc(bytes)
+		if binaryParserFunc != nil {
+			obj, _, err = binaryParserFunc(bytes)
+		} else {
+			obj, err = decodeBinaryRequest[T](api, bytes, validationMode)
+		}
 		if err != nil {
-			return obj, ierrors.Wrapf(ErrInvalidParameter, "failed to parse binary data, error: %w", err)
+			return obj, ierrors.Errorf("failed to parse binary data, error: %w: %w", err, ErrInvalidParameter)
 		}
 
 	default:
 		return obj, echo.ErrUnsupportedMediaType
 	}
 
+	if validationMode != ValidationModeNone {
+		if err := parseOptions.validate(obj); err != nil {
+			return obj, err
+		}
+	}
+
 	return obj, nil
 }
 
+// decodeBinaryRequest decodes the binary data via the API with the given validation mode.
+func decodeBinaryRequest[T any](api iotago.API, bytes []byte, validationMode ValidationMode) (T, error) {
+	var obj T
+
+	reflectType := reflect.TypeOf(obj)
+	if reflectType != nil && reflectType.Kind() == reflect.Pointer {
+		// passed generic type is a pointer type
+		// create a new instance of the type and decode into it
+		//nolint:forcetypeassert // we know that obj is a pointer type
+		obj = reflect.New(reflectType.Elem()).Interface().(T)
+		_, err := api.Decode(bytes, obj, validationMode.serixOptions()...)
+
+		return obj, err
+	}
+
+	_, err := api.Decode(bytes, &obj, validationMode.serixOptions()...)
+
+	return obj, err
+}
+
 // SendResponseByHeader sends the response based on the MIME type in the accept header.
 // Supported MIME types: IOTASerializerV2, JSON.
 // If the MIME type is not supported, or there is none, it defaults to JSON.
+// Custom serializers registered via RegisterResponseSerializer take precedence over the default encoding.
+// The response is only validated if ValidationModeStrict was set for responses via the ValidationMiddleware.
 func SendResponseByHeader(c echo.Context, api iotago.API, obj any, httpStatusCode ...int) error {
 	mimeType, err := GetAcceptHeaderContentType(c, iotaapi.MIMEApplicationVendorIOTASerializerV2, echo.MIMEApplicationJSON)
 	if err != nil && !ierrors.Is(err, ErrNotAcceptable) {
@@ -207,9 +366,22 @@ func SendResponseByHeader(c echo.Context, api iotago.API, obj any, httpStatusCod
 		statusCode = httpStatusCode[0]
 	}
 
+	if mimeType == "" {
+		mimeType = echo.MIMEApplicationJSON
+	}
+
+	if serializer, exists := responseSerializer(obj, mimeType); exists {
+		b, err := serializer(obj)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to serialize response with custom serializer for %s", mimeType)
+		}
+
+		return c.Blob(statusCode, mimeType, b)
+	}
+
 	switch mimeType {
 	case iotaapi.MIMEApplicationVendorIOTASerializerV2:
-		b, err := api.Encode(obj)
+		b, err := api.Encode(obj, responseValidationMode(c).serixOptions()...)
 		if err != nil {
 			return ierrors.Wrap(err, "failed to encode binary data")
 		}
@@ -218,7 +390,7 @@ func SendResponseByHeader(c echo.Context, api iotago.API, obj any, httpStatusCod
 
 	// default to echo.MIMEApplicationJSON
 	default:
-		j, err := api.JSONEncode(obj)
+		j, err := api.JSONEncode(obj, responseValidationMode(c).serixOptions()...)
 		if err != nil {
 			return ierrors.Wrap(err, "failed to encode json data")
 		}
@@ -243,7 +415,7 @@ func ParseUint32QueryParam(c echo.Context, paramName string, maxValue ...uint32)
 
 	value, err := strconv.ParseUint(intString, 10, 32)
 	if err != nil {
-		return 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, error: %w", intString, err)
+		return 0, ierrors.Errorf("invalid value: %s, error: %w: %w", intString, err, ErrInvalidParameter)
 	}
 
 	if len(maxValue) > 0 {
@@ -266,7 +438,7 @@ func ParseSlotQueryParam(c echo.Context, paramName string) (iotago.SlotIndex, er
 
 	value, err := strconv.ParseUint(slotParam, 10, 32)
 	if err != nil {
-		return 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, error: %w", slotParam, err)
+		return 0, ierrors.Errorf("invalid value: %s, error: %w: %w", slotParam, err, ErrInvalidParameter)
 	}
 
 	return iotago.SlotIndex(value), nil
@@ -283,7 +455,7 @@ func ParseEpochQueryParam(c echo.Context, paramName string) (iotago.EpochIndex,
 
 	value, err := strconv.ParseUint(epochParam, 10, 32)
 	if err != nil {
-		return 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, error: %w", epochParam, err)
+		return 0, ierrors.Errorf("invalid value: %s, error: %w: %w", epochParam, err, ErrInvalidParameter)
 	}
 
 	return iotago.EpochIndex(value), nil
@@ -300,13 +472,13 @@ func ParseEpochCursorQueryParam(c echo.Context, paramName string) (iotago.EpochI
 
 	epochPart, err := strconv.ParseUint(cursorParts[0], 10, 32)
 	if err != nil {
-		return 0, 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, in parsing query parameter: %s error: %w", cursorParts[0], paramName, err)
+		return 0, 0, ierrors.Errorf("invalid value: %s, in parsing query parameter: %s error: %w: %w", cursorParts[0], paramName, err, ErrInvalidParameter)
 	}
 	startedAtEpoch := iotago.EpochIndex(epochPart)
 
 	indexPart, err := strconv.ParseUint(cursorParts[1], 10, 32)
 	if err != nil {
-		return 0, 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, in parsing query parameter: %s error: %w", cursorParts[1], paramName, err)
+		return 0, 0, ierrors.Errorf("invalid value: %s, in parsing query parameter: %s error: %w: %w", cursorParts[1], paramName, err, ErrInvalidParameter)
 	}
 	index := uint32(indexPart)
 
@@ -324,13 +496,13 @@ func ParseSlotCursorQueryParam(c echo.Context, paramName string) (iotago.SlotInd
 
 	slotPart, err := strconv.ParseUint(cursorParts[0], 10, 32)
 	if err != nil {
-		return 0, 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, in parsing query parameter: %s error: %w", cursorParts[0], paramName, err)
+		return 0, 0, ierrors.Errorf("invalid value: %s, in parsing query parameter: %s error: %w: %w", cursorParts[0], paramName, err, ErrInvalidParameter)
 	}
 	startedAtSlot := iotago.SlotIndex(slotPart)
 
 	indexPart, err := strconv.ParseUint(cursorParts[1], 10, 32)
 	if err != nil {
-		return 0, 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, in parsing query parameter: %s error: %w", cursorParts[1], paramName, err)
+		return 0, 0, ierrors.Errorf("invalid value: %s, in parsing query parameter: %s error: %w: %w", cursorParts[1], paramName, err, ErrInvalidParameter)
 	}
 	index := uint32(indexPart)
 
@@ -353,6 +525,27 @@ func ParsePageSizeQueryParam(c echo.Context, paramName string, maxPageSize uint3
 	return maxPageSize
 }
 
+// ParsePageSizeQueryParamStrict parses the page size query parameter.
+// It returns the maxPageSize if the query parameter is not set.
+// In contrast to ParsePageSizeQueryParam, it returns an ErrInvalidParameter
+// if the page size is invalid, zero or exceeds the maxPageSize.
+func ParsePageSizeQueryParamStrict(c echo.Context, paramName string, maxPageSize uint32) (uint32, error) {
+	if len(c.QueryParam(paramName)) == 0 {
+		return maxPageSize, nil
+	}
+
+	pageSize, err := ParseUint32QueryParam(c, paramName, maxPageSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if pageSize == 0 {
+		return 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: parameter \"%s\" must be greater than 0", paramName)
+	}
+
+	return pageSize, nil
+}
+
 // ParseHexQueryParam parses the hex query parameter.
 // It returns an error if the query parameter is not set.
 func ParseHexQueryParam(c echo.Context, paramName string, maxLen int) ([]byte, error) {
@@ -360,7 +553,7 @@ func ParseHexQueryParam(c echo.Context, paramName string, maxLen int) ([]byte, e
 
 	paramBytes, err := hexutil.DecodeHex(param)
 	if err != nil {
-		return nil, ierrors.Wrapf(ErrInvalidParameter, "invalid param: %s, error: %w", paramName, err)
+		return nil, ierrors.Errorf("invalid param: %s, error: %w: %w", paramName, err, ErrInvalidParameter)
 	}
 	if len(paramBytes) > maxLen {
 		return nil, ierrors.Wrapf(ErrInvalidParameter, "query parameter %s too long, max. %d bytes but is %d", paramName, maxLen, len(paramBytes))
@@ -387,7 +580,7 @@ func ParseBech32AddressQueryParam(c echo.Context, prefix iotago.NetworkPrefix, p
 
 	hrp, bech32Address, err := iotago.ParseBech32(addressParam)
 	if err != nil {
-		return nil, ierrors.Wrapf(ErrInvalidParameter, "invalid address: %s, error: %w", addressParam, err)
+		return nil, ierrors.Errorf("invalid address: %s, error: %w: %w", addressParam, err, ErrInvalidParameter)
 	}
 
 	if hrp != prefix {
@@ -408,7 +601,7 @@ func ParseCommitmentIDQueryParam(c echo.Context, paramName string) (iotago.Commi
 
 	commitmentID, err := iotago.CommitmentIDFromHexString(commitmentIDHex)
 	if err != nil {
-		return iotago.EmptyCommitmentID, ierrors.Wrapf(ErrInvalidParameter, "invalid commitment ID: %s, error: %w", commitmentIDHex, err)
+		return iotago.EmptyCommitmentID, ierrors.Errorf("invalid commitment ID: %s, error: %w: %w", commitmentIDHex, err, ErrInvalidParameter)
 	}
 
 	return commitmentID, nil
@@ -435,7 +628,7 @@ func ParseCommitmentIDParam(c echo.Context, paramName string) (iotago.Commitment
 
 	commitmentID, err := iotago.CommitmentIDFromHexString(commitmentIDHex)
 	if err != nil {
-		return iotago.EmptyCommitmentID, ierrors.Wrapf(ErrInvalidParameter, "invalid commitment ID: %s, error: %w", commitmentIDHex, err)
+		return iotago.EmptyCommitmentID, ierrors.Errorf("invalid commitment ID: %s, error: %w: %w", commitmentIDHex, err, ErrInvalidParameter)
 	}
 
 	return commitmentID, nil
@@ -447,7 +640,7 @@ func ParseBlockIDParam(c echo.Context, paramName string) (iotago.BlockID, error)
 
 	blockIDs, err := iotago.BlockIDsFromHexString([]string{blockIDHex})
 	if err != nil {
-		return iotago.EmptyBlockID, ierrors.Wrapf(ErrInvalidParameter, "invalid block ID: %s, error: %w", blockIDHex, err)
+		return iotago.EmptyBlockID, ierrors.Errorf("invalid block ID: %s, error: %w: %w", blockIDHex, err, ErrInvalidParameter)
 	}
 
 	return blockIDs[0], nil
@@ -460,7 +653,7 @@ func ParseTransactionIDParam(c echo.Context, paramName string) (iotago.Transacti
 
 	transactionIDBytes, err := hexutil.DecodeHex(transactionIDHex)
 	if err != nil {
-		return transactionID, ierrors.Wrapf(ErrInvalidParameter, "invalid transaction ID: %s, error: %w", transactionIDHex, err)
+		return transactionID, ierrors.Errorf("invalid transaction ID: %s, error: %w: %w", transactionIDHex, err, ErrInvalidParameter)
 	}
 
 	if len(transactionIDBytes) != iotago.TransactionIDLength {
@@ -478,7 +671,7 @@ func ParseOutputIDParam(c echo.Context, paramName string) (iotago.OutputID, erro
 
 	outputID, err := iotago.OutputIDFromHexString(outputIDParam)
 	if err != nil {
-		return iotago.OutputID{}, ierrors.Wrapf(ErrInvalidParameter, "invalid output ID: %s, error: %w", outputIDParam, err)
+		return iotago.OutputID{}, ierrors.Errorf("invalid output ID: %s, error: %w: %w", outputIDParam, err, ErrInvalidParameter)
 	}
 
 	return outputID, nil
@@ -491,7 +684,7 @@ func ParseFoundryIDParam(c echo.Context, paramName string) (iotago.FoundryID, er
 
 	foundryIDBytes, err := hexutil.DecodeHex(foundryIDHex)
 	if err != nil {
-		return foundryID, ierrors.Wrapf(ErrInvalidParameter, "invalid foundry ID: %s, error: %w", foundryIDHex, err)
+		return foundryID, ierrors.Errorf("invalid foundry ID: %s, error: %w: %w", foundryIDHex, err, ErrInvalidParameter)
 	}
 
 	if len(foundryIDBytes) != iotago.FoundryIDLength {
@@ -510,7 +703,7 @@ func ParseDelegationIDParam(c echo.Context, paramName string) (iotago.Delegation
 
 	delegationIDBytes, err := hexutil.DecodeHex(delegationIDHex)
 	if err != nil {
-		return delegationID, ierrors.Wrapf(ErrInvalidParameter, "invalid delegationID: %s, error: %w", delegationIDHex, err)
+		return delegationID, ierrors.Errorf("invalid delegationID: %s, error: %w: %w", delegationIDHex, err, ErrInvalidParameter)
 	}
 
 	if len(delegationIDBytes) != iotago.DelegationIDLength {
@@ -528,7 +721,7 @@ func ParseBech32AddressParam(c echo.Context, prefix iotago.NetworkPrefix, paramN
 
 	hrp, bech32Address, err := iotago.ParseBech32(addressParam)
 	if err != nil {
-		return nil, ierrors.Wrapf(ErrInvalidParameter, "invalid address: %s, error: %w", addressParam, err)
+		return nil, ierrors.Errorf("invalid address: %s, error: %w: %w", addressParam, err, ErrInvalidParameter)
 	}
 
 	if hrp != prefix {
@@ -547,7 +740,7 @@ func ParseUint64Param(c echo.Context, paramName string, maxValue ...uint64) (uin
 
 	value, err := strconv.ParseUint(intString, 10, 64)
 	if err != nil {
-		return 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, error: %w", intString, err)
+		return 0, ierrors.Errorf("invalid value: %s, error: %w: %w", intString, err, ErrInvalidParameter)
 	}
 
 	if len(maxValue) > 0 {
@@ -569,7 +762,7 @@ func ParseSlotParam(c echo.Context, paramName string) (iotago.SlotIndex, error)
 
 	value, err := strconv.ParseUint(slotParam, 10, 32)
 	if err != nil {
-		return 0, ierrors.Wrapf(ErrInvalidParameter, "invalid value: %s, error: %w", slotParam, err)
+		return 0, ierrors.Errorf("invalid value: %s, error: %w: %w", slotParam, err, ErrInvalidParameter)
 	}
 
 	return iotago.SlotIndex(value), nil
@@ -0,0 +1,242 @@
+package httpserver
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+var (
+	// ErrIPNotAllowed is returned if the IP address of the client is not allowed to access the API.
+	ErrIPNotAllowed = echo.NewHTTPError(http.StatusForbidden, "ip address not allowed")
+	// ErrInvalidCIDR is returned if an entry of an IP list is neither a valid CIDR nor a valid IP address.
+	ErrInvalidCIDR = ierrors.New("invalid CIDR")
+)
+
+// ParametersIPFilter contains the definition of the IP filter parameters.
+// It can be embedded into the parameters of the REST API component of an extension.
+type ParametersIPFilter struct {
+	// Enabled defines whether the IP filter middleware is enabled.
+	Enabled bool `default:"false" usage:"whether the IP filter middleware is enabled"`
+	// AllowList defines the CIDRs or IP addresses that are allowed to access the API.
+	AllowList []string `default:"" usage:"the CIDRs or IP addresses that are allowed to access the API (empty to allow all addresses that are not denied)"`
+	// DenyList defines the CIDRs or IP addresses that are not allowed to access the API.
+	DenyList []string `default:"" usage:"the CIDRs or IP addresses that are not allowed to access the API, it takes precedence over the allow list"`
+	// TrustedProxies defines the CIDRs or IP addresses of the proxies whose X-Forwarded-For header is trusted.
+	TrustedProxies []string `default:"" usage:"the CIDRs or IP addresses of the proxies whose X-Forwarded-For header is trusted"`
+}
+
+// IPFilter restricts the access to the API to clients with allowed IP addresses.
+// The deny list takes precedence over the allow list, an empty allow list allows all addresses that are not denied.
+//
+// The IP address of the client is the remote address of the connection. If the connection was opened
+// by a trusted proxy, the X-Forwarded-For header is evaluated from right to left
+// and the first address that is not a trusted proxy is used instead.
+type IPFilter struct {
+	allowList      []*net.IPNet
+	denyList       []*net.IPNet
+	trustedProxies []*net.IPNet
+	skipper        middleware.Skipper
+
+	// err is the first error that occurred while parsing the options.
+	err error
+}
+
+// WithIPAllowList sets the CIDRs or IP addresses that are allowed to access the API.
+func WithIPAllowList(cidrs ...string) options.Option[IPFilter] {
+	return func(f *IPFilter) {
+		f.allowList = f.parseIPNets(cidrs)
+	}
+}
+
+// WithIPDenyList sets the CIDRs or IP addresses that are not allowed to access the API.
+func WithIPDenyList(cidrs ...string) options.Option[IPFilter] {
+	return func(f *IPFilter) {
+		f.denyList = f.parseIPNets(cidrs)
+	}
+}
+
+// WithTrustedProxies sets the CIDRs or IP addresses of the proxies whose X-Forwarded-For header is trusted.
+func WithTrustedProxies(cidrs ...string) options.Option[IPFilter] {
+	return func(f *IPFilter) {
+		f.trustedProxies = f.parseIPNets(cidrs)
+	}
+}
+
+// WithIPFilterSkipper sets a function that defines which requests are not filtered.
+func WithIPFilterSkipper(skipper middleware.Skipper) options.Option[IPFilter] {
+	return func(f *IPFilter) {
+		f.skipper = skipper
+	}
+}
+
+// NewIPFilter creates a new IPFilter.
+// It returns ErrInvalidCIDR if one of the configured entries can't be parsed.
+func NewIPFilter(opts ...options.Option[IPFilter]) (*IPFilter, error) {
+	f := options.Apply(&IPFilter{
+		skipper: middleware.DefaultSkipper,
+	}, opts)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f, nil
+}
+
+// NewIPFilterFromParameters creates a new IPFilter configured by the given parameters.
+// It returns nil if the IP filter is not enabled in the parameters.
+func NewIPFilterFromParameters(params *ParametersIPFilter) (*IPFilter, error) {
+	if params == nil || !params.Enabled {
+		//nolint:nilnil // nil, nil is ok in this context, a disabled filter is not added to the Echo instance
+		return nil, nil
+	}
+
+	return NewIPFilter(
+		WithIPAllowList(params.AllowList...),
+		WithIPDenyList(params.DenyList...),
+		WithTrustedProxies(params.TrustedProxies...),
+	)
+}
+
+// parseIPNets parses the given CIDRs or IP addresses, empty entries are ignored.
+func (f *IPFilter) parseIPNets(cidrs []string) []*net.IPNet {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		ipNet, err := parseIPNet(cidr)
+		if err != nil {
+			if f.err == nil {
+				f.err = err
+			}
+
+			continue
+		}
+
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets
+}
+
+// parseIPNet parses a CIDR, a single IP address is converted to a CIDR that only contains this address.
+func parseIPNet(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, ierrors.Wrapf(ErrInvalidCIDR, "\"%s\"", cidr)
+		}
+
+		if ipv4 := ip.To4(); ipv4 != nil {
+			return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, ierrors.Wrapf(ErrInvalidCIDR, "\"%s\"", cidr)
+	}
+
+	return ipNet, nil
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allowed returns true if the given IP address is allowed to access the API.
+func (f *IPFilter) Allowed(ip net.IP) bool {
+	if ip == nil || containsIP(f.denyList, ip) {
+		return false
+	}
+
+	return len(f.allowList) == 0 || containsIP(f.allowList, ip)
+}
+
+// RemoteIP returns the IP address of the remote end of the connection of the given request, or nil if it can't be determined.
+// Forwarded headers are ignored, since they can be set by any client.
+func RemoteIP(req *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	return net.ParseIP(host)
+}
+
+// ClientIP returns the IP address of the client of the given request, or nil if it can't be determined.
+func (f *IPFilter) ClientIP(req *http.Request) net.IP {
+	ip := RemoteIP(req)
+	if ip == nil || !containsIP(f.trustedProxies, ip) {
+		return ip
+	}
+
+	// the connection was opened by a trusted proxy, so the forwarded addresses are evaluated
+	// from the closest to the most distant hop until an address is found that is not a trusted proxy
+	forwardedFor := strings.Split(strings.Join(req.Header.Values(echo.HeaderXForwardedFor), ","), ",")
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(forwardedFor[i])
+		if entry == "" {
+			continue
+		}
+
+		forwardedIP := net.ParseIP(entry)
+		if forwardedIP == nil {
+			// the header was tampered with, so the client can't be determined
+			return nil
+		}
+
+		if !containsIP(f.trustedProxies, forwardedIP) {
+			return forwardedIP
+		}
+
+		ip = forwardedIP
+	}
+
+	// all hops are trusted proxies, so the most distant one is the client
+	return ip
+}
+
+// Middleware returns a middleware that rejects requests of clients that are not allowed
+// to access the API with ErrIPNotAllowed.
+func (f *IPFilter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if f.skipper(c) {
+				return next(c)
+			}
+
+			if !f.Allowed(f.ClientIP(c.Request())) {
+				return ErrIPNotAllowed
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// WithIPFilter adds the middleware of the given IPFilter to the Echo instance.
+// The middleware is not added if the filter is nil, e.g. because it is disabled in the parameters.
+func WithIPFilter(filter *IPFilter) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.ipFilter = filter
+	}
+}
@@ -0,0 +1,272 @@
+package httpserver_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+func TestNewIPFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidrs   []string
+		wantErr bool
+	}{
+		{"CIDRs", []string{"10.0.0.0/8", "fd00::/8"}, false},
+		{"IP addresses", []string{"10.0.0.1", "::1"}, false},
+		{"empty entries", []string{"", " "}, false},
+		{"invalid IP address", []string{"10.0.0.256"}, true},
+		{"invalid CIDR", []string{"10.0.0.0/33"}, true},
+		{"host name", []string{"localhost"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := httpserver.NewIPFilter(httpserver.WithIPAllowList(test.cidrs...))
+			if test.wantErr {
+				require.ErrorIs(t, err, httpserver.ErrInvalidCIDR)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIPFilterAllowed(t *testing.T) {
+	filter, err := httpserver.NewIPFilter(
+		httpserver.WithIPAllowList("10.0.0.0/8", "2001:db8::/32"),
+		httpserver.WithIPDenyList("10.0.0.13", "2001:db8::dead"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		ip      net.IP
+		allowed bool
+	}{
+		{"allowed IPv4 address", net.ParseIP("10.1.2.3"), true},
+		{"allowed IPv4-mapped IPv6 address", net.ParseIP("::ffff:10.1.2.3"), true},
+		{"allowed IPv6 address", net.ParseIP("2001:db8::1"), true},
+		{"denied IPv4 address within the allow list", net.ParseIP("10.0.0.13"), false},
+		{"denied IPv6 address within the allow list", net.ParseIP("2001:db8::dead"), false},
+		{"IPv4 address outside the allow list", net.ParseIP("192.168.1.1"), false},
+		{"IPv6 address outside the allow list", net.ParseIP("2001:db9::1"), false},
+		{"unknown address", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.allowed, filter.Allowed(test.ip))
+		})
+	}
+
+	denyOnlyFilter, err := httpserver.NewIPFilter(httpserver.WithIPDenyList("10.0.0.13"))
+	require.NoError(t, err)
+	require.True(t, denyOnlyFilter.Allowed(net.ParseIP("192.168.1.1")))
+	require.False(t, denyOnlyFilter.Allowed(net.ParseIP("10.0.0.13")))
+}
+
+func TestIPFilterClientIP(t *testing.T) {
+	filter, err := httpserver.NewIPFilter(httpserver.WithTrustedProxies("10.0.0.1", "172.16.0.0/12"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		forwardedFor  []string
+		wantClientIP  string
+		wantUnknownIP bool
+	}{
+		{
+			name:         "direct connection",
+			remoteAddr:   "192.168.1.1:1234",
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:         "direct connection with IPv6 address",
+			remoteAddr:   "[2001:db8::1]:1234",
+			wantClientIP: "2001:db8::1",
+		},
+		{
+			name:         "spoofed header of an untrusted client",
+			remoteAddr:   "192.168.1.1:1234",
+			forwardedFor: []string{"10.1.2.3"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:         "trusted proxy without header",
+			remoteAddr:   "10.0.0.1:1234",
+			wantClientIP: "10.0.0.1",
+		},
+		{
+			name:         "trusted proxy",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"192.168.1.1"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:         "chain of trusted proxies",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"192.168.1.1, 172.16.0.5", "172.17.0.5"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:         "spoofed entries in front of the client",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"1.2.3.4, 5.6.7.8, 192.168.1.1"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:         "spoofed trusted proxy in front of the client",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"10.0.0.1, 192.168.1.1, 172.16.0.5"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:         "only trusted proxies",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"172.16.0.6, 172.16.0.5"},
+			wantClientIP: "172.16.0.6",
+		},
+		{
+			name:         "empty entries",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"192.168.1.1, ,"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:          "tampered header",
+			remoteAddr:    "10.0.0.1:1234",
+			forwardedFor:  []string{"192.168.1.1, not-an-ip"},
+			wantUnknownIP: true,
+		},
+		{
+			name:         "tampered entry behind the client is not evaluated",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: []string{"not-an-ip, 192.168.1.1"},
+			wantClientIP: "192.168.1.1",
+		},
+		{
+			name:          "invalid remote address",
+			remoteAddr:    "invalid",
+			wantUnknownIP: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			for _, forwardedFor := range test.forwardedFor {
+				req.Header.Add(echo.HeaderXForwardedFor, forwardedFor)
+			}
+
+			clientIP := filter.ClientIP(req)
+			if test.wantUnknownIP {
+				require.Nil(t, clientIP)
+
+				return
+			}
+
+			require.True(t, net.ParseIP(test.wantClientIP).Equal(clientIP), "expected %s, got %s", test.wantClientIP, clientIP)
+		})
+	}
+}
+
+func TestIPFilterMiddleware(t *testing.T) {
+	filter, err := httpserver.NewIPFilter(
+		httpserver.WithIPAllowList("192.168.0.0/16"),
+		httpserver.WithTrustedProxies("10.0.0.1"),
+		httpserver.WithIPFilterSkipper(func(c echo.Context) bool {
+			return c.Request().URL.Path == "/health"
+		}),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		path         string
+		remoteAddr   string
+		forwardedFor string
+		wantErr      error
+	}{
+		{
+			name:       "allowed client",
+			path:       "/api",
+			remoteAddr: "192.168.1.1:1234",
+		},
+		{
+			name:       "client that is not allowed",
+			path:       "/api",
+			remoteAddr: "172.16.0.1:1234",
+			wantErr:    httpserver.ErrIPNotAllowed,
+		},
+		{
+			name:         "client that spoofs an allowed address",
+			path:         "/api",
+			remoteAddr:   "172.16.0.1:1234",
+			forwardedFor: "192.168.1.1",
+			wantErr:      httpserver.ErrIPNotAllowed,
+		},
+		{
+			name:         "allowed client behind a trusted proxy",
+			path:         "/api",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "192.168.1.1",
+		},
+		{
+			name:         "client that is not allowed behind a trusted proxy",
+			path:         "/api",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "192.168.1.1, 172.16.0.1",
+			wantErr:      httpserver.ErrIPNotAllowed,
+		},
+		{
+			name:         "tampered header behind a trusted proxy",
+			path:         "/api",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "192.168.1.1, not-an-ip",
+			wantErr:      httpserver.ErrIPNotAllowed,
+		},
+		{
+			name:       "skipped route",
+			path:       "/health",
+			remoteAddr: "172.16.0.1:1234",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.forwardedFor != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, test.forwardedFor)
+			}
+
+			var called bool
+			err := filter.Middleware()(func(echo.Context) error {
+				called = true
+
+				return nil
+			})(echo.New().NewContext(req, httptest.NewRecorder()))
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				require.False(t, called)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, called)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// JSONCodec is a JSON implementation that can be used instead of encoding/json.
+// It is compatible with the standard library API, e.g. jsoniter.ConfigCompatibleWithStandardLibrary.
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// codecJSONSerializer is an echo.JSONSerializer that uses a JSONCodec.
+type codecJSONSerializer struct {
+	codec JSONCodec
+}
+
+// NewJSONSerializer returns an echo.JSONSerializer that uses the given JSONCodec.
+func NewJSONSerializer(codec JSONCodec) echo.JSONSerializer {
+	return &codecJSONSerializer{codec: codec}
+}
+
+// Serialize converts an interface into a json and writes it to the response.
+func (s *codecJSONSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	b, err := s.codec.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	if indent != "" {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, b, "", indent); err != nil {
+			return err
+		}
+		b = indented.Bytes()
+	}
+
+	_, err = c.Response().Write(b)
+
+	return err
+}
+
+// Deserialize reads a JSON from a request body and converts it into an interface.
+func (s *codecJSONSerializer) Deserialize(c echo.Context, i interface{}) error {
+	b, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return ierrors.Errorf("%w: failed to read request body: %w", ErrInvalidParameter, err)
+	}
+
+	if err := s.codec.Unmarshal(b, i); err != nil {
+		return ierrors.Errorf("%w: failed to decode json data: %w", ErrInvalidParameter, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package httpserver
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// JWTAuthClaimsContextKey is the key of the validated AuthClaims in the echo context.
+	JWTAuthClaimsContextKey = "jwt"
+
+	// jwtAuthScheme is the scheme of the authorization header.
+	jwtAuthScheme = "Bearer"
+)
+
+var (
+	// ErrJWTMissing is returned if the request does not contain a JWT.
+	ErrJWTMissing = echo.NewHTTPError(http.StatusUnauthorized, "missing or malformed jwt")
+	// ErrJWTInvalid is returned if the JWT of the request is invalid or expired.
+	ErrJWTInvalid = echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired jwt")
+	// ErrJWTInvalidClaims is returned if the claims of the JWT are not valid for the route.
+	ErrJWTInvalidClaims = echo.NewHTTPError(http.StatusUnauthorized, "invalid jwt claims")
+	// ErrRouteNotAccessible is returned if the route is neither public nor protected.
+	ErrRouteNotAccessible = echo.NewHTTPError(http.StatusForbidden, "route not accessible")
+)
+
+// ParametersJWTAuth contains the definition of the JWT authentication parameters.
+// It can be embedded into the parameters of the REST API component of an extension.
+type ParametersJWTAuth struct {
+	// Salt defines the salt used inside the JWT tokens, it has to match the salt of the node.
+	Salt string `default:"IOTA" usage:"the salt used inside the JWT tokens, it has to match the salt of the node"`
+	// PublicRoutes defines the routes that can be called without authorization. Wildcards using * are allowed.
+	PublicRoutes []string `default:"" usage:"the routes that can be called without authorization. Wildcards using * are allowed"`
+	// ProtectedRoutes defines the routes that need a valid JWT. Wildcards using * are allowed.
+	ProtectedRoutes []string `default:"" usage:"the routes that need a valid JWT. Wildcards using * are allowed"`
+}
+
+// AuthClaims are the claims of the JWT tokens issued by the node.
+type AuthClaims struct {
+	jwt.StandardClaims
+	// Dashboard defines whether the token is valid for the dashboard.
+	Dashboard bool `json:"dashboard"`
+	// API defines whether the token is valid for the API.
+	API bool `json:"api"`
+}
+
+// JWTAuthAllowFunc decides whether the validated claims grant access to the requested route.
+type JWTAuthAllowFunc func(c echo.Context, claims *AuthClaims) bool
+
+// JWTAuth issues and validates JWT tokens with the same scheme as the dashboard of the node.
+// The tokens are signed with HS256, the subject is the salt and the issuer and audience are the node ID.
+type JWTAuth struct {
+	salt           string
+	sessionTimeout time.Duration
+	nodeID         string
+	secret         []byte
+}
+
+// NewJWTAuth creates a new JWTAuth.
+// To validate tokens issued by the node, the salt, the node ID and the secret (the marshaled private key of the node)
+// have to match the ones of the node. A sessionTimeout of 0 issues tokens that never expire.
+func NewJWTAuth(salt string, sessionTimeout time.Duration, nodeID string, secret []byte) (*JWTAuth, error) {
+	if salt == "" {
+		return nil, ierrors.New("JWT salt must not be empty")
+	}
+	if len(secret) == 0 {
+		return nil, ierrors.New("JWT secret must not be empty")
+	}
+
+	return &JWTAuth{
+		salt:           salt,
+		sessionTimeout: sessionTimeout,
+		nodeID:         nodeID,
+		secret:         secret,
+	}, nil
+}
+
+// IssueJWT issues a new JWT token that is valid for the API and/or the dashboard.
+func (j *JWTAuth) IssueJWT(api bool, dashboard bool) (string, error) {
+	now := time.Now()
+
+	claims := &AuthClaims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   j.salt,
+			Issuer:    j.nodeID,
+			Audience:  j.nodeID,
+			Id:        strconv.FormatInt(now.Unix(), 10),
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+		},
+		Dashboard: dashboard,
+		API:       api,
+	}
+
+	if j.sessionTimeout > 0 {
+		claims.ExpiresAt = now.Add(j.sessionTimeout).Unix()
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.secret)
+}
+
+// ParseJWT parses the given token and validates its signature, expiration and identity claims.
+func (j *JWTAuth) ParseJWT(token string) (*AuthClaims, error) {
+	claims := &AuthClaims{}
+
+	parsedToken, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ierrors.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return j.secret, nil
+	})
+	if err != nil {
+		return nil, ierrors.Wrap(ErrJWTInvalid, err.Error())
+	}
+
+	if !parsedToken.Valid {
+		return nil, ErrJWTInvalid
+	}
+
+	if claims.Subject != j.salt || claims.Issuer != j.nodeID || !claims.VerifyAudience(j.nodeID, true) {
+		return nil, ErrJWTInvalidClaims
+	}
+
+	return claims, nil
+}
+
+// VerifyJWT returns true if the given token is valid and the claims are allowed by the allow function.
+func (j *JWTAuth) VerifyJWT(token string, allow func(claims *AuthClaims) bool) bool {
+	claims, err := j.ParseJWT(token)
+	if err != nil {
+		return false
+	}
+
+	return allow(claims)
+}
+
+// Middleware returns a middleware that grants access to public routes without authorization
+// and to protected routes only with a valid JWT in the authorization header that is allowed by the allow function.
+// If allow is nil, only tokens that are valid for the API are accepted.
+// Requests to routes that are neither public nor protected are rejected.
+// The validated claims are stored in the context under JWTAuthClaimsContextKey.
+func (j *JWTAuth) Middleware(publicRoutes *RouteMatcher, protectedRoutes *RouteMatcher, allow JWTAuthAllowFunc) echo.MiddlewareFunc {
+	if allow == nil {
+		allow = func(_ echo.Context, claims *AuthClaims) bool {
+			return claims.API
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			path := c.Request().URL.EscapedPath()
+
+			if publicRoutes.Matches(path) {
+				return next(c)
+			}
+
+			if !protectedRoutes.Matches(path) {
+				return ErrRouteNotAccessible
+			}
+
+			token, err := extractBearerToken(c)
+			if err != nil {
+				return err
+			}
+
+			claims, err := j.ParseJWT(token)
+			if err != nil {
+				return err
+			}
+
+			if !allow(c, claims) {
+				return ErrJWTInvalidClaims
+			}
+
+			c.Set(JWTAuthClaimsContextKey, claims)
+
+			return next(c)
+		}
+	}
+}
+
+// MiddlewareFromParameters returns the middleware of the JWTAuth with the routes configured in the given parameters.
+func (j *JWTAuth) MiddlewareFromParameters(params *ParametersJWTAuth, allow JWTAuthAllowFunc) (echo.MiddlewareFunc, error) {
+	publicRoutes, err := NewRouteMatcher(params.PublicRoutes)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "invalid public routes")
+	}
+
+	protectedRoutes, err := NewRouteMatcher(params.ProtectedRoutes)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "invalid protected routes")
+	}
+
+	return j.Middleware(publicRoutes, protectedRoutes, allow), nil
+}
+
+// AuthClaimsFromContext returns the claims that were validated by the JWTAuth middleware.
+func AuthClaimsFromContext(c echo.Context) (*AuthClaims, bool) {
+	claims, ok := c.Get(JWTAuthClaimsContextKey).(*AuthClaims)
+
+	return claims, ok
+}
+
+func extractBearerToken(c echo.Context) (string, error) {
+	scheme, token, found := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, jwtAuthScheme) || token == "" {
+		return "", ErrJWTMissing
+	}
+
+	return token, nil
+}
+
+// RouteMatcher matches request paths against a list of routes.
+// The routes may contain wildcards using *, e.g. "/api/core/v3/blocks/*".
+type RouteMatcher struct {
+	regexes []*regexp.Regexp
+}
+
+// NewRouteMatcher creates a new RouteMatcher for the given routes.
+func NewRouteMatcher(routes []string) (*RouteMatcher, error) {
+	regexes := make([]*regexp.Regexp, 0, len(routes))
+	for _, route := range routes {
+		if route == "" {
+			continue
+		}
+
+		expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(route), `\*`, "(.*?)") + "$"
+		regex, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "invalid route \"%s\"", route)
+		}
+
+		regexes = append(regexes, regex)
+	}
+
+	return &RouteMatcher{regexes: regexes}, nil
+}
+
+// Matches returns true if the path matches any of the routes.
+func (m *RouteMatcher) Matches(path string) bool {
+	if m == nil {
+		return false
+	}
+
+	for _, regex := range m.regexes {
+		if regex.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,351 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+const (
+	testJWTSalt   = "IOTA"
+	testJWTNodeID = "node"
+)
+
+var testJWTSecret = []byte("secret")
+
+func newTestJWTAuth(t *testing.T, secret []byte, nodeID string) *httpserver.JWTAuth {
+	t.Helper()
+
+	jwtAuth, err := httpserver.NewJWTAuth(testJWTSalt, time.Hour, nodeID, secret)
+	require.NoError(t, err)
+
+	return jwtAuth
+}
+
+func issueTestJWT(t *testing.T, jwtAuth *httpserver.JWTAuth, api bool, dashboard bool) string {
+	t.Helper()
+
+	token, err := jwtAuth.IssueJWT(api, dashboard)
+	require.NoError(t, err)
+
+	return token
+}
+
+func signTestJWT(t *testing.T, method jwt.SigningMethod, key any, claims *httpserver.AuthClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestRouteMatcher(t *testing.T) {
+	matcher, err := httpserver.NewRouteMatcher([]string{"/health", "/api/core/v3/blocks/*", "/api/*/info", ""})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		path    string
+		matches bool
+	}{
+		{"exact route", "/health", true},
+		{"exact route with suffix", "/health/check", false},
+		{"exact route with prefix", "/v1/health", false},
+		{"wildcard suffix", "/api/core/v3/blocks/0x1234", true},
+		{"wildcard suffix with sub path", "/api/core/v3/blocks/0x1234/metadata", true},
+		{"wildcard suffix without separator", "/api/core/v3/blocks", false},
+		{"wildcard infix", "/api/indexer/v2/info", true},
+		{"wildcard infix with other suffix", "/api/indexer/v2/outputs", false},
+		{"regex characters are quoted", "/healthy", false},
+		{"empty path", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.matches, matcher.Matches(test.path))
+		})
+	}
+
+	var nilMatcher *httpserver.RouteMatcher
+	require.False(t, nilMatcher.Matches("/health"))
+}
+
+func TestJWTAuthParseJWT(t *testing.T) {
+	jwtAuth := newTestJWTAuth(t, testJWTSecret, testJWTNodeID)
+
+	validClaims := func() *httpserver.AuthClaims {
+		now := time.Now()
+
+		return &httpserver.AuthClaims{
+			StandardClaims: jwt.StandardClaims{
+				Subject:   testJWTSalt,
+				Issuer:    testJWTNodeID,
+				Audience:  testJWTNodeID,
+				IssuedAt:  now.Unix(),
+				NotBefore: now.Unix(),
+				ExpiresAt: now.Add(time.Hour).Unix(),
+			},
+			API: true,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr error
+	}{
+		{
+			name:  "issued token",
+			token: func() string { return issueTestJWT(t, jwtAuth, true, false) },
+		},
+		{
+			name:  "token of the node",
+			token: func() string { return signTestJWT(t, jwt.SigningMethodHS256, testJWTSecret, validClaims()) },
+		},
+		{
+			name:    "malformed token",
+			token:   func() string { return "not-a-jwt" },
+			wantErr: httpserver.ErrJWTInvalid,
+		},
+		{
+			name:    "token signed with another secret",
+			token:   func() string { return issueTestJWT(t, newTestJWTAuth(t, []byte("other"), testJWTNodeID), true, false) },
+			wantErr: httpserver.ErrJWTInvalid,
+		},
+		{
+			name: "unsigned token",
+			token: func() string {
+				return signTestJWT(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims())
+			},
+			wantErr: httpserver.ErrJWTInvalid,
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				claims := validClaims()
+				claims.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+
+				return signTestJWT(t, jwt.SigningMethodHS256, testJWTSecret, claims)
+			},
+			wantErr: httpserver.ErrJWTInvalid,
+		},
+		{
+			name: "token that is not valid yet",
+			token: func() string {
+				claims := validClaims()
+				claims.NotBefore = time.Now().Add(time.Hour).Unix()
+
+				return signTestJWT(t, jwt.SigningMethodHS256, testJWTSecret, claims)
+			},
+			wantErr: httpserver.ErrJWTInvalid,
+		},
+		{
+			name: "token with another salt",
+			token: func() string {
+				claims := validClaims()
+				claims.Subject = "other"
+
+				return signTestJWT(t, jwt.SigningMethodHS256, testJWTSecret, claims)
+			},
+			wantErr: httpserver.ErrJWTInvalidClaims,
+		},
+		{
+			name:    "token of another node",
+			token:   func() string { return issueTestJWT(t, newTestJWTAuth(t, testJWTSecret, "other"), true, false) },
+			wantErr: httpserver.ErrJWTInvalidClaims,
+		},
+		{
+			name: "token for another audience",
+			token: func() string {
+				claims := validClaims()
+				claims.Audience = "other"
+
+				return signTestJWT(t, jwt.SigningMethodHS256, testJWTSecret, claims)
+			},
+			wantErr: httpserver.ErrJWTInvalidClaims,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims, err := jwtAuth.ParseJWT(test.token())
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				require.Nil(t, claims)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, claims.API)
+		})
+	}
+}
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	jwtAuth := newTestJWTAuth(t, testJWTSecret, testJWTNodeID)
+
+	middleware, err := jwtAuth.MiddlewareFromParameters(&httpserver.ParametersJWTAuth{
+		PublicRoutes:    []string{"/health", "/api/public/*"},
+		ProtectedRoutes: []string{"/api/*"},
+	}, nil)
+	require.NoError(t, err)
+
+	apiToken := issueTestJWT(t, jwtAuth, true, false)
+	dashboardToken := issueTestJWT(t, jwtAuth, false, true)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		wantErr       error
+		wantClaims    bool
+	}{
+		{
+			name: "public route without token",
+			path: "/health",
+		},
+		{
+			name:          "public route with invalid token",
+			path:          "/api/public/info",
+			authorization: "Bearer invalid",
+		},
+		{
+			name:          "protected route with API token",
+			path:          "/api/core/v3/info",
+			authorization: "Bearer " + apiToken,
+			wantClaims:    true,
+		},
+		{
+			name:          "protected route with lowercase scheme",
+			path:          "/api/core/v3/info",
+			authorization: "bearer " + apiToken,
+			wantClaims:    true,
+		},
+		{
+			name:    "protected route without token",
+			path:    "/api/core/v3/info",
+			wantErr: httpserver.ErrJWTMissing,
+		},
+		{
+			name:          "protected route with other scheme",
+			path:          "/api/core/v3/info",
+			authorization: "Basic " + apiToken,
+			wantErr:       httpserver.ErrJWTMissing,
+		},
+		{
+			name:          "protected route with scheme only",
+			path:          "/api/core/v3/info",
+			authorization: "Bearer ",
+			wantErr:       httpserver.ErrJWTMissing,
+		},
+		{
+			name:          "protected route with token without scheme",
+			path:          "/api/core/v3/info",
+			authorization: apiToken,
+			wantErr:       httpserver.ErrJWTMissing,
+		},
+		{
+			name:          "protected route with invalid token",
+			path:          "/api/core/v3/info",
+			authorization: "Bearer invalid",
+			wantErr:       httpserver.ErrJWTInvalid,
+		},
+		{
+			name:          "protected route with dashboard token",
+			path:          "/api/core/v3/info",
+			authorization: "Bearer " + dashboardToken,
+			wantErr:       httpserver.ErrJWTInvalidClaims,
+		},
+		{
+			name:          "route that is neither public nor protected",
+			path:          "/debug/pprof",
+			authorization: "Bearer " + apiToken,
+			wantErr:       httpserver.ErrRouteNotAccessible,
+		},
+		{
+			name:    "encoded separator does not match the routes",
+			path:    "/api%2Fpublic/info",
+			wantErr: httpserver.ErrRouteNotAccessible,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, test.authorization)
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var called bool
+			err := middleware(func(c echo.Context) error {
+				called = true
+
+				return nil
+			})(c)
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				require.False(t, called)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, called)
+
+			claims, hasClaims := httpserver.AuthClaimsFromContext(c)
+			require.Equal(t, test.wantClaims, hasClaims)
+			if test.wantClaims {
+				require.True(t, claims.API)
+			}
+		})
+	}
+}
+
+func TestJWTAuthMiddlewareAllowFunc(t *testing.T) {
+	jwtAuth := newTestJWTAuth(t, testJWTSecret, testJWTNodeID)
+
+	protectedRoutes, err := httpserver.NewRouteMatcher([]string{"/dashboard/*"})
+	require.NoError(t, err)
+
+	middleware := jwtAuth.Middleware(nil, protectedRoutes, func(_ echo.Context, claims *httpserver.AuthClaims) bool {
+		return claims.Dashboard
+	})
+
+	tests := []struct {
+		name      string
+		api       bool
+		dashboard bool
+		wantErr   error
+	}{
+		{"dashboard token", false, true, nil},
+		{"API and dashboard token", true, true, nil},
+		{"API token", true, false, httpserver.ErrJWTInvalidClaims},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/dashboard/peers", nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+issueTestJWT(t, jwtAuth, test.api, test.dashboard))
+
+			err := middleware(func(echo.Context) error { return nil })(echo.New().NewContext(req, httptest.NewRecorder()))
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package httpserver
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultMultipartMaxFileSize is the default maximum size of a file of a multipart form in bytes.
+	DefaultMultipartMaxFileSize = 64 << 20
+	// DefaultMultipartMaxValueSize is the default maximum size of a value of a multipart form in bytes.
+	DefaultMultipartMaxValueSize = 64 << 10
+	// DefaultMultipartMaxParts is the default maximum amount of parts of a multipart form.
+	DefaultMultipartMaxParts = 32
+
+	// multipartDefaultContentType is the content type of file parts without a Content-Type header.
+	multipartDefaultContentType = "application/octet-stream"
+)
+
+// MultipartOptions are the options for parsing multipart forms.
+type MultipartOptions struct {
+	maxFileSize  int64
+	maxValueSize int64
+	maxParts     int
+	tempDir      string
+	// fileContentTypes are the expected content types of the files per field name.
+	fileContentTypes map[string][]string
+}
+
+// WithMultipartMaxFileSize sets the maximum size of a single file in bytes,
+// reading more results in ErrRequestEntityTooLarge.
+func WithMultipartMaxFileSize(maxFileSize int64) options.Option[MultipartOptions] {
+	return func(o *MultipartOptions) {
+		o.maxFileSize = maxFileSize
+	}
+}
+
+// WithMultipartMaxValueSize sets the maximum size of a single non-file value in bytes,
+// reading more results in ErrRequestEntityTooLarge.
+func WithMultipartMaxValueSize(maxValueSize int64) options.Option[MultipartOptions] {
+	return func(o *MultipartOptions) {
+		o.maxValueSize = maxValueSize
+	}
+}
+
+// WithMultipartMaxParts sets the maximum amount of parts (files and values) of the form.
+func WithMultipartMaxParts(maxParts int) options.Option[MultipartOptions] {
+	return func(o *MultipartOptions) {
+		o.maxParts = maxParts
+	}
+}
+
+// WithMultipartTempDir sets the directory the files are stored in by ParseMultipartForm.
+// It defaults to the directory for temporary files of the system.
+func WithMultipartTempDir(tempDir string) options.Option[MultipartOptions] {
+	return func(o *MultipartOptions) {
+		o.tempDir = tempDir
+	}
+}
+
+// WithMultipartFileContentTypes sets the expected content types of the files of the given field,
+// e.g. "application/octet-stream" or "image/*". Files with another content type are rejected with echo.ErrUnsupportedMediaType.
+// The files of fields without expected content types are not validated.
+func WithMultipartFileContentTypes(fieldName string, contentTypes ...string) options.Option[MultipartOptions] {
+	return func(o *MultipartOptions) {
+		o.fileContentTypes[fieldName] = append(o.fileContentTypes[fieldName], contentTypes...)
+	}
+}
+
+func newMultipartOptions(opts []options.Option[MultipartOptions]) *MultipartOptions {
+	return options.Apply(&MultipartOptions{
+		maxFileSize:      DefaultMultipartMaxFileSize,
+		maxValueSize:     DefaultMultipartMaxValueSize,
+		maxParts:         DefaultMultipartMaxParts,
+		fileContentTypes: make(map[string][]string),
+	}, opts)
+}
+
+// validateContentType returns an error if the content type is not one of the expected content types of the field.
+func (o *MultipartOptions) validateContentType(fieldName string, contentType string) error {
+	expectedContentTypes, exists := o.fileContentTypes[fieldName]
+	if !exists {
+		return nil
+	}
+
+	for _, expectedContentType := range expectedContentTypes {
+		if prefix, isWildcard := strings.CutSuffix(expectedContentType, "/*"); isWildcard {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return nil
+			}
+
+			continue
+		}
+
+		if contentType == expectedContentType {
+			return nil
+		}
+	}
+
+	return ierrors.Wrapf(echo.ErrUnsupportedMediaType, "unsupported content type of file \"%s\": %s", fieldName, contentType)
+}
+
+// MultipartFileHeader describes a file of a multipart form.
+type MultipartFileHeader struct {
+	// FieldName is the name of the form field of the file.
+	FieldName string
+	// FileName is the name of the file as sent by the client, without directories.
+	FileName string
+	// ContentType is the media type of the file without parameters.
+	ContentType string
+}
+
+// MultipartFileFunc is called for every file of a multipart form with a reader of its content.
+// Reading more than the maximum file size from the reader results in ErrRequestEntityTooLarge.
+type MultipartFileFunc func(header *MultipartFileHeader, reader io.Reader) error
+
+// StreamMultipartForm reads the multipart form of the request part by part and passes the content of the files
+// to fileFunc without buffering them, e.g. to verify or import a snapshot while it is uploaded.
+// The values of the other fields are returned.
+func StreamMultipartForm(c echo.Context, fileFunc MultipartFileFunc, opts ...options.Option[MultipartOptions]) (url.Values, error) {
+	multipartOptions := newMultipartOptions(opts)
+
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		if ierrors.Is(err, http.ErrNotMultipart) {
+			return nil, ierrors.Wrap(echo.ErrUnsupportedMediaType, "request is not a multipart form")
+		}
+
+		return nil, ierrors.Errorf("%w: invalid multipart form: %w", ErrInvalidParameter, err)
+	}
+
+	values := make(url.Values)
+	for parts := 0; ; parts++ {
+		part, err := reader.NextPart()
+		if err != nil {
+			if ierrors.Is(err, io.EOF) {
+				return values, nil
+			}
+			if ierrors.Is(err, ErrRequestEntityTooLarge) {
+				return nil, err
+			}
+
+			return nil, ierrors.Errorf("%w: invalid multipart form: %w", ErrInvalidParameter, err)
+		}
+
+		if parts >= multipartOptions.maxParts {
+			_ = part.Close()
+			return nil, ierrors.Wrapf(ErrRequestEntityTooLarge, "multipart form has more than %d parts", multipartOptions.maxParts)
+		}
+
+		if err := streamMultipartPart(part, values, fileFunc, multipartOptions); err != nil {
+			_ = part.Close()
+			return nil, err
+		}
+
+		if err := part.Close(); err != nil {
+			return nil, ierrors.Errorf("%w: invalid multipart form: %w", ErrInvalidParameter, err)
+		}
+	}
+}
+
+// streamMultipartPart adds the value of a non-file part to the values, or passes the content of a file part to fileFunc.
+func streamMultipartPart(part *multipart.Part, values url.Values, fileFunc MultipartFileFunc, multipartOptions *MultipartOptions) error {
+	fieldName := part.FormName()
+	if fieldName == "" {
+		return ierrors.Wrap(ErrInvalidParameter, "multipart form contains a part without field name")
+	}
+
+	if part.FileName() == "" {
+		value, err := io.ReadAll(newLimitedReadCloser(part, part, multipartOptions.maxValueSize))
+		if err != nil {
+			if ierrors.Is(err, ErrRequestEntityTooLarge) {
+				return ierrors.Wrapf(err, "value of field \"%s\" is larger than %d bytes", fieldName, multipartOptions.maxValueSize)
+			}
+
+			return ierrors.Errorf("%w: failed to read value of field \"%s\": %w", ErrInvalidParameter, fieldName, err)
+		}
+		values.Add(fieldName, string(value))
+
+		return nil
+	}
+
+	contentType := multipartDefaultContentType
+	if contentTypeHeader := part.Header.Get(echo.HeaderContentType); contentTypeHeader != "" {
+		mediaType, _, err := mime.ParseMediaType(contentTypeHeader)
+		if err != nil {
+			return ierrors.Wrapf(ErrInvalidParameter, "invalid content type of file \"%s\": %s", fieldName, contentTypeHeader)
+		}
+		contentType = mediaType
+	}
+
+	if err := multipartOptions.validateContentType(fieldName, contentType); err != nil {
+		return err
+	}
+
+	return fileFunc(&MultipartFileHeader{
+		FieldName:   fieldName,
+		FileName:    part.FileName(),
+		ContentType: contentType,
+	}, newLimitedReadCloser(part, part, multipartOptions.maxFileSize))
+}
+
+// MultipartFile is a file of a multipart form that was stored in a temporary file by ParseMultipartForm.
+type MultipartFile struct {
+	*MultipartFileHeader
+
+	// Size is the size of the file in bytes.
+	Size int64
+	// Path is the path of the temporary file.
+	Path string
+}
+
+// Open opens the temporary file for reading.
+func (f *MultipartFile) Open() (*os.File, error) {
+	return os.Open(f.Path)
+}
+
+// Remove removes the temporary file.
+func (f *MultipartFile) Remove() error {
+	if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// MultipartForm is a multipart form whose files were stored in temporary files.
+// The temporary files need to be removed via RemoveAll after the request was handled.
+type MultipartForm struct {
+	// Values are the values of the non-file fields.
+	Values url.Values
+	// Files are the files per field name.
+	Files map[string][]*MultipartFile
+}
+
+// Value returns the first value of the given field, or an empty string if the field does not exist.
+func (f *MultipartForm) Value(fieldName string) string {
+	return f.Values.Get(fieldName)
+}
+
+// File returns the first file of the given field, or ErrInvalidParameter if the form contains no such file.
+func (f *MultipartForm) File(fieldName string) (*MultipartFile, error) {
+	files := f.Files[fieldName]
+	if len(files) == 0 {
+		return nil, ierrors.Wrapf(ErrInvalidParameter, "file \"%s\" not specified", fieldName)
+	}
+
+	return files[0], nil
+}
+
+// RemoveAll removes the temporary files of all files of the form.
+func (f *MultipartForm) RemoveAll() error {
+	var err error
+	for _, files := range f.Files {
+		for _, file := range files {
+			if removeErr := file.Remove(); removeErr != nil {
+				err = ierrors.Join(err, ierrors.Wrapf(removeErr, "failed to remove temporary file of \"%s\"", file.FieldName))
+			}
+		}
+	}
+
+	return err
+}
+
+// ParseMultipartForm reads the multipart form of the request and streams the files to temporary files,
+// so large uploads are not kept in memory. The caller needs to call RemoveAll on the returned form after the request was handled.
+// If parsing the form fails, the already stored files are removed.
+func ParseMultipartForm(c echo.Context, opts ...options.Option[MultipartOptions]) (*MultipartForm, error) {
+	multipartOptions := newMultipartOptions(opts)
+
+	form := &MultipartForm{
+		Files: make(map[string][]*MultipartFile),
+	}
+
+	values, err := StreamMultipartForm(c, func(header *MultipartFileHeader, reader io.Reader) error {
+		file, err := storeMultipartFile(header, reader, multipartOptions)
+		if err != nil {
+			return err
+		}
+		form.Files[header.FieldName] = append(form.Files[header.FieldName], file)
+
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, ierrors.Join(err, form.RemoveAll())
+	}
+	form.Values = values
+
+	return form, nil
+}
+
+// storeMultipartFile copies the content of the file to a new temporary file in the configured directory.
+func storeMultipartFile(header *MultipartFileHeader, reader io.Reader, multipartOptions *MultipartOptions) (*MultipartFile, error) {
+	tempFile, err := os.CreateTemp(multipartOptions.tempDir, "multipart-*")
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to create temporary file")
+	}
+
+	size, err := io.Copy(tempFile, reader)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+
+		if ierrors.Is(err, ErrRequestEntityTooLarge) {
+			return nil, ierrors.Wrapf(err, "file \"%s\" is larger than %d bytes", header.FieldName, multipartOptions.maxFileSize)
+		}
+
+		return nil, ierrors.Wrapf(err, "failed to store file \"%s\"", header.FieldName)
+	}
+
+	return &MultipartFile{
+		MultipartFileHeader: header,
+		Size:                size,
+		Path:                tempFile.Name(),
+	}, nil
+}
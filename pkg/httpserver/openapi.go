@@ -0,0 +1,327 @@
+package httpserver
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// RouteOpenAPI is the route of the generated OpenAPI document.
+	RouteOpenAPI = "/api/openapi.json"
+
+	// OpenAPIVersion is the version of the generated OpenAPI documents.
+	OpenAPIVersion = "3.1.0"
+)
+
+// ParameterLocation is the location of a route parameter.
+type ParameterLocation string
+
+const (
+	ParameterLocationPath   ParameterLocation = "path"
+	ParameterLocationQuery  ParameterLocation = "query"
+	ParameterLocationHeader ParameterLocation = "header"
+)
+
+// Schema is a JSON schema as used in OpenAPI documents.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// RouteParameter describes a parameter of a route.
+type RouteParameter struct {
+	Name        string            `json:"name"`
+	In          ParameterLocation `json:"in"`
+	Description string            `json:"description,omitempty"`
+	Required    bool              `json:"required,omitempty"`
+	Schema      *Schema           `json:"schema,omitempty"`
+}
+
+// RouteDefinition describes a route that is registered at the RouteRegistry.
+type RouteDefinition struct {
+	// Method is the HTTP method of the route.
+	Method string
+	// Path is the path of the route relative to the group it is added to, in echo notation (e.g. "/blocks/:blockID").
+	Path string
+	// Summary is a short summary of the route.
+	Summary string
+	// Description is an optional detailed description of the route.
+	Description string
+	// Tags are used to group the routes in the document.
+	Tags []string
+	// Parameters are the path, query and header parameters of the route.
+	// Path parameters are always required.
+	Parameters []*RouteParameter
+	// Request is a value of the type of the JSON request body, or nil if the route has no request body.
+	Request any
+	// Responses maps the status codes of the route to a value of the type of the JSON response body.
+	// A nil value defines a response without body.
+	Responses map[int]any
+}
+
+// RouteAdder is implemented by echo.Echo and echo.Group.
+type RouteAdder interface {
+	Add(method string, path string, handler echo.HandlerFunc, middleware ...echo.MiddlewareFunc) *echo.Route
+}
+
+// OpenAPIInfo is the info object of an OpenAPI document.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIDocument is an OpenAPI document.
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       *OpenAPIInfo                            `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components *OpenAPIComponents                      `json:"components,omitempty"`
+}
+
+// OpenAPIOperation is an operation of a path in an OpenAPI document.
+type OpenAPIOperation struct {
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*RouteParameter           `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIRequestBody is the request body of an operation in an OpenAPI document.
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse is a response of an operation in an OpenAPI document.
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType is the content of a request or response in an OpenAPI document.
+type OpenAPIMediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// OpenAPIComponents are the reusable schemas of an OpenAPI document.
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// RouteRegistry registers routes together with their description
+// and generates an OpenAPI document from them.
+// All routes should be registered before the document is served.
+type RouteRegistry struct {
+	info *OpenAPIInfo
+
+	mutex   sync.RWMutex
+	paths   map[string]map[string]*OpenAPIOperation
+	schemas map[string]*Schema
+}
+
+// NewRouteRegistry creates a new RouteRegistry for an API with the given title and version.
+func NewRouteRegistry(title string, version string, description string) *RouteRegistry {
+	return &RouteRegistry{
+		info: &OpenAPIInfo{
+			Title:       title,
+			Version:     version,
+			Description: description,
+		},
+		paths:   make(map[string]map[string]*OpenAPIOperation),
+		schemas: make(map[string]*Schema),
+	}
+}
+
+// Register adds the route to the given echo instance or group and adds its description to the OpenAPI document.
+func (r *RouteRegistry) Register(adder RouteAdder, definition *RouteDefinition, handler echo.HandlerFunc, middleware ...echo.MiddlewareFunc) *echo.Route {
+	route := adder.Add(definition.Method, definition.Path, handler, middleware...)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	operation := &OpenAPIOperation{
+		Summary:     definition.Summary,
+		Description: definition.Description,
+		Tags:        definition.Tags,
+		Parameters:  make([]*RouteParameter, 0, len(definition.Parameters)),
+		Responses:   make(map[string]*OpenAPIResponse, len(definition.Responses)),
+	}
+
+	for _, parameter := range definition.Parameters {
+		if parameter.In == ParameterLocationPath {
+			parameter.Required = true
+		}
+		if parameter.Schema == nil {
+			parameter.Schema = &Schema{Type: "string"}
+		}
+		operation.Parameters = append(operation.Parameters, parameter)
+	}
+
+	if definition.Request != nil {
+		operation.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]*OpenAPIMediaType{
+				echo.MIMEApplicationJSON: {Schema: r.schemaForType(reflect.TypeOf(definition.Request))},
+			},
+		}
+	}
+
+	for statusCode, responseValue := range definition.Responses {
+		response := &OpenAPIResponse{Description: http.StatusText(statusCode)}
+		if responseValue != nil {
+			response.Content = map[string]*OpenAPIMediaType{
+				echo.MIMEApplicationJSON: {Schema: r.schemaForType(reflect.TypeOf(responseValue))},
+			}
+		}
+		operation.Responses[strconv.Itoa(statusCode)] = response
+	}
+
+	path := openAPIPath(route.Path)
+	if _, exists := r.paths[path]; !exists {
+		r.paths[path] = make(map[string]*OpenAPIOperation)
+	}
+	r.paths[path][strings.ToLower(definition.Method)] = operation
+
+	return route
+}
+
+// Document returns the OpenAPI document of all registered routes.
+func (r *RouteRegistry) Document() *OpenAPIDocument {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return &OpenAPIDocument{
+		OpenAPI:    OpenAPIVersion,
+		Info:       r.info,
+		Paths:      r.paths,
+		Components: &OpenAPIComponents{Schemas: r.schemas},
+	}
+}
+
+// RegisterOpenAPIRoute serves the OpenAPI document at RouteOpenAPI.
+func (r *RouteRegistry) RegisterOpenAPIRoute(e *echo.Echo) {
+	e.GET(RouteOpenAPI, func(c echo.Context) error {
+		return c.JSON(http.StatusOK, r.Document())
+	})
+}
+
+// openAPIPath converts an echo path (e.g. "/blocks/:blockID") to an OpenAPI path (e.g. "/blocks/{blockID}").
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaForType derives the JSON schema of the given type.
+// Named structs are added to the component schemas and referenced.
+// The mutex must be held by the caller.
+func (r *RouteRegistry) schemaForType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType),
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		// custom serialized types (e.g. IDs) are serialized as strings
+		return &Schema{Type: "string"}
+	}
+
+	//nolint:exhaustive // all other kinds are not serializable
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		// 64 bit integers are serialized as strings to avoid precision loss in JavaScript clients
+		return &Schema{Type: "string"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "hex"}
+		}
+
+		return &Schema{Type: "array", Items: r.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaForType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+
+		if _, exists := r.schemas[t.Name()]; !exists {
+			// add a placeholder first to support recursive types
+			r.schemas[t.Name()] = &Schema{}
+			r.schemas[t.Name()] = r.structSchema(t)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+func (r *RouteRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, tagOptions, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = r.schemaForType(field.Type)
+		if !strings.Contains(tagOptions, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	sort.Strings(schema.Required)
+
+	return schema
+}
@@ -0,0 +1,167 @@
+package httpserver
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// CursorType defines whether a cursor points to a position within a slot or within an epoch.
+type CursorType byte
+
+const (
+	// CursorTypeSlot is the type of cursors that point to a position within a slot.
+	CursorTypeSlot CursorType = iota
+	// CursorTypeEpoch is the type of cursors that point to a position within an epoch.
+	CursorTypeEpoch
+)
+
+// cursorLength is the length of a serialized cursor: type (1 byte), slot or epoch (4 bytes), index (4 bytes).
+const cursorLength = 9
+
+// Cursor points to the position of an item within a slot or an epoch.
+type Cursor struct {
+	Type CursorType
+	// Key is the slot or the epoch, depending on the type.
+	Key   uint32
+	Index uint32
+}
+
+// NewSlotCursor creates a cursor that points to the given index within the given slot.
+func NewSlotCursor(slot iotago.SlotIndex, index uint32) Cursor {
+	return Cursor{Type: CursorTypeSlot, Key: uint32(slot), Index: index}
+}
+
+// NewEpochCursor creates a cursor that points to the given index within the given epoch.
+func NewEpochCursor(epoch iotago.EpochIndex, index uint32) Cursor {
+	return Cursor{Type: CursorTypeEpoch, Key: uint32(epoch), Index: index}
+}
+
+// Slot returns the slot of a slot cursor.
+func (c Cursor) Slot() iotago.SlotIndex {
+	return iotago.SlotIndex(c.Key)
+}
+
+// Epoch returns the epoch of an epoch cursor.
+func (c Cursor) Epoch() iotago.EpochIndex {
+	return iotago.EpochIndex(c.Key)
+}
+
+// Encode returns the opaque base64 representation of the cursor.
+func (c Cursor) Encode() string {
+	bytes := make([]byte, cursorLength)
+	bytes[0] = byte(c.Type)
+	binary.BigEndian.PutUint32(bytes[1:5], c.Key)
+	binary.BigEndian.PutUint32(bytes[5:9], c.Index)
+
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// DecodeCursor decodes the opaque base64 representation of a cursor.
+func DecodeCursor(cursor string) (Cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, ierrors.Errorf("%w: invalid cursor: %w", ErrInvalidParameter, err)
+	}
+
+	if len(bytes) != cursorLength {
+		return Cursor{}, ierrors.Wrapf(ErrInvalidParameter, "invalid cursor length: %d", len(bytes))
+	}
+
+	cursorType := CursorType(bytes[0])
+	if cursorType != CursorTypeSlot && cursorType != CursorTypeEpoch {
+		return Cursor{}, ierrors.Wrapf(ErrInvalidParameter, "invalid cursor type: %d", cursorType)
+	}
+
+	return Cursor{
+		Type:  cursorType,
+		Key:   binary.BigEndian.Uint32(bytes[1:5]),
+		Index: binary.BigEndian.Uint32(bytes[5:9]),
+	}, nil
+}
+
+// PageResponse is a page of items with the cursor to the next page.
+type PageResponse[T any] struct {
+	// Items are the items of the page.
+	Items []T `json:"items"`
+	// PageSize is the maximum amount of items of the page.
+	PageSize uint32 `json:"pageSize"`
+	// Cursor is the cursor to the next page, it is empty if this is the last page.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// Paginator parses the cursor and the page size of a request and assembles the response page.
+type Paginator[T any] struct {
+	cursor     *Cursor
+	pageSize   uint32
+	cursorFunc func(item T) Cursor
+}
+
+// NewPaginator parses the cursor and the page size query parameters of the request.
+// The page size is clamped to [1, maxPageSize] and defaults to maxPageSize if not set.
+// The cursorFunc returns the cursor that points to the given item.
+func NewPaginator[T any](c echo.Context, cursorType CursorType, cursorParamName string, pageSizeParamName string, maxPageSize uint32, cursorFunc func(item T) Cursor) (*Paginator[T], error) {
+	pageSize := max(ParsePageSizeQueryParam(c, pageSizeParamName, maxPageSize), 1)
+
+	p := &Paginator[T]{
+		pageSize:   pageSize,
+		cursorFunc: cursorFunc,
+	}
+
+	if cursorParam := c.QueryParam(cursorParamName); cursorParam != "" {
+		cursor, err := DecodeCursor(cursorParam)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "failed to parse query parameter \"%s\"", cursorParamName)
+		}
+
+		if cursor.Type != cursorType {
+			return nil, ierrors.Wrapf(ErrInvalidParameter, "invalid cursor type in query parameter \"%s\"", cursorParamName)
+		}
+
+		p.cursor = &cursor
+	}
+
+	return p, nil
+}
+
+// Cursor returns the cursor of the first item of the requested page.
+// It returns false if the first page was requested.
+func (p *Paginator[T]) Cursor() (Cursor, bool) {
+	if p.cursor == nil {
+		return Cursor{}, false
+	}
+
+	return *p.cursor, true
+}
+
+// PageSize returns the page size of the request.
+func (p *Paginator[T]) PageSize() uint32 {
+	return p.pageSize
+}
+
+// QueryLimit returns the amount of items that should be queried to assemble the page,
+// which is one more than the page size to determine the cursor of the next page.
+func (p *Paginator[T]) QueryLimit() uint32 {
+	return p.pageSize + 1
+}
+
+// Page assembles the response page from the queried items, starting at the cursor.
+// If there are more items than the page size, the cursor of the first item of the next page is set.
+func (p *Paginator[T]) Page(items []T) *PageResponse[T] {
+	if uint32(len(items)) <= p.pageSize {
+		return &PageResponse[T]{
+			Items:    items,
+			PageSize: p.pageSize,
+		}
+	}
+
+	return &PageResponse[T]{
+		Items:    items[:p.pageSize],
+		PageSize: p.pageSize,
+		Cursor:   p.cursorFunc(items[p.pageSize]).Encode(),
+	}
+}
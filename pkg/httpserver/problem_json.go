@@ -0,0 +1,82 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// MIMEApplicationProblemJSON is the media type of problem details as defined in RFC 9457.
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	// problemTypeBlank is the problem type of errors without a more specific type.
+	problemTypeBlank = "about:blank"
+)
+
+// ProblemDetails defines the error response schema according to RFC 9457 (problem details for HTTP APIs).
+type ProblemDetails struct {
+	// Type is a URI that identifies the problem type, it is built from the ErrorCode of the error.
+	Type string `json:"type"`
+	// Title is a short summary of the problem type, i.e. the text of the HTTP status code.
+	Title string `json:"title"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+	// Detail is the explanation of this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is the path of the request that caused the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// Code is the machine-readable ErrorCode of the error, it is omitted if no ErrorCode is known for the error.
+	Code string `json:"code,omitempty"`
+	// Details are optional details of the error, see WithErrorDetails.
+	Details map[string]any `json:"details,omitempty"`
+	// RequestID is the ID of the request, see WithRequestID.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// WithProblemJSONErrors answers errors with application/problem+json as defined in RFC 9457 instead of the error envelope.
+// The type of a problem is the ErrorCode of the error appended to the given base URI, e.g. "https://example.com/problems/not_found".
+// If the base URI is empty or no ErrorCode is known for the error, the type is "about:blank".
+func WithProblemJSONErrors(typeBaseURI string) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.problemJSONErrors = true
+		o.problemTypeBaseURI = typeBaseURI
+	}
+}
+
+// problemType returns the URI of the problem type of the given ErrorCode.
+func problemType(typeBaseURI string, code ErrorCode) string {
+	if typeBaseURI == "" || code == "" {
+		return problemTypeBlank
+	}
+
+	return strings.TrimSuffix(typeBaseURI, "/") + "/" + string(code)
+}
+
+func problemJSONErrorHandler(typeBaseURI string) func(error, echo.Context) {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			// the response was already (partially) sent, e.g. by a streamed response
+			return
+		}
+
+		statusCode, message, errorCode := resolveError(err)
+
+		// the content type is only set by c.JSON if it is not set yet
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		_ = c.JSON(statusCode, &ProblemDetails{
+			Type:      problemType(typeBaseURI, errorCode),
+			Title:     http.StatusText(statusCode),
+			Status:    statusCode,
+			Detail:    message,
+			Instance:  c.Request().URL.Path,
+			Code:      string(errorCode),
+			Details:   ErrorDetailsFromError(err),
+			RequestID: RequestID(c),
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package httpserver
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// QueryParamValuesSeparator separates the values of an array query parameter.
+const QueryParamValuesSeparator = ","
+
+// QueryParamParser parses and validates the values of an array query parameter.
+type QueryParamParser[T any] struct {
+	// Name is the name of the values that is used in error messages, e.g. "block ID".
+	Name string
+	// Parse parses and validates a single value.
+	Parse func(value string) (T, error)
+	// Key returns the key of a parsed value, values with the same key are only returned once.
+	Key func(item T) string
+}
+
+// BlockIDQueryParamParser returns a QueryParamParser for hex encoded block IDs.
+func BlockIDQueryParamParser() *QueryParamParser[iotago.BlockID] {
+	return &QueryParamParser[iotago.BlockID]{
+		Name: "block ID",
+		Parse: func(value string) (iotago.BlockID, error) {
+			blockIDs, err := iotago.BlockIDsFromHexString([]string{strings.ToLower(value)})
+			if err != nil {
+				return iotago.EmptyBlockID, err
+			}
+
+			return blockIDs[0], nil
+		},
+		Key: func(blockID iotago.BlockID) string {
+			return string(blockID[:])
+		},
+	}
+}
+
+// OutputIDQueryParamParser returns a QueryParamParser for hex encoded output IDs.
+func OutputIDQueryParamParser() *QueryParamParser[iotago.OutputID] {
+	return &QueryParamParser[iotago.OutputID]{
+		Name: "output ID",
+		Parse: func(value string) (iotago.OutputID, error) {
+			return iotago.OutputIDFromHexString(strings.ToLower(value))
+		},
+		Key: func(outputID iotago.OutputID) string {
+			return string(outputID[:])
+		},
+	}
+}
+
+// Bech32AddressQueryParamParser returns a QueryParamParser for bech32 encoded addresses with the given prefix.
+func Bech32AddressQueryParamParser(prefix iotago.NetworkPrefix) *QueryParamParser[iotago.Address] {
+	return &QueryParamParser[iotago.Address]{
+		Name: "address",
+		Parse: func(value string) (iotago.Address, error) {
+			hrp, address, err := iotago.ParseBech32(strings.ToLower(value))
+			if err != nil {
+				return nil, err
+			}
+
+			if hrp != prefix {
+				return nil, ierrors.Errorf("expected prefix: %s", prefix)
+			}
+
+			return address, nil
+		},
+		Key: func(address iotago.Address) string {
+			return address.Key()
+		},
+	}
+}
+
+// SlotQueryParamParser returns a QueryParamParser for slot indexes.
+func SlotQueryParamParser() *QueryParamParser[iotago.SlotIndex] {
+	return &QueryParamParser[iotago.SlotIndex]{
+		Name: "slot",
+		Parse: func(value string) (iotago.SlotIndex, error) {
+			slot, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return 0, err
+			}
+
+			return iotago.SlotIndex(slot), nil
+		},
+		Key: func(slot iotago.SlotIndex) string {
+			return strconv.FormatUint(uint64(slot), 10)
+		},
+	}
+}
+
+// ParseCommaSeparatedQueryParam parses the comma-separated values of the array query parameter with the given parser,
+// e.g. "/blocks?ids=0x...,0x...". The parameter may also be given multiple times, then the values of all occurrences are combined.
+// Duplicate values are removed, the order of the first occurrences is kept.
+// It returns an empty slice if the query parameter is not set,
+// and an error if a value is empty or invalid, or if there are more than maxCount values (0 for unlimited).
+func ParseCommaSeparatedQueryParam[T any](c echo.Context, paramName string, maxCount int, parser *QueryParamParser[T]) ([]T, error) {
+	values := make([]string, 0)
+	for _, param := range c.QueryParams()[paramName] {
+		if param == "" {
+			continue
+		}
+
+		values = append(values, strings.Split(param, QueryParamValuesSeparator)...)
+	}
+
+	// the amount is checked before the values are parsed, so huge requests are rejected early
+	if maxCount > 0 && len(values) > maxCount {
+		return nil, ierrors.Wrapf(ErrInvalidParameter, "parameter \"%s\" contains %d values, the maximum is %d", paramName, len(values), maxCount)
+	}
+
+	items := make([]T, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, ierrors.Wrapf(ErrInvalidParameter, "parameter \"%s\" contains an empty value", paramName)
+		}
+
+		item, err := parser.Parse(value)
+		if err != nil {
+			return nil, ierrors.Wrapf(ErrInvalidParameter, "invalid %s: %s, error: %s", parser.Name, value, err)
+		}
+
+		key := parser.Key(item)
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,238 @@
+package httpserver
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// QueryTag is the struct tag used by BindQuery.
+	// Format: `query:"<name>[,required][,max=<value>][,prefix=<bech32 prefix>]"`.
+	QueryTag = "query"
+)
+
+var (
+	typeSlotIndex    = reflect.TypeOf(iotago.SlotIndex(0))
+	typeEpochIndex   = reflect.TypeOf(iotago.EpochIndex(0))
+	typeWorkScore    = reflect.TypeOf(iotago.WorkScore(0))
+	typeCommitmentID = reflect.TypeOf(iotago.CommitmentID{})
+	typeAddress      = reflect.TypeOf((*iotago.Address)(nil)).Elem()
+	typeTime         = reflect.TypeOf(time.Time{})
+	typeBytes        = reflect.TypeOf([]byte{})
+)
+
+// queryFieldTag is the parsed query struct tag of a field.
+type queryFieldTag struct {
+	name     string
+	required bool
+	max      uint64
+	hasMax   bool
+	prefix   string
+}
+
+func parseQueryFieldTag(tag string) (*queryFieldTag, error) {
+	parts := strings.Split(tag, ",")
+
+	fieldTag := &queryFieldTag{name: parts[0]}
+	for _, part := range parts[1:] {
+		switch {
+		case part == "required":
+			fieldTag.required = true
+
+		case strings.HasPrefix(part, "max="):
+			maxValue, err := strconv.ParseUint(strings.TrimPrefix(part, "max="), 10, 64)
+			if err != nil {
+				return nil, ierrors.Wrapf(err, "invalid max value in query tag %s", tag)
+			}
+			fieldTag.max = maxValue
+			fieldTag.hasMax = true
+
+		case strings.HasPrefix(part, "prefix="):
+			fieldTag.prefix = strings.TrimPrefix(part, "prefix=")
+
+		default:
+			return nil, ierrors.Errorf("unknown option %s in query tag %s", part, tag)
+		}
+	}
+
+	return fieldTag, nil
+}
+
+// BindQuery creates a T and populates its fields from the query parameters based on the query struct tags.
+// Supported field types are iotago.SlotIndex, iotago.EpochIndex, iotago.WorkScore, iotago.CommitmentID,
+// iotago.Address (bech32), time.Time (unix timestamp), []byte (hex), bool, string and unsigned integers.
+// The "max" option limits the value of numbers and the length of byte slices,
+// the "prefix" option sets the expected bech32 prefix of addresses.
+func BindQuery[T any](c echo.Context) (*T, error) {
+	obj := new(T)
+
+	value := reflect.ValueOf(obj).Elem()
+	if value.Kind() != reflect.Struct {
+		return nil, ierrors.Errorf("BindQuery requires a struct type, got %s", value.Type())
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		tag, exists := field.Tag.Lookup(QueryTag)
+		if !exists || !field.IsExported() {
+			continue
+		}
+
+		fieldTag, err := parseQueryFieldTag(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.QueryParam(fieldTag.name) == "" {
+			if fieldTag.required {
+				return nil, ierrors.Wrapf(ErrInvalidParameter, "parameter \"%s\" not specified", fieldTag.name)
+			}
+
+			continue
+		}
+
+		if err := bindQueryField(c, value.Field(i), fieldTag); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj, nil
+}
+
+//nolint:gocyclo // the switch over the supported types is easier to read in one place
+func bindQueryField(c echo.Context, fieldValue reflect.Value, fieldTag *queryFieldTag) error {
+	checkMax := func(value uint64) error {
+		if fieldTag.hasMax && value > fieldTag.max {
+			return ierrors.Wrapf(ErrInvalidParameter, "invalid value: %d, higher than the max number %d", value, fieldTag.max)
+		}
+
+		return nil
+	}
+
+	switch fieldValue.Type() {
+	case typeSlotIndex:
+		slot, err := ParseSlotQueryParam(c, fieldTag.name)
+		if err != nil {
+			return err
+		}
+		if err := checkMax(uint64(slot)); err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(slot))
+
+	case typeEpochIndex:
+		epoch, err := ParseEpochQueryParam(c, fieldTag.name)
+		if err != nil {
+			return err
+		}
+		if err := checkMax(uint64(epoch)); err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(epoch))
+
+	case typeWorkScore:
+		workScore, err := ParseWorkScoreQueryParam(c, fieldTag.name)
+		if err != nil {
+			return err
+		}
+		if err := checkMax(uint64(workScore)); err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(workScore))
+
+	case typeCommitmentID:
+		commitmentID, err := ParseCommitmentIDQueryParam(c, fieldTag.name)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(commitmentID))
+
+	case typeAddress:
+		address, err := parseBech32AddressQueryParam(c, fieldTag.name, fieldTag.prefix)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(address))
+
+	case typeTime:
+		timestamp, err := ParseUnixTimestampQueryParam(c, fieldTag.name)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(timestamp))
+
+	case typeBytes:
+		maxLen := iotago.MaxPayloadSize
+		if fieldTag.hasMax {
+			maxLen = int(fieldTag.max)
+		}
+
+		bytes, err := ParseHexQueryParam(c, fieldTag.name, maxLen)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetBytes(bytes)
+
+	default:
+		return bindQueryFieldByKind(c, fieldValue, fieldTag, checkMax)
+	}
+
+	return nil
+}
+
+func bindQueryFieldByKind(c echo.Context, fieldValue reflect.Value, fieldTag *queryFieldTag, checkMax func(value uint64) error) error {
+	param := c.QueryParam(fieldTag.name)
+
+	//nolint:exhaustive // only the listed kinds are supported
+	switch fieldValue.Kind() {
+	case reflect.String:
+		fieldValue.SetString(param)
+
+	case reflect.Bool:
+		value, err := ParseBoolQueryParam(c, fieldTag.name)
+		if err != nil {
+			return ierrors.Errorf("%w: invalid value: %s: %w", ErrInvalidParameter, param, err)
+		}
+		fieldValue.SetBool(value)
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		value, err := strconv.ParseUint(param, 10, fieldValue.Type().Bits())
+		if err != nil {
+			return ierrors.Errorf("%w: invalid value: %s: %w", ErrInvalidParameter, param, err)
+		}
+		if err := checkMax(value); err != nil {
+			return err
+		}
+		fieldValue.SetUint(value)
+
+	default:
+		return ierrors.Errorf("unsupported type %s for query parameter \"%s\"", fieldValue.Type(), fieldTag.name)
+	}
+
+	return nil
+}
+
+// parseBech32AddressQueryParam parses the bech32 address query parameter.
+// If prefix is empty, addresses of all networks are accepted.
+func parseBech32AddressQueryParam(c echo.Context, paramName string, prefix string) (iotago.Address, error) {
+	if prefix != "" {
+		return ParseBech32AddressQueryParam(c, iotago.NetworkPrefix(prefix), paramName)
+	}
+
+	addressParam := strings.ToLower(c.QueryParam(paramName))
+
+	_, bech32Address, err := iotago.ParseBech32(addressParam)
+	if err != nil {
+		return nil, ierrors.Errorf("%w: invalid address: %s: %w", ErrInvalidParameter, addressParam, err)
+	}
+
+	return bech32Address, nil
+}
@@ -0,0 +1,123 @@
+package httpserver
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultRateLimit is the default amount of requests per second a client is allowed to send.
+	DefaultRateLimit = 10
+	// DefaultRateLimitBurst is the default amount of requests a client is allowed to send at once.
+	DefaultRateLimitBurst = 20
+	// DefaultRateLimitExpiresIn is the default duration after which the limiter of an inactive client is removed.
+	DefaultRateLimitExpiresIn = 3 * time.Minute
+	// DefaultRateLimitRetryAfter is the default Retry-After duration of rejected requests.
+	DefaultRateLimitRetryAfter = 1 * time.Second
+)
+
+// RateLimiter contains the settings of the rate limiting middleware.
+type RateLimiter struct {
+	rate       rate.Limit
+	burst      int
+	expiresIn  time.Duration
+	retryAfter time.Duration
+	perRoute   bool
+	skipper    middleware.Skipper
+	clientIP   func(req *http.Request) net.IP
+}
+
+// WithRateLimit sets the amount of requests per second and the burst size of the token bucket of every client.
+func WithRateLimit(requestsPerSecond float64, burst int) options.Option[RateLimiter] {
+	return func(r *RateLimiter) {
+		r.rate = rate.Limit(requestsPerSecond)
+		r.burst = burst
+	}
+}
+
+// WithRateLimitExpiresIn sets the duration after which the limiter of an inactive client is removed.
+func WithRateLimitExpiresIn(expiresIn time.Duration) options.Option[RateLimiter] {
+	return func(r *RateLimiter) {
+		r.expiresIn = expiresIn
+	}
+}
+
+// WithRateLimitRetryAfter sets the Retry-After duration of rejected requests.
+func WithRateLimitRetryAfter(retryAfter time.Duration) options.Option[RateLimiter] {
+	return func(r *RateLimiter) {
+		r.retryAfter = retryAfter
+	}
+}
+
+// WithRateLimitPerRoute limits the requests of every client per route instead of over all routes.
+func WithRateLimitPerRoute() options.Option[RateLimiter] {
+	return func(r *RateLimiter) {
+		r.perRoute = true
+	}
+}
+
+// WithRateLimitIPFilter identifies the clients via IPFilter.ClientIP, so the X-Forwarded-For header of the
+// trusted proxies of the IP filter is evaluated. By default, the clients are identified by the remote address
+// of the connection, since the forwarded headers can be set by any client to bypass the rate limit.
+func WithRateLimitIPFilter(ipFilter *IPFilter) options.Option[RateLimiter] {
+	return func(r *RateLimiter) {
+		r.clientIP = ipFilter.ClientIP
+	}
+}
+
+// WithRateLimitSkipper sets a function that defines which requests are not rate limited.
+func WithRateLimitSkipper(skipper middleware.Skipper) options.Option[RateLimiter] {
+	return func(r *RateLimiter) {
+		r.skipper = skipper
+	}
+}
+
+// RateLimiterMiddleware returns a middleware that limits the requests per client IP with a token bucket.
+// Requests exceeding the limit are rejected with status code 429 and a Retry-After header.
+// Requests whose client IP can't be determined are rejected with status code 403.
+func RateLimiterMiddleware(opts ...options.Option[RateLimiter]) echo.MiddlewareFunc {
+	r := options.Apply(&RateLimiter{
+		rate:       DefaultRateLimit,
+		burst:      DefaultRateLimitBurst,
+		expiresIn:  DefaultRateLimitExpiresIn,
+		retryAfter: DefaultRateLimitRetryAfter,
+		perRoute:   false,
+		skipper:    middleware.DefaultSkipper,
+		clientIP:   RemoteIP,
+	}, opts)
+
+	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Skipper: r.skipper,
+		Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:      r.rate,
+			Burst:     r.burst,
+			ExpiresIn: r.expiresIn,
+		}),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			clientIP := r.clientIP(c.Request())
+			if clientIP == nil {
+				return "", ierrors.New("unable to determine the client IP")
+			}
+
+			if r.perRoute {
+				return clientIP.String() + " " + c.Request().Method + " " + c.Path(), nil
+			}
+
+			return clientIP.String(), nil
+		},
+		ErrorHandler: func(_ echo.Context, err error) error {
+			return echo.ErrForbidden.WithInternal(err)
+		},
+		DenyHandler: func(c echo.Context, _ string, _ error) error {
+			return TooManyRequests(c, r.retryAfter, "rate limit exceeded")
+		},
+	})
+}
@@ -0,0 +1,189 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+// testRateLimitRequest is a request that is sent to the rate limiter and the status code it is expected to result in.
+type testRateLimitRequest struct {
+	remoteAddr     string
+	forwardedFor   string
+	path           string
+	wantStatusCode int
+}
+
+func TestRateLimiterMiddleware(t *testing.T) {
+	ipFilter, err := httpserver.NewIPFilter(httpserver.WithTrustedProxies("10.0.0.1"))
+	require.NoError(t, err)
+
+	// the rate is negligible, so only the burst of two requests is available to every client during the test
+	rateLimitOpts := []options.Option[httpserver.RateLimiter]{
+		httpserver.WithRateLimit(0.0001, 2),
+		httpserver.WithRateLimitRetryAfter(3 * time.Second),
+	}
+
+	tests := []struct {
+		name     string
+		opts     []options.Option[httpserver.RateLimiter]
+		requests []testRateLimitRequest
+	}{
+		{
+			name: "burst of a client",
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "clients are limited separately",
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.2:1234", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "ports of a client are not distinguished",
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1235", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1236", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "spoofed forwarded header is ignored by default",
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", forwardedFor: "1.1.1.1", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", forwardedFor: "2.2.2.2", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", forwardedFor: "3.3.3.3", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "forwarded header of an untrusted proxy is ignored",
+			opts: []options.Option[httpserver.RateLimiter]{httpserver.WithRateLimitIPFilter(ipFilter)},
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", forwardedFor: "1.1.1.1", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", forwardedFor: "2.2.2.2", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", forwardedFor: "3.3.3.3", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "clients behind a trusted proxy are limited separately",
+			opts: []options.Option[httpserver.RateLimiter]{httpserver.WithRateLimitIPFilter(ipFilter)},
+			requests: []testRateLimitRequest{
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "192.168.1.1", wantStatusCode: http.StatusOK},
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "192.168.1.1", wantStatusCode: http.StatusOK},
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "192.168.1.2", wantStatusCode: http.StatusOK},
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "192.168.1.1", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "spoofed entries behind a trusted proxy are ignored",
+			opts: []options.Option[httpserver.RateLimiter]{httpserver.WithRateLimitIPFilter(ipFilter)},
+			requests: []testRateLimitRequest{
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "1.1.1.1, 192.168.1.1", wantStatusCode: http.StatusOK},
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "2.2.2.2, 192.168.1.1", wantStatusCode: http.StatusOK},
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "3.3.3.3, 192.168.1.1", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "tampered header behind a trusted proxy",
+			opts: []options.Option[httpserver.RateLimiter]{httpserver.WithRateLimitIPFilter(ipFilter)},
+			requests: []testRateLimitRequest{
+				{remoteAddr: "10.0.0.1:1234", forwardedFor: "192.168.1.1, not-an-ip", wantStatusCode: http.StatusForbidden},
+			},
+		},
+		{
+			name: "unknown client",
+			requests: []testRateLimitRequest{
+				{remoteAddr: "invalid", wantStatusCode: http.StatusForbidden},
+			},
+		},
+		{
+			name: "routes are limited together by default",
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", path: "/a", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/b", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/a", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "routes are limited separately",
+			opts: []options.Option[httpserver.RateLimiter]{httpserver.WithRateLimitPerRoute()},
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", path: "/a", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/a", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/b", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/a", wantStatusCode: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "skipped requests",
+			opts: []options.Option[httpserver.RateLimiter]{httpserver.WithRateLimitSkipper(func(c echo.Context) bool {
+				return c.Path() == "/health"
+			})},
+			requests: []testRateLimitRequest{
+				{remoteAddr: "192.168.1.1:1234", path: "/health", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/health", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/health", wantStatusCode: http.StatusOK},
+				{remoteAddr: "192.168.1.1:1234", path: "/a", wantStatusCode: http.StatusOK},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rateLimiter := httpserver.RateLimiterMiddleware(append(rateLimitOpts, test.opts...)...)
+
+			for i, request := range test.requests {
+				path := request.path
+				if path == "" {
+					path = "/"
+				}
+
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				req.RemoteAddr = request.remoteAddr
+				if request.forwardedFor != "" {
+					req.Header.Set(echo.HeaderXForwardedFor, request.forwardedFor)
+				}
+
+				// the rate limiter passes its errors to the HTTPErrorHandler instead of returning them
+				var err error
+				e := echo.New()
+				e.HTTPErrorHandler = func(handledErr error, _ echo.Context) {
+					err = handledErr
+				}
+
+				c := e.NewContext(req, httptest.NewRecorder())
+				c.SetPath(path)
+
+				require.NoError(t, rateLimiter(func(echo.Context) error { return nil })(c))
+				switch request.wantStatusCode {
+				case http.StatusOK:
+					require.NoError(t, err, "request %d", i)
+
+				case http.StatusTooManyRequests:
+					require.ErrorIs(t, err, httpserver.ErrTooManyRequests, "request %d", i)
+					require.Equal(t, "3", c.Response().Header().Get(echo.HeaderRetryAfter))
+
+				default:
+					var httpErr *echo.HTTPError
+					require.True(t, ierrors.As(err, &httpErr), "request %d", i)
+					require.Equal(t, request.wantStatusCode, httpErr.Code, "request %d", i)
+				}
+			}
+		})
+	}
+}
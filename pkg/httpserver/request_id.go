@@ -0,0 +1,165 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/requestid"
+)
+
+const (
+	// requestIDContextKey is the key of the request ID in the echo context.
+	requestIDContextKey = "requestID"
+
+	// tracerName is the name of the OpenTelemetry tracer of the REST API.
+	tracerName = "github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+// RequestIDMiddleware returns a middleware that assigns a request ID to every request.
+// A valid request ID sent by the client in the X-Request-ID header is kept, otherwise a new one is generated.
+// The request ID is returned in the X-Request-ID header of the response and is part of the error envelope.
+// It is also added to the context of the request, so NodeBridge calls made with this context
+// propagate it to the node.
+func RequestIDMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			id := req.Header.Get(requestid.HeaderName)
+			if !requestid.IsValid(id) {
+				id = requestid.New()
+			}
+
+			c.Set(requestIDContextKey, id)
+			c.Response().Header().Set(requestid.HeaderName, id)
+			c.SetRequest(req.WithContext(requestid.NewContext(req.Context(), id)))
+
+			return next(c)
+		}
+	}
+}
+
+// RequestID returns the request ID that was assigned by the RequestIDMiddleware,
+// or an empty string if the middleware is not used.
+func RequestID(c echo.Context) string {
+	id, _ := c.Get(requestIDContextKey).(string)
+
+	return id
+}
+
+// RequestIDLogAttr returns the request ID as log attribute,
+// so log messages of a handler can be correlated with the request, e.g. via logger.LogErrorAttrs.
+func RequestIDLogAttr(c echo.Context) slog.Attr {
+	return slog.String(requestIDContextKey, RequestID(c))
+}
+
+// WithRequestID adds the RequestIDMiddleware to the Echo instance.
+func WithRequestID() options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.requestID = true
+	}
+}
+
+// RequestTracingHook is called at the start of every request.
+// The returned function is called with the status code and the error of the handler once the request is done.
+type RequestTracingHook func(c echo.Context) (done func(statusCode int, err error))
+
+// RequestTracingMiddleware returns a middleware that calls the given hook for every request.
+func RequestTracingMiddleware(hook RequestTracingHook) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			done := hook(c)
+
+			err := next(c)
+
+			// the error is handled by the HTTPErrorHandler after the middleware returned,
+			// so the status code of the response is derived from the error
+			statusCode := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				statusCode = statusCodeFromError(err)
+			}
+			done(statusCode, err)
+
+			return err
+		}
+	}
+}
+
+// WithRequestTracing adds the RequestTracingMiddleware with the given hook to the Echo instance.
+// Use OpenTelemetryTracingHook to create an OpenTelemetry span per request.
+func WithRequestTracing(hook RequestTracingHook) options.Option[echoOptions] {
+	return func(o *echoOptions) {
+		o.requestTracingHook = hook
+	}
+}
+
+// OpenTelemetryTracingHook returns a RequestTracingHook that creates an OpenTelemetry span per request
+// with the method, the route, the status code and the request ID as attributes.
+// A trace context sent by the client is continued. The span is added to the context of the request,
+// so NodeBridge calls made with this context become child spans.
+// The global tracer provider is used if tracerProvider is nil.
+func OpenTelemetryTracingHook(tracerProvider trace.TracerProvider) RequestTracingHook {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	tracer := tracerProvider.Tracer(tracerName)
+
+	return func(c echo.Context) func(statusCode int, err error) {
+		req := c.Request()
+
+		// the route is only known after the router matched the request, which happens before the middlewares are called
+		route := c.Path()
+		if route == "" {
+			route = req.URL.Path
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracer.Start(ctx, req.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.HTTPRoute(route),
+			),
+		)
+		if id := RequestID(c); id != "" {
+			span.SetAttributes(attribute.String("http.request.id", id))
+		}
+		c.SetRequest(req.WithContext(ctx))
+
+		return func(statusCode int, err error) {
+			defer span.End()
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+			if statusCode >= http.StatusInternalServerError {
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.SetStatus(codes.Error, http.StatusText(statusCode))
+			}
+		}
+	}
+}
+
+// statusCodeFromError returns the status code of the response the HTTPErrorHandler sends for the given error.
+func statusCodeFromError(err error) int {
+	var apiErr *APIError
+	var httpErr *echo.HTTPError
+	switch {
+	case ierrors.As(err, &apiErr):
+		return apiErr.StatusCode
+	case ierrors.As(err, &httpErr):
+		return httpErr.Code
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,56 @@
+package httpserver
+
+import (
+	"reflect"
+	"sync"
+
+	iotaapi "github.com/iotaledger/iota.go/v4/api"
+)
+
+// responseSerializers holds the custom serializers per object type and MIME type.
+var responseSerializers = struct {
+	sync.RWMutex
+	serializers map[reflect.Type]map[string]func(obj any) ([]byte, error)
+}{
+	serializers: make(map[reflect.Type]map[string]func(obj any) ([]byte, error)),
+}
+
+func init() {
+	// raw bytes are already encoded and are passed through without re-encoding
+	RegisterResponseSerializer(iotaapi.MIMEApplicationVendorIOTASerializerV2, func(obj []byte) ([]byte, error) {
+		return obj, nil
+	})
+}
+
+// RegisterResponseSerializer registers a custom serializer for objects of type T and the given MIME type,
+// which is used by SendResponseByHeader instead of the default serix encoding.
+// This allows to serve already encoded data without a decode/re-encode round trip.
+func RegisterResponseSerializer[T any](mimeType string, serializer func(obj T) ([]byte, error)) {
+	responseSerializers.Lock()
+	defer responseSerializers.Unlock()
+
+	objType := reflect.TypeOf((*T)(nil)).Elem()
+	if _, exists := responseSerializers.serializers[objType]; !exists {
+		responseSerializers.serializers[objType] = make(map[string]func(obj any) ([]byte, error))
+	}
+
+	responseSerializers.serializers[objType][mimeType] = func(obj any) ([]byte, error) {
+		//nolint:forcetypeassert // the serializer is only called for objects of type T
+		return serializer(obj.(T))
+	}
+}
+
+// responseSerializer returns the custom serializer for the given object and MIME type.
+func responseSerializer(obj any, mimeType string) (func(obj any) ([]byte, error), bool) {
+	responseSerializers.RLock()
+	defer responseSerializers.RUnlock()
+
+	serializersForType, exists := responseSerializers.serializers[reflect.TypeOf(obj)]
+	if !exists {
+		return nil, false
+	}
+
+	serializer, exists := serializersForType[mimeType]
+
+	return serializer, exists
+}
@@ -0,0 +1,190 @@
+package httpserver
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+	iotaapi "github.com/iotaledger/iota.go/v4/api"
+)
+
+// streamFlushInterval is the amount of items after which a streamed response is flushed to the client.
+const streamFlushInterval = 100
+
+// ResponseStreamEncoder writes a sequence of items to an io.Writer without buffering the whole sequence.
+//
+// In JSON form, the items are written as a JSON array.
+// In IOTA binary form, every serix encoded item is prefixed with its length as uint32 (little endian).
+// Custom serializers registered via RegisterResponseSerializer take precedence over the default encoding.
+type ResponseStreamEncoder struct {
+	writer   io.Writer
+	api      iotago.API
+	mimeType string
+	count    int
+
+	// beforeWrite is called before anything is written to the writer.
+	beforeWrite func()
+}
+
+// NewResponseStreamEncoder creates a new ResponseStreamEncoder for the given MIME type.
+// Supported MIME types: IOTASerializerV2, JSON.
+func NewResponseStreamEncoder(writer io.Writer, api iotago.API, mimeType string) (*ResponseStreamEncoder, error) {
+	if mimeType != iotaapi.MIMEApplicationVendorIOTASerializerV2 && mimeType != echo.MIMEApplicationJSON {
+		return nil, ierrors.Wrapf(ErrNotAcceptable, "unsupported MIME type for streaming: %s", mimeType)
+	}
+
+	return &ResponseStreamEncoder{
+		writer:   writer,
+		api:      api,
+		mimeType: mimeType,
+	}, nil
+}
+
+// Count returns the amount of items that were encoded.
+func (e *ResponseStreamEncoder) Count() int {
+	return e.count
+}
+
+// Encode writes the next item.
+func (e *ResponseStreamEncoder) Encode(item any) error {
+	bytes, err := e.encodeItem(item)
+	if err != nil {
+		return err
+	}
+
+	if e.beforeWrite != nil {
+		e.beforeWrite()
+	}
+
+	switch e.mimeType {
+	case iotaapi.MIMEApplicationVendorIOTASerializerV2:
+		var lengthPrefix [4]byte
+		binary.LittleEndian.PutUint32(lengthPrefix[:], uint32(len(bytes)))
+
+		if _, err := e.writer.Write(lengthPrefix[:]); err != nil {
+			return err
+		}
+
+	default:
+		delimiter := []byte(",")
+		if e.count == 0 {
+			delimiter = []byte("[")
+		}
+
+		if _, err := e.writer.Write(delimiter); err != nil {
+			return err
+		}
+	}
+
+	if _, err := e.writer.Write(bytes); err != nil {
+		return err
+	}
+	e.count++
+
+	return nil
+}
+
+// Close terminates the sequence. It has to be called after the last item was encoded.
+func (e *ResponseStreamEncoder) Close() error {
+	if e.mimeType != echo.MIMEApplicationJSON {
+		return nil
+	}
+
+	terminator := []byte("]")
+	if e.count == 0 {
+		terminator = []byte("[]")
+	}
+
+	_, err := e.writer.Write(terminator)
+
+	return err
+}
+
+func (e *ResponseStreamEncoder) encodeItem(item any) ([]byte, error) {
+	if serializer, exists := responseSerializer(item, e.mimeType); exists {
+		bytes, err := serializer(item)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "failed to serialize item with custom serializer for %s", e.mimeType)
+		}
+
+		return bytes, nil
+	}
+
+	if e.mimeType == iotaapi.MIMEApplicationVendorIOTASerializerV2 {
+		bytes, err := e.api.Encode(item)
+		if err != nil {
+			return nil, ierrors.Wrap(err, "failed to encode binary data")
+		}
+
+		return bytes, nil
+	}
+
+	bytes, err := e.api.JSONEncode(item)
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to encode json data")
+	}
+
+	return bytes, nil
+}
+
+// SendStreamResponseByHeader streams a sequence of items based on the MIME type in the accept header.
+// The items are produced by itemsFunc, which passes every item to the given encode function.
+// Supported MIME types: IOTASerializerV2, JSON.
+// If the MIME type is not supported, or there is none, it defaults to JSON.
+//
+// The response is committed with the first item, so errors returned by itemsFunc afterwards
+// can't be reported to the client anymore and only end the response.
+func SendStreamResponseByHeader[T any](c echo.Context, api iotago.API, itemsFunc func(encode func(item T) error) error, httpStatusCode ...int) error {
+	mimeType, err := GetAcceptHeaderContentType(c, iotaapi.MIMEApplicationVendorIOTASerializerV2, echo.MIMEApplicationJSON)
+	if err != nil && !ierrors.Is(err, ErrNotAcceptable) {
+		return err
+	}
+
+	if mimeType == "" {
+		mimeType = echo.MIMEApplicationJSON
+	}
+
+	statusCode := http.StatusOK
+	if len(httpStatusCode) > 0 {
+		statusCode = httpStatusCode[0]
+	}
+
+	response := c.Response()
+
+	encoder, err := NewResponseStreamEncoder(response, api, mimeType)
+	if err != nil {
+		return err
+	}
+
+	encoder.beforeWrite = func() {
+		if response.Committed {
+			return
+		}
+
+		response.Header().Set(echo.HeaderContentType, mimeType)
+		response.WriteHeader(statusCode)
+	}
+
+	if err := itemsFunc(func(item T) error {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+
+		if encoder.Count()%streamFlushInterval == 0 {
+			response.Flush()
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// commit the response in case there were no items
+	encoder.beforeWrite()
+
+	return encoder.Close()
+}
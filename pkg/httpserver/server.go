@@ -0,0 +1,263 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultServerShutdownTimeout is the default timeout for the graceful shutdown of every listener of the Server.
+	DefaultServerShutdownTimeout = 5 * time.Second
+)
+
+var (
+	// ErrServerNoListeners is returned if the Server was started without listeners.
+	ErrServerNoListeners = ierrors.New("no listeners configured")
+	// ErrServerSocketPathInUse is returned if the path of a Unix socket listener exists but is not a socket.
+	ErrServerSocketPathInUse = ierrors.New("unix socket path exists and is not a socket")
+)
+
+// serverListener is a listener the Server binds the Echo instance on.
+type serverListener struct {
+	network string
+	address string
+
+	// tlsConfig is used if TLS is enabled, it is loaded from the certFile and keyFile if not set.
+	tlsConfig *tls.Config
+	certFile  string
+	keyFile   string
+
+	listener net.Listener
+	server   *http.Server
+}
+
+func (l *serverListener) tlsEnabled() bool {
+	return l.tlsConfig != nil || l.certFile != ""
+}
+
+// Server serves an Echo instance on multiple listeners at the same time, e.g. on a TCP port for the node
+// and on a Unix socket or a TLS port for a reverse proxy. Every listener is shut down gracefully on its own.
+type Server struct {
+	echo            *echo.Echo
+	listeners       []*serverListener
+	shutdownTimeout time.Duration
+
+	addressesMutex sync.RWMutex
+	addresses      []net.Addr
+}
+
+// WithTCPListener serves the Echo instance on the given TCP address ("host:port").
+func WithTCPListener(address string) options.Option[Server] {
+	return func(s *Server) {
+		s.listeners = append(s.listeners, &serverListener{network: "tcp", address: address})
+	}
+}
+
+// WithUnixListener serves the Echo instance on a Unix socket at the given path.
+// An existing socket file at the path is removed before the listener is created.
+func WithUnixListener(path string) options.Option[Server] {
+	return func(s *Server) {
+		s.listeners = append(s.listeners, &serverListener{network: "unix", address: path})
+	}
+}
+
+// WithTLSListener serves the Echo instance via TLS on the given TCP address ("host:port"),
+// with the certificate and the private key loaded from the given PEM encoded files.
+func WithTLSListener(address string, certFile string, keyFile string) options.Option[Server] {
+	return func(s *Server) {
+		s.listeners = append(s.listeners, &serverListener{network: "tcp", address: address, certFile: certFile, keyFile: keyFile})
+	}
+}
+
+// WithTLSConfigListener serves the Echo instance via TLS on the given TCP address ("host:port") with the given TLS config.
+func WithTLSConfigListener(address string, tlsConfig *tls.Config) options.Option[Server] {
+	return func(s *Server) {
+		s.listeners = append(s.listeners, &serverListener{network: "tcp", address: address, tlsConfig: tlsConfig})
+	}
+}
+
+// WithServerShutdownTimeout sets the timeout for the graceful shutdown of every listener.
+func WithServerShutdownTimeout(timeout time.Duration) options.Option[Server] {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// NewServer creates a new Server that serves the given Echo instance on the configured listeners.
+func NewServer(e *echo.Echo, opts ...options.Option[Server]) *Server {
+	return options.Apply(&Server{
+		echo:            e,
+		shutdownTimeout: DefaultServerShutdownTimeout,
+	}, opts)
+}
+
+// Addresses returns the addresses the listeners are bound to, e.g. to get the port if it was chosen by the system.
+// It is empty if the server is not running.
+func (s *Server) Addresses() []net.Addr {
+	s.addressesMutex.RLock()
+	defer s.addressesMutex.RUnlock()
+
+	return append([]net.Addr(nil), s.addresses...)
+}
+
+// Run binds all listeners and serves the Echo instance on them until the context is canceled or one of them fails.
+// If a listener can't be bound, the already bound listeners are closed and the error is returned.
+// Afterwards every listener is shut down gracefully, errors during the shutdown are returned as well.
+func (s *Server) Run(ctx context.Context) error {
+	if len(s.listeners) == 0 {
+		return ErrServerNoListeners
+	}
+
+	for _, l := range s.listeners {
+		if err := s.listen(l); err != nil {
+			return ierrors.Join(ierrors.Wrapf(err, "failed to listen on %s://%s", l.network, l.address), s.closeListeners())
+		}
+	}
+
+	addresses := make([]net.Addr, 0, len(s.listeners))
+	for _, l := range s.listeners {
+		addresses = append(addresses, l.listener.Addr())
+	}
+	s.addressesMutex.Lock()
+	s.addresses = addresses
+	s.addressesMutex.Unlock()
+
+	serverErrChan := make(chan error, len(s.listeners))
+	for _, l := range s.listeners {
+		go func(l *serverListener) {
+			if err := l.server.Serve(l.listener); err != nil && !ierrors.Is(err, http.ErrServerClosed) {
+				serverErrChan <- ierrors.Wrapf(err, "API server on %s://%s stopped", l.network, l.address)
+			}
+		}(l)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+	case runErr = <-serverErrChan:
+	}
+
+	//nolint:contextcheck // the listeners need to be shut down even if the context is already canceled
+	return ierrors.Join(runErr, s.shutdown())
+}
+
+// listen binds the listener and creates its HTTP server.
+func (s *Server) listen(l *serverListener) error {
+	tlsConfig := l.tlsConfig
+	if tlsConfig == nil && l.certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+		if err != nil {
+			return ierrors.Wrap(err, "failed to load TLS certificate")
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	if l.network == "unix" {
+		if err := removeStaleSocket(l.address); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen(l.network, l.address)
+	if err != nil {
+		return err
+	}
+	if l.tlsEnabled() {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	l.listener = listener
+	l.server = &http.Server{
+		Handler:           s.echo,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       s.echo.Server.ReadTimeout,
+		ReadHeaderTimeout: s.echo.Server.ReadHeaderTimeout,
+		WriteTimeout:      s.echo.Server.WriteTimeout,
+		IdleTimeout:       s.echo.Server.IdleTimeout,
+		MaxHeaderBytes:    s.echo.Server.MaxHeaderBytes,
+	}
+
+	return nil
+}
+
+// removeStaleSocket removes the socket file of a previous run at the given path, which prevents the listener from being created.
+// Other files at the path are not removed, because the path is most likely misconfigured.
+func removeStaleSocket(path string) error {
+	fileInfo, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return ierrors.Wrap(err, "failed to check existing socket file")
+	}
+
+	if fileInfo.Mode()&os.ModeSocket == 0 {
+		return ierrors.Wrapf(ErrServerSocketPathInUse, "path: %s", path)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return ierrors.Wrap(err, "failed to remove existing socket file")
+	}
+
+	return nil
+}
+
+// closeListeners closes the listeners that were already bound.
+func (s *Server) closeListeners() error {
+	var err error
+	for _, l := range s.listeners {
+		if l.listener == nil {
+			continue
+		}
+
+		if closeErr := l.listener.Close(); closeErr != nil {
+			err = ierrors.Join(err, ierrors.Wrapf(closeErr, "failed to close listener on %s://%s", l.network, l.address))
+		}
+		l.listener = nil
+		l.server = nil
+	}
+
+	return err
+}
+
+// shutdown shuts down all listeners gracefully and concurrently, every listener with its own timeout.
+func (s *Server) shutdown() error {
+	s.addressesMutex.Lock()
+	s.addresses = nil
+	s.addressesMutex.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(s.listeners))
+	for i, l := range s.listeners {
+		wg.Add(1)
+		go func(i int, l *serverListener) {
+			defer wg.Done()
+
+			ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), s.shutdownTimeout)
+			defer cancelShutdown()
+
+			if err := l.server.Shutdown(ctxShutdown); err != nil {
+				errs[i] = ierrors.Wrapf(err, "failed to shut down API server on %s://%s", l.network, l.address)
+			}
+			l.listener = nil
+			l.server = nil
+		}(i, l)
+	}
+	wg.Wait()
+
+	return ierrors.Join(errs...)
+}
@@ -0,0 +1,246 @@
+package httpserver
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// MIMETextEventStream is the MIME type of server-sent events.
+	MIMETextEventStream = "text/event-stream"
+	// HeaderLastEventID is the header that contains the ID of the last event a reconnecting client received.
+	HeaderLastEventID = "Last-Event-ID"
+
+	// DefaultSSEKeepAliveInterval is the default interval in which keep-alive comments are sent if there were no events.
+	DefaultSSEKeepAliveInterval = 15 * time.Second
+	// DefaultSSEBufferSize is the default amount of events that are buffered per client.
+	DefaultSSEBufferSize = 100
+)
+
+// ErrSSEClientTooSlow is returned if the buffer of a server-sent events client was full, so events were lost.
+var ErrSSEClientTooSlow = ierrors.New("server-sent events client could not keep up")
+
+// SSEEvent is a server-sent event.
+type SSEEvent struct {
+	// ID is the ID of the event, which the client sends in the Last-Event-ID header if it reconnects.
+	// It is omitted if it is empty.
+	ID string
+	// Event is the type of the event, it is omitted if it is empty.
+	Event string
+	// Data is the payload of the event, every line is sent as a separate data field.
+	Data []byte
+	// Retry is the time the client waits before it reconnects, it is omitted if it is zero.
+	Retry time.Duration
+}
+
+// SSEWriter writes server-sent events to the response.
+// It is safe to use from multiple goroutines.
+type SSEWriter struct {
+	mutex    sync.Mutex
+	response *echo.Response
+}
+
+// NewSSEWriter commits the response with the headers of an event stream and returns a writer for the events.
+func NewSSEWriter(c echo.Context) *SSEWriter {
+	response := c.Response()
+
+	response.Header().Set(echo.HeaderContentType, MIMETextEventStream)
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	// disables the response buffering of reverse proxies like nginx
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	return &SSEWriter{
+		response: response,
+	}
+}
+
+// LastEventID returns the ID of the last event a reconnecting client received,
+// or an empty string if the client connects for the first time.
+func LastEventID(c echo.Context) string {
+	return c.Request().Header.Get(HeaderLastEventID)
+}
+
+// Send writes the event and flushes it to the client.
+func (w *SSEWriter) Send(sseEvent *SSEEvent) error {
+	var buffer bytes.Buffer
+
+	if sseEvent.ID != "" {
+		writeSSEField(&buffer, "id", sseEvent.ID)
+	}
+	if sseEvent.Event != "" {
+		writeSSEField(&buffer, "event", sseEvent.Event)
+	}
+	if sseEvent.Retry > 0 {
+		writeSSEField(&buffer, "retry", strconv.FormatInt(sseEvent.Retry.Milliseconds(), 10))
+	}
+	for _, line := range strings.Split(string(sseEvent.Data), "\n") {
+		writeSSEField(&buffer, "data", strings.TrimSuffix(line, "\r"))
+	}
+	buffer.WriteByte('\n')
+
+	return w.write(buffer.Bytes())
+}
+
+// KeepAlive writes a comment that keeps the connection open, e.g. if there is a proxy with an idle timeout in between.
+func (w *SSEWriter) KeepAlive() error {
+	return w.write([]byte(": keep-alive\n\n"))
+}
+
+func (w *SSEWriter) write(data []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, err := w.response.Write(data); err != nil {
+		return err
+	}
+	w.response.Flush()
+
+	return nil
+}
+
+func writeSSEField(buffer *bytes.Buffer, name string, value string) {
+	// line breaks are not allowed in the fields, they would end the field
+	value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+
+	buffer.WriteString(name)
+	buffer.WriteString(": ")
+	buffer.WriteString(value)
+	buffer.WriteByte('\n')
+}
+
+// NewSSEJSONEvent creates an event with the JSON encoded item as data.
+// The item is encoded like the items of SendStreamResponseByHeader, so custom serializers are used if registered.
+func NewSSEJSONEvent(api iotago.API, id string, eventType string, item any) (*SSEEvent, error) {
+	var data []byte
+	var err error
+
+	if serializer, exists := responseSerializer(item, echo.MIMEApplicationJSON); exists {
+		data, err = serializer(item)
+	} else {
+		data, err = api.JSONEncode(item)
+	}
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to encode json data")
+	}
+
+	return &SSEEvent{
+		ID:    id,
+		Event: eventType,
+		Data:  data,
+	}, nil
+}
+
+// SSEOptions are the options of SendSSEEvents.
+type SSEOptions struct {
+	keepAliveInterval time.Duration
+	bufferSize        int
+	retry             time.Duration
+}
+
+// WithSSEKeepAliveInterval sets the interval in which keep-alive comments are sent if there were no events.
+// Keep-alive comments are disabled if the interval is 0.
+func WithSSEKeepAliveInterval(interval time.Duration) options.Option[SSEOptions] {
+	return func(o *SSEOptions) {
+		o.keepAliveInterval = interval
+	}
+}
+
+// WithSSEBufferSize sets the amount of events that are buffered for the client.
+// If the buffer is full, the stream ends with ErrSSEClientTooSlow, so the client reconnects and can resume via the Last-Event-ID.
+func WithSSEBufferSize(bufferSize int) options.Option[SSEOptions] {
+	return func(o *SSEOptions) {
+		o.bufferSize = bufferSize
+	}
+}
+
+// WithSSERetry sets the time the client waits before it reconnects, which is sent to the client before the first event.
+func WithSSERetry(retry time.Duration) options.Option[SSEOptions] {
+	return func(o *SSEOptions) {
+		o.retry = retry
+	}
+}
+
+// SendSSEEvents streams the triggers of the given event to the client as server-sent events,
+// e.g. the StreamEvents of the NodeBridge. The triggers are converted via encode, if it returns nil the trigger is skipped.
+// It blocks until the client disconnects or the stream ended because of an error.
+//
+// The event is not blocked by the client, the triggers are buffered and the stream ends with ErrSSEClientTooSlow
+// if the client can't keep up. The error can't be reported to the client anymore, since the response is already committed.
+func SendSSEEvents[T any](c echo.Context, e *event.Event1[T], encode func(item T) (*SSEEvent, error), opts ...options.Option[SSEOptions]) error {
+	sseOptions := options.Apply(&SSEOptions{
+		keepAliveInterval: DefaultSSEKeepAliveInterval,
+		bufferSize:        DefaultSSEBufferSize,
+	}, opts)
+
+	items := make(chan T, sseOptions.bufferSize)
+	// overflow is closed if the buffer of the client was full
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+
+	hook := e.Hook(func(item T) {
+		select {
+		case items <- item:
+		default:
+			overflowOnce.Do(func() { close(overflow) })
+		}
+	})
+	defer hook.Unhook()
+
+	writer := NewSSEWriter(c)
+	if sseOptions.retry > 0 {
+		if err := writer.write([]byte("retry: " + strconv.FormatInt(sseOptions.retry.Milliseconds(), 10) + "\n\n")); err != nil {
+			return err
+		}
+	}
+
+	var keepAlive <-chan time.Time
+	if sseOptions.keepAliveInterval > 0 {
+		ticker := time.NewTicker(sseOptions.keepAliveInterval)
+		defer ticker.Stop()
+
+		keepAlive = ticker.C
+	}
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-overflow:
+			return ErrSSEClientTooSlow
+
+		case <-keepAlive:
+			if err := writer.KeepAlive(); err != nil {
+				return err
+			}
+
+		case item := <-items:
+			sseEvent, err := encode(item)
+			if err != nil {
+				return err
+			}
+			if sseEvent == nil {
+				continue
+			}
+
+			if err := writer.Send(sseEvent); err != nil {
+				return err
+			}
+		}
+	}
+}
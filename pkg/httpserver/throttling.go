@@ -0,0 +1,67 @@
+package httpserver
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+)
+
+// ErrTooManyRequests defines the too many requests error.
+var ErrTooManyRequests = echo.NewHTTPError(http.StatusTooManyRequests, "too many requests")
+
+// RetryAfterSeconds returns the value of the Retry-After header for the given duration.
+// The duration is rounded up to full seconds, with a minimum of one second.
+func RetryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.FormatInt(int64(math.Max(1, math.Ceil(retryAfter.Seconds()))), 10)
+}
+
+// TooManyRequests sets the Retry-After header and returns an ErrTooManyRequests error,
+// which results in the standard error envelope with status code 429.
+func TooManyRequests(c echo.Context, retryAfter time.Duration, reason string) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, RetryAfterSeconds(retryAfter))
+
+	return ierrors.Wrap(ErrTooManyRequests, reason)
+}
+
+// IsCongestionError returns true if the error is an INX error of the NodeBridge that signals
+// that the node is congested and the request should be retried later.
+// Exceeded gRPC message size limits are reported with the same status code, but are not retryable,
+// so they are not treated as congestion.
+func IsCongestionError(err error) bool {
+	return ierrors.Is(err, nodebridge.ErrTooManyRequests)
+}
+
+// HandleCongestionError translates INX congestion errors into the same 429 response
+// that is used by the throttling middlewares. Other errors are returned unchanged.
+func HandleCongestionError(c echo.Context, err error, retryAfter time.Duration) error {
+	if !IsCongestionError(err) {
+		return err
+	}
+
+	return TooManyRequests(c, retryAfter, "node is congested")
+}
+
+// ConcurrencyLimiterMiddleware returns a middleware that limits the amount of requests which are processed concurrently.
+// Requests exceeding the limit are rejected with status code 429 and the given Retry-After duration.
+func ConcurrencyLimiterMiddleware(maxConcurrentRequests int, retryAfter time.Duration) echo.MiddlewareFunc {
+	semaphore := make(chan struct{}, maxConcurrentRequests)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+
+				return next(c)
+			default:
+				return TooManyRequests(c, retryAfter, "too many concurrent requests")
+			}
+		}
+	}
+}
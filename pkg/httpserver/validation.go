@@ -0,0 +1,117 @@
+package httpserver
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+const (
+	// requestValidationModeContextKey is the key of the validation mode of requests in the echo context.
+	requestValidationModeContextKey = "requestValidationMode"
+	// responseValidationModeContextKey is the key of the validation mode of responses in the echo context.
+	responseValidationModeContextKey = "responseValidationMode"
+)
+
+// ValidationMode defines how objects are validated while they are decoded from requests or encoded into responses.
+type ValidationMode int
+
+const (
+	// ValidationModeStrict checks the syntactic rules of serix, e.g. the length bounds and the lexical order of arrays
+	// and the validators registered at the API, and runs the custom request validators.
+	ValidationModeStrict ValidationMode = iota
+	// ValidationModeCustomOnly only requires the objects to be decodable, the syntactic rules of serix are not checked,
+	// but the custom request validators are run.
+	ValidationModeCustomOnly
+	// ValidationModeNone neither checks the syntactic rules of serix nor runs the custom request validators,
+	// e.g. for internal endpoints that accept partial objects which are not valid yet.
+	ValidationModeNone
+)
+
+// serixOptions returns the serix options of the validation mode.
+// Serix validation can only be enabled as a whole, so only ValidationModeStrict enables it.
+func (m ValidationMode) serixOptions() []serix.Option {
+	if m == ValidationModeStrict {
+		return []serix.Option{serix.WithValidation()}
+	}
+
+	return nil
+}
+
+// ValidationMiddleware returns a middleware that sets the validation mode of ParseRequestByHeader
+// and SendResponseByHeader for the routes it is added to.
+// Without the middleware, requests are validated strictly and responses are not validated.
+func ValidationMiddleware(requestMode ValidationMode, responseMode ValidationMode) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(requestValidationModeContextKey, requestMode)
+			c.Set(responseValidationModeContextKey, responseMode)
+
+			return next(c)
+		}
+	}
+}
+
+// responseValidationMode returns the validation mode of responses that was set by the ValidationMiddleware.
+func responseValidationMode(c echo.Context) ValidationMode {
+	if mode, ok := c.Get(responseValidationModeContextKey).(ValidationMode); ok {
+		return mode
+	}
+
+	return ValidationModeNone
+}
+
+// RequestParseOptions are the options of ParseRequestByHeader.
+type RequestParseOptions struct {
+	validationMode *ValidationMode
+	validators     []func(obj any) error
+}
+
+// WithRequestValidationMode sets the validation mode of the call, which overrides the mode of the ValidationMiddleware.
+func WithRequestValidationMode(mode ValidationMode) options.Option[RequestParseOptions] {
+	return func(o *RequestParseOptions) {
+		o.validationMode = &mode
+	}
+}
+
+// WithRequestValidator adds a custom validator that is called with the decoded object,
+// unless the validation mode is ValidationModeNone. Errors are returned wrapped with ErrInvalidParameter.
+// The type of the validator needs to match the type that is parsed.
+func WithRequestValidator[T any](validator func(obj T) error) options.Option[RequestParseOptions] {
+	return func(o *RequestParseOptions) {
+		o.validators = append(o.validators, func(obj any) error {
+			typedObj, ok := obj.(T)
+			if !ok {
+				return ierrors.Errorf("request validator expects %T, got %T", *new(T), obj)
+			}
+
+			return validator(typedObj)
+		})
+	}
+}
+
+// requestValidationMode returns the validation mode of the call, or the mode that was set by the ValidationMiddleware.
+func (o *RequestParseOptions) requestValidationMode(c echo.Context) ValidationMode {
+	if o.validationMode != nil {
+		return *o.validationMode
+	}
+
+	if mode, ok := c.Get(requestValidationModeContextKey).(ValidationMode); ok {
+		return mode
+	}
+
+	return ValidationModeStrict
+}
+
+// validate runs the custom validators with the decoded object.
+func (o *RequestParseOptions) validate(obj any) error {
+	for _, validator := range o.validators {
+		if err := validator(obj); err != nil {
+			return ierrors.Errorf("%w: invalid request: %w", ErrInvalidParameter, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package nodebridge
+
+import (
+	"context"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// AccountChangeType is the type of an AccountChange.
+type AccountChangeType byte
+
+const (
+	// AccountChangeTypeCreated is the type of accounts that were created in the slot.
+	AccountChangeTypeCreated AccountChangeType = iota
+	// AccountChangeTypeUpdated is the type of accounts that were transitioned in the slot.
+	AccountChangeTypeUpdated
+	// AccountChangeTypeDestroyed is the type of accounts that were destroyed in the slot.
+	AccountChangeTypeDestroyed
+)
+
+// String returns the name of the AccountChangeType.
+func (t AccountChangeType) String() string {
+	switch t {
+	case AccountChangeTypeCreated:
+		return "created"
+	case AccountChangeTypeUpdated:
+		return "updated"
+	case AccountChangeTypeDestroyed:
+		return "destroyed"
+	default:
+		return "unknown"
+	}
+}
+
+// AccountChange is the change of an account within a slot.
+type AccountChange struct {
+	// Type is the type of the change.
+	Type AccountChangeType
+	// AccountID is the ID of the account.
+	AccountID iotago.AccountID
+	// Previous is the account output at the start of the slot, it is nil if the account was created.
+	Previous *Output
+	// Current is the account output at the end of the slot, it is nil if the account was destroyed.
+	Current *Output
+}
+
+// PreviousAccount returns the account output at the start of the slot, or nil if the account was created.
+func (c *AccountChange) PreviousAccount() *iotago.AccountOutput {
+	if c.Previous == nil {
+		return nil
+	}
+
+	//nolint:forcetypeassert // only account outputs are added to account changes
+	return c.Previous.Output.(*iotago.AccountOutput)
+}
+
+// CurrentAccount returns the account output at the end of the slot, or nil if the account was destroyed.
+func (c *AccountChange) CurrentAccount() *iotago.AccountOutput {
+	if c.Current == nil {
+		return nil
+	}
+
+	//nolint:forcetypeassert // only account outputs are added to account changes
+	return c.Current.Output.(*iotago.AccountOutput)
+}
+
+// BlockIssuerChanged returns true if the block issuer feature (keys or expiry) of the account changed,
+// or if the account was created or destroyed with a block issuer feature.
+func (c *AccountChange) BlockIssuerChanged() bool {
+	var previous, current *iotago.BlockIssuerFeature
+	if account := c.PreviousAccount(); account != nil {
+		previous = account.FeatureSet().BlockIssuer()
+	}
+	if account := c.CurrentAccount(); account != nil {
+		current = account.FeatureSet().BlockIssuer()
+	}
+
+	if previous == nil || current == nil {
+		return previous != current
+	}
+
+	return !previous.Equal(current)
+}
+
+// StakingChanged returns true if the staking feature of the account changed,
+// or if the account was created or destroyed with a staking feature.
+func (c *AccountChange) StakingChanged() bool {
+	var previous, current *iotago.StakingFeature
+	if account := c.PreviousAccount(); account != nil {
+		previous = account.FeatureSet().Staking()
+	}
+	if account := c.CurrentAccount(); account != nil {
+		current = account.FeatureSet().Staking()
+	}
+
+	if previous == nil || current == nil {
+		return previous != current
+	}
+
+	return !previous.Equal(current)
+}
+
+// AccountChanges are the changes of all accounts within a committed slot.
+type AccountChanges struct {
+	API          iotago.API
+	CommitmentID iotago.CommitmentID
+	// Changes are the changes of the accounts, in the order of the ledger update.
+	Changes []*AccountChange
+}
+
+// AccountChangesFromLedgerUpdate derives the account changes from the given ledger update.
+// An account is created if there is no consumed output of it, destroyed if there is no created output of it,
+// and updated otherwise.
+func AccountChangesFromLedgerUpdate(update *LedgerUpdate) *AccountChanges {
+	previousOutputs := make(map[iotago.AccountID]*Output)
+	for _, output := range update.Consumed {
+		if account, isAccount := output.Output.(*iotago.AccountOutput); isAccount {
+			previousOutputs[accountIDFromOutput(output.OutputID, account)] = output
+		}
+	}
+
+	changes := make([]*AccountChange, 0, len(previousOutputs))
+
+	for _, output := range update.Created {
+		account, isAccount := output.Output.(*iotago.AccountOutput)
+		if !isAccount {
+			continue
+		}
+
+		accountID := accountIDFromOutput(output.OutputID, account)
+
+		previous, exists := previousOutputs[accountID]
+		if !exists {
+			changes = append(changes, &AccountChange{
+				Type:      AccountChangeTypeCreated,
+				AccountID: accountID,
+				Current:   output,
+			})
+
+			continue
+		}
+		delete(previousOutputs, accountID)
+
+		changes = append(changes, &AccountChange{
+			Type:      AccountChangeTypeUpdated,
+			AccountID: accountID,
+			Previous:  previous,
+			Current:   output,
+		})
+	}
+
+	// the remaining consumed accounts have no successor, so they were destroyed
+	for _, output := range update.Consumed {
+		account, isAccount := output.Output.(*iotago.AccountOutput)
+		if !isAccount {
+			continue
+		}
+
+		accountID := accountIDFromOutput(output.OutputID, account)
+		if _, destroyed := previousOutputs[accountID]; !destroyed {
+			continue
+		}
+
+		changes = append(changes, &AccountChange{
+			Type:      AccountChangeTypeDestroyed,
+			AccountID: accountID,
+			Previous:  output,
+		})
+	}
+
+	return &AccountChanges{
+		API:          update.API,
+		CommitmentID: update.CommitmentID,
+		Changes:      changes,
+	}
+}
+
+// accountIDFromOutput returns the ID of the account, which is derived from the output ID for new accounts.
+func accountIDFromOutput(outputID iotago.OutputID, account *iotago.AccountOutput) iotago.AccountID {
+	if account.AccountID.Empty() {
+		return iotago.AccountIDFromOutputID(outputID)
+	}
+
+	return account.AccountID
+}
+
+// ListenToAccountChanges listens to the changes of accounts per committed slot.
+// The changes are derived from the ledger updates, so the block issuance credits of the accounts are not included.
+// Slots without account changes are not passed to the consumer.
+func (n *nodeBridge) ListenToAccountChanges(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(changes *AccountChanges) error) error {
+	// only account outputs are relevant, so all other outputs are filtered before they are unwrapped
+	filter := NewOutputFilter(WithOutputTypes(iotago.OutputAccount))
+
+	return n.listenToLedgerUpdates(ctx, startSlot, endSlot, filter, func(update *LedgerUpdate) error {
+		changes := AccountChangesFromLedgerUpdate(update)
+		if len(changes.Changes) == 0 {
+			return nil
+		}
+
+		return consumer(changes)
+	})
+}
@@ -2,9 +2,15 @@ package nodebridge
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
 )
 
 // ReadIsCandidate returns true if the given account is a candidate.
@@ -36,3 +42,93 @@ func (n *nodeBridge) ReadIsValidatorAccount(ctx context.Context, id iotago.Accou
 
 	return result.GetValue(), nil
 }
+
+// ReadCommittee returns the committee of the given epoch.
+func (n *nodeBridge) ReadCommittee(ctx context.Context, epoch iotago.EpochIndex) (*api.CommitteeResponse, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return nodeClient.Committee(ctx, epoch)
+}
+
+// ReadValidators returns a page of the validators that were registered for the given epoch.
+// If the cursor is empty, the first page is returned, otherwise the page that starts at the cursor
+// of the previous response. The cursor of the response is empty if it is the last page.
+func (n *nodeBridge) ReadValidators(ctx context.Context, epoch iotago.EpochIndex, cursor string) (*api.ValidatorsResponse, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor == "" {
+		cursor = ValidatorsCursor(epoch, 0)
+	}
+
+	return nodeClient.Validators(ctx, 0, cursor)
+}
+
+// ReadAllValidators returns all validators that were registered for the given epoch by following the pages.
+func (n *nodeBridge) ReadAllValidators(ctx context.Context, epoch iotago.EpochIndex) ([]*api.ValidatorResponse, error) {
+	return ReadAllValidatorPages(ctx, epoch, n.ReadValidators)
+}
+
+// StakingInfo returns the staking data of the given validator account,
+// e.g. its pool stake, its fixed cost and whether it was active recently.
+func (n *nodeBridge) StakingInfo(ctx context.Context, accountID iotago.AccountID) (*api.ValidatorResponse, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:forcetypeassert // the address of an account ID is always an account address
+	return nodeClient.Validator(ctx, accountID.ToAddress().(*iotago.AccountAddress))
+}
+
+// RewardsForOutput returns the mana rewards the given staking account or delegation output can claim in the given epoch.
+// The rewards are decayed up to the claim epoch. If the claim epoch is 0, the rewards are claimed in the latest committed slot.
+func (n *nodeBridge) RewardsForOutput(ctx context.Context, outputID iotago.OutputID, claimEpoch iotago.EpochIndex) (*api.ManaRewardsResponse, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	route := api.EndpointWithNamedParameterValue(api.CoreRouteRewards, api.ParameterOutputID, outputID.ToHex())
+	if claimEpoch != 0 {
+		claimSlot := n.apiProvider.APIForEpoch(claimEpoch).TimeProvider().EpochStart(claimEpoch)
+		route += "?" + url.Values{api.ParameterSlot: []string{strconv.FormatUint(uint64(claimSlot), 10)}}.Encode()
+	}
+
+	rewards := new(api.ManaRewardsResponse)
+	//nolint:bodyclose // the body is closed by the node client
+	if _, err := nodeClient.DoWithRequestHeaderHook(ctx, http.MethodGet, route, nodeclient.RequestHeaderHookAcceptJSON, nil, rewards); err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
+}
+
+// ValidatorsCursor returns the cursor of the validators of the given epoch starting at the given index.
+func ValidatorsCursor(epoch iotago.EpochIndex, index uint32) string {
+	return fmt.Sprintf("%d,%d", epoch, index)
+}
+
+// ReadAllValidatorPages reads all pages of the validators of the given epoch with the given page reader.
+func ReadAllValidatorPages(ctx context.Context, epoch iotago.EpochIndex, readPage func(ctx context.Context, epoch iotago.EpochIndex, cursor string) (*api.ValidatorsResponse, error)) ([]*api.ValidatorResponse, error) {
+	validators := make([]*api.ValidatorResponse, 0)
+
+	cursor := ""
+	for {
+		response, err := readPage(ctx, epoch, cursor)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, response.Validators...)
+
+		if response.Cursor == "" {
+			return validators, nil
+		}
+		cursor = response.Cursor
+	}
+}
@@ -0,0 +1,188 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// AddressSet is a set of addresses that can be modified while it is used by ListenToAddressActivity.
+// It is safe for concurrent use.
+type AddressSet struct {
+	mutex     sync.RWMutex
+	addresses map[string]iotago.Address
+}
+
+// NewAddressSet creates a new AddressSet that contains the given addresses.
+func NewAddressSet(addresses ...iotago.Address) *AddressSet {
+	s := &AddressSet{
+		addresses: make(map[string]iotago.Address, len(addresses)),
+	}
+	s.Add(addresses...)
+
+	return s
+}
+
+// Add adds the given addresses to the set.
+func (s *AddressSet) Add(addresses ...iotago.Address) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, address := range addresses {
+		s.addresses[address.Key()] = address
+	}
+}
+
+// Remove removes the given addresses from the set.
+func (s *AddressSet) Remove(addresses ...iotago.Address) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, address := range addresses {
+		delete(s.addresses, address.Key())
+	}
+}
+
+// Contains returns true if the given address is part of the set.
+func (s *AddressSet) Contains(address iotago.Address) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.addresses[address.Key()]
+
+	return exists
+}
+
+// Addresses returns all addresses of the set.
+func (s *AddressSet) Addresses() []iotago.Address {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	addresses := make([]iotago.Address, 0, len(s.addresses))
+	for _, address := range s.addresses {
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+// Size returns the amount of addresses in the set.
+func (s *AddressSet) Size() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.addresses)
+}
+
+// matchingAddresses returns the addresses of the set that are contained in the unlock conditions of the given output.
+func (s *AddressSet) matchingAddresses(output iotago.Output) []iotago.Address {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var matching []iotago.Address
+	for _, address := range unlockConditionAddresses(output.UnlockConditionSet()) {
+		if _, exists := s.addresses[address.Key()]; exists {
+			matching = append(matching, address)
+		}
+	}
+
+	return matching
+}
+
+// AddressActivityType is the type of an AddressActivity.
+type AddressActivityType byte
+
+const (
+	// AddressActivityTypeCreated is the type of outputs that were created for the address.
+	AddressActivityTypeCreated AddressActivityType = iota
+	// AddressActivityTypeSpent is the type of outputs of the address that were spent.
+	AddressActivityTypeSpent
+)
+
+// String returns the name of the AddressActivityType.
+func (t AddressActivityType) String() string {
+	switch t {
+	case AddressActivityTypeCreated:
+		return "created"
+	case AddressActivityTypeSpent:
+		return "spent"
+	default:
+		return "unknown"
+	}
+}
+
+// AddressActivity is an output that was created for or spent by an address.
+type AddressActivity struct {
+	// Type is the type of the activity.
+	Type AddressActivityType
+	// Address is the address that is contained in one of the unlock conditions of the output.
+	Address iotago.Address
+	// Output is the created or spent output.
+	Output *Output
+}
+
+// AddressActivities are the activities of all tracked addresses within a committed slot.
+type AddressActivities struct {
+	API          iotago.API
+	CommitmentID iotago.CommitmentID
+	// Activities are the activities of the addresses, the spent outputs are listed before the created outputs.
+	// An output that contains several tracked addresses results in one activity per address.
+	Activities []*AddressActivity
+}
+
+// ForAddress returns the activities of the given address.
+func (a *AddressActivities) ForAddress(address iotago.Address) []*AddressActivity {
+	activities := make([]*AddressActivity, 0)
+	for _, activity := range a.Activities {
+		if activity.Address.Equal(address) {
+			activities = append(activities, activity)
+		}
+	}
+
+	return activities
+}
+
+// AddressActivitiesFromLedgerUpdate derives the activities of the addresses in the given set from the given ledger update.
+func AddressActivitiesFromLedgerUpdate(update *LedgerUpdate, addresses *AddressSet) *AddressActivities {
+	activities := make([]*AddressActivity, 0)
+
+	addActivities := func(activityType AddressActivityType, outputs []*Output) {
+		for _, output := range outputs {
+			for _, address := range addresses.matchingAddresses(output.Output) {
+				activities = append(activities, &AddressActivity{
+					Type:    activityType,
+					Address: address,
+					Output:  output,
+				})
+			}
+		}
+	}
+	addActivities(AddressActivityTypeSpent, update.Consumed)
+	addActivities(AddressActivityTypeCreated, update.Created)
+
+	return &AddressActivities{
+		API:          update.API,
+		CommitmentID: update.CommitmentID,
+		Activities:   activities,
+	}
+}
+
+// ListenToAddressActivity listens to the outputs that were created for or spent by the addresses in the given set per committed slot.
+// Addresses can be added to or removed from the set at any time, the change applies to the next received ledger update.
+// Slots without activities are not passed to the consumer.
+func (n *nodeBridge) ListenToAddressActivity(ctx context.Context, startSlot, endSlot iotago.SlotIndex, addresses *AddressSet, consumer func(activities *AddressActivities) error) error {
+	// outputs of other addresses are filtered before they are unwrapped
+	filter := NewOutputFilter(WithOutputPredicate(func(output iotago.Output) bool {
+		return len(addresses.matchingAddresses(output)) > 0
+	}))
+
+	return n.listenToLedgerUpdates(ctx, startSlot, endSlot, filter, func(update *LedgerUpdate) error {
+		activities := AddressActivitiesFromLedgerUpdate(update, addresses)
+		if len(activities.Activities) == 0 {
+			return nil
+		}
+
+		return consumer(activities)
+	})
+}
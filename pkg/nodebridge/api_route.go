@@ -2,6 +2,8 @@ package nodebridge
 
 import (
 	"context"
+	"net"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -9,31 +11,134 @@ import (
 	inx "github.com/iotaledger/inx/go"
 )
 
-// RegisterAPIRoute registers the given API route.
-func (n *nodeBridge) RegisterAPIRoute(ctx context.Context, route string, bindAddress string, path string) error {
-	bindAddressParts := strings.Split(bindAddress, ":")
-	if len(bindAddressParts) != 2 {
-		return ierrors.Errorf("invalid address %s", bindAddress)
+const (
+	// APIRouteSchemeHTTP is the scheme the node uses to proxy the requests of a registered API route.
+	APIRouteSchemeHTTP = "http"
+	// APIRouteSchemeHTTPS is the scheme of API routes served via TLS.
+	APIRouteSchemeHTTPS = "https"
+)
+
+var (
+	// ErrInvalidAPIRouteAddress is returned if the address of an API route can't be parsed.
+	ErrInvalidAPIRouteAddress = ierrors.New("invalid API route address")
+	// ErrUnsupportedAPIRouteScheme is returned if the node can't proxy the requests of an API route with the given scheme.
+	// The INX API route registration only contains the host and the port, so the node always proxies via plain HTTP.
+	// Extensions that are only reachable via HTTPS need a reverse proxy that terminates TLS in front of them.
+	ErrUnsupportedAPIRouteScheme = ierrors.New("unsupported API route scheme")
+)
+
+// APIRouteAddress is the address on which the node reaches the API of an extension.
+type APIRouteAddress struct {
+	// Scheme is the scheme of the address, e.g. "http".
+	Scheme string
+	// Host is the host name or IP address, IPv6 literals are stored without brackets.
+	Host string
+	// Port is the port of the address.
+	Port uint32
+}
+
+// String returns the address as URL.
+func (a *APIRouteAddress) String() string {
+	return a.Scheme + "://" + net.JoinHostPort(a.Host, strconv.FormatUint(uint64(a.Port), 10))
+}
+
+// ParseAPIRouteAddress parses the address on which the node reaches the API of an extension.
+// The address is either a "host:port" pair like a bind address, or a URL like "http://proxy.example.com:8080",
+// which allows to advertise an external address that differs from the local bind address, e.g. behind a reverse proxy.
+// IPv6 literals need to be enclosed in brackets, e.g. "[::1]:9091".
+// If a URL does not contain a port, the default port of the scheme is used.
+func ParseAPIRouteAddress(address string) (*APIRouteAddress, error) {
+	if !strings.Contains(address, "://") {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, ierrors.Errorf("%w: %w", ErrInvalidAPIRouteAddress, err)
+		}
+
+		return newAPIRouteAddress(APIRouteSchemeHTTP, host, port)
+	}
+
+	addressURL, err := url.Parse(address)
+	if err != nil {
+		return nil, ierrors.Errorf("%w: %w", ErrInvalidAPIRouteAddress, err)
+	}
+
+	if addressURL.Path != "" && addressURL.Path != "/" {
+		return nil, ierrors.Wrapf(ErrInvalidAPIRouteAddress, "address %s must not contain a path", address)
+	}
+
+	scheme := strings.ToLower(addressURL.Scheme)
+	port := addressURL.Port()
+	if port == "" {
+		switch scheme {
+		case APIRouteSchemeHTTP:
+			port = "80"
+		case APIRouteSchemeHTTPS:
+			port = "443"
+		}
+	}
+
+	return newAPIRouteAddress(scheme, addressURL.Hostname(), port)
+}
+
+func newAPIRouteAddress(scheme string, host string, port string) (*APIRouteAddress, error) {
+	if scheme != APIRouteSchemeHTTP && scheme != APIRouteSchemeHTTPS {
+		return nil, ierrors.Wrapf(ErrUnsupportedAPIRouteScheme, "scheme %q", scheme)
 	}
-	port, err := strconv.ParseInt(bindAddressParts[1], 10, 32)
+
+	if strings.ContainsAny(host, "[]") {
+		return nil, ierrors.Wrapf(ErrInvalidAPIRouteAddress, "invalid host %s", host)
+	}
+
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || portNumber == 0 {
+		return nil, ierrors.Wrapf(ErrInvalidAPIRouteAddress, "invalid port %s", port)
+	}
+
+	return &APIRouteAddress{
+		Scheme: scheme,
+		Host:   host,
+		Port:   uint32(portNumber),
+	}, nil
+}
+
+// RegisterAPIRoute registers the given API route.
+// The address is the address on which the node reaches the extension, see ParseAPIRouteAddress.
+func (n *nodeBridge) RegisterAPIRoute(ctx context.Context, route string, address string, path string) error {
+	apiRouteAddress, err := ParseAPIRouteAddress(address)
 	if err != nil {
 		return err
 	}
 
+	if apiRouteAddress.Scheme != APIRouteSchemeHTTP {
+		return ierrors.Wrapf(ErrUnsupportedAPIRouteScheme, "the node proxies API routes via %s, address %s", APIRouteSchemeHTTP, address)
+	}
+
 	apiReq := &inx.APIRouteRequest{
 		Route: route,
-		Host:  bindAddressParts[0],
-		Port:  uint32(port),
+		Host:  apiRouteAddress.Host,
+		Port:  apiRouteAddress.Port,
 		Path:  path,
 	}
 
-	_, err = n.client.RegisterAPIRoute(ctx, apiReq)
+	if _, err := n.client.RegisterAPIRoute(ctx, apiReq); err != nil {
+		return err
+	}
 
-	return err
+	// the route is remembered, so it can be re-registered if the node restarted
+	n.apiRoutesMutex.Lock()
+	n.apiRoutes[route] = apiReq
+	n.apiRoutesMutex.Unlock()
+
+	return nil
 }
 
 // UnregisterAPIRoute unregisters the given API route.
 func (n *nodeBridge) UnregisterAPIRoute(ctx context.Context, route string) error {
+	// the route is forgotten even if the call fails, so it is not re-registered after a reconnect
+	n.apiRoutesMutex.Lock()
+	delete(n.apiRoutes, route)
+	n.apiRoutesMutex.Unlock()
+
 	apiReq := &inx.APIRouteRequest{
 		Route: route,
 	}
@@ -41,3 +146,25 @@ func (n *nodeBridge) UnregisterAPIRoute(ctx context.Context, route string) error
 
 	return err
 }
+
+// reregisterAPIRoutes registers all known API routes again, e.g. after the node restarted and lost its routes.
+// The APIRouteReregistered event is triggered for every route.
+func (n *nodeBridge) reregisterAPIRoutes(ctx context.Context) {
+	n.apiRoutesMutex.Lock()
+	apiReqs := make([]*inx.APIRouteRequest, 0, len(n.apiRoutes))
+	for _, apiReq := range n.apiRoutes {
+		apiReqs = append(apiReqs, apiReq)
+	}
+	n.apiRoutesMutex.Unlock()
+
+	for _, apiReq := range apiReqs {
+		_, err := n.client.RegisterAPIRoute(ctx, apiReq)
+		if err != nil {
+			n.LogWarnf("failed to re-register API route %s: %s", apiReq.GetRoute(), err)
+		} else {
+			n.LogInfof("re-registered API route %s", apiReq.GetRoute())
+		}
+
+		n.events.APIRouteReregistered.Trigger(apiReq.GetRoute(), err)
+	}
+}
@@ -0,0 +1,635 @@
+package nodebridge
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultBalanceHistoryRetention is the default amount of slots for which the balance history is kept.
+	DefaultBalanceHistoryRetention iotago.SlotIndex = 8640
+	// DefaultBalanceSnapshotInterval is the default amount of slots after which a snapshot is written to the BalanceStore.
+	DefaultBalanceSnapshotInterval iotago.SlotIndex = 60
+)
+
+var (
+	// ErrBalanceSnapshotNotFound is returned by a BalanceStore if no snapshot was stored yet.
+	ErrBalanceSnapshotNotFound = ierrors.New("balance snapshot not found")
+	// ErrBalanceTrackerNotBootstrapped is returned if the balances are queried before the tracker was bootstrapped.
+	ErrBalanceTrackerNotBootstrapped = ierrors.New("balance tracker not bootstrapped yet")
+	// ErrBalanceHistoryUnavailable is returned if the balance of a slot is queried that is not part of the balance history.
+	ErrBalanceHistoryUnavailable = ierrors.New("balance history not available for slot")
+)
+
+// Balance is the balance of an address.
+// It is derived from the owner address of the outputs, expiration and storage deposit return
+// unlock conditions are not taken into account.
+type Balance struct {
+	// BaseTokens is the amount of base tokens of all outputs of the address.
+	BaseTokens iotago.BaseToken
+	// Mana is the amount of stored mana of all outputs of the address, without decay.
+	Mana iotago.Mana
+	// NativeTokens are the amounts of native tokens of all outputs of the address.
+	NativeTokens map[iotago.NativeTokenID]*big.Int
+}
+
+// NewBalance creates a new empty Balance.
+func NewBalance() *Balance {
+	return &Balance{
+		NativeTokens: make(map[iotago.NativeTokenID]*big.Int),
+	}
+}
+
+// Clone returns a deep copy of the Balance.
+func (b *Balance) Clone() *Balance {
+	balance := &Balance{
+		BaseTokens:   b.BaseTokens,
+		Mana:         b.Mana,
+		NativeTokens: make(map[iotago.NativeTokenID]*big.Int, len(b.NativeTokens)),
+	}
+
+	for nativeTokenID, amount := range b.NativeTokens {
+		balance.NativeTokens[nativeTokenID] = new(big.Int).Set(amount)
+	}
+
+	return balance
+}
+
+// IsZero returns true if the Balance does not hold any base tokens, mana or native tokens.
+func (b *Balance) IsZero() bool {
+	return b.BaseTokens == 0 && b.Mana == 0 && len(b.NativeTokens) == 0
+}
+
+// Equal returns true if both balances are equal.
+func (b *Balance) Equal(other *Balance) bool {
+	if b.BaseTokens != other.BaseTokens || b.Mana != other.Mana || len(b.NativeTokens) != len(other.NativeTokens) {
+		return false
+	}
+
+	for nativeTokenID, amount := range b.NativeTokens {
+		otherAmount, exists := other.NativeTokens[nativeTokenID]
+		if !exists || amount.Cmp(otherAmount) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addOutput adds the funds of the given output to the Balance.
+func (b *Balance) addOutput(output iotago.Output) {
+	b.BaseTokens += output.BaseTokenAmount()
+	b.Mana += output.StoredMana()
+
+	if nativeToken := output.FeatureSet().NativeToken(); nativeToken != nil {
+		amount, exists := b.NativeTokens[nativeToken.ID]
+		if !exists {
+			amount = new(big.Int)
+			b.NativeTokens[nativeToken.ID] = amount
+		}
+		amount.Add(amount, nativeToken.Amount)
+	}
+}
+
+// subOutput removes the funds of the given output from the Balance.
+func (b *Balance) subOutput(output iotago.Output) {
+	b.BaseTokens -= output.BaseTokenAmount()
+	b.Mana -= output.StoredMana()
+
+	if nativeToken := output.FeatureSet().NativeToken(); nativeToken != nil {
+		amount, exists := b.NativeTokens[nativeToken.ID]
+		if !exists {
+			amount = new(big.Int)
+			b.NativeTokens[nativeToken.ID] = amount
+		}
+		amount.Sub(amount, nativeToken.Amount)
+
+		if amount.Sign() == 0 {
+			delete(b.NativeTokens, nativeToken.ID)
+		}
+	}
+}
+
+// balanceOwner returns the address that owns the funds of the given output.
+func balanceOwner(output iotago.Output) iotago.Address {
+	unlockConditions := output.UnlockConditionSet()
+
+	switch {
+	case unlockConditions.Address() != nil:
+		return unlockConditions.Address().Address
+	case unlockConditions.StateControllerAddress() != nil:
+		return unlockConditions.StateControllerAddress().Address
+	case unlockConditions.ImmutableAccount() != nil:
+		return unlockConditions.ImmutableAccount().Address
+	default:
+		return nil
+	}
+}
+
+// AddressBalance is the balance of an address.
+type AddressBalance struct {
+	Address iotago.Address
+	Balance *Balance
+}
+
+// BalanceSnapshot contains the balances of all tracked addresses as of a commitment.
+type BalanceSnapshot struct {
+	// CommitmentID is the commitment the balances belong to.
+	CommitmentID iotago.CommitmentID
+	// Balances are the balances of all addresses that hold funds.
+	Balances []*AddressBalance
+}
+
+// BalanceStore persists the snapshots of the BalanceTracker.
+type BalanceStore interface {
+	// LoadBalanceSnapshot returns the latest stored snapshot, or ErrBalanceSnapshotNotFound if there is none.
+	LoadBalanceSnapshot(ctx context.Context) (*BalanceSnapshot, error)
+	// StoreBalanceSnapshot stores the given snapshot and replaces the previous one.
+	StoreBalanceSnapshot(ctx context.Context, snapshot *BalanceSnapshot) error
+}
+
+// MemoryBalanceStore is a BalanceStore that keeps the latest snapshot in memory.
+type MemoryBalanceStore struct {
+	mutex    sync.RWMutex
+	snapshot *BalanceSnapshot
+}
+
+// NewMemoryBalanceStore creates a new MemoryBalanceStore.
+func NewMemoryBalanceStore() *MemoryBalanceStore {
+	return &MemoryBalanceStore{}
+}
+
+// LoadBalanceSnapshot returns the latest stored snapshot, or ErrBalanceSnapshotNotFound if there is none.
+func (s *MemoryBalanceStore) LoadBalanceSnapshot(_ context.Context) (*BalanceSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.snapshot == nil {
+		return nil, ErrBalanceSnapshotNotFound
+	}
+
+	return s.snapshot, nil
+}
+
+// StoreBalanceSnapshot stores the given snapshot and replaces the previous one.
+func (s *MemoryBalanceStore) StoreBalanceSnapshot(_ context.Context, snapshot *BalanceSnapshot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.snapshot = snapshot
+
+	return nil
+}
+
+// BalanceChange is the change of the balance of an address within a committed slot.
+type BalanceChange struct {
+	// Address is the address whose balance changed.
+	Address iotago.Address
+	// CommitmentID is the commitment of the slot the balance changed in.
+	CommitmentID iotago.CommitmentID
+	// Previous is the balance at the start of the slot.
+	Previous *Balance
+	// Current is the balance at the end of the slot.
+	Current *Balance
+}
+
+// BalanceTrackerEvents are the events of the BalanceTracker.
+type BalanceTrackerEvents struct {
+	// BalanceChanged is triggered for every address whose balance changed within a committed slot.
+	BalanceChanged *event.Event1[*BalanceChange]
+}
+
+// balanceHistoryEntry is the balance of an address starting at a slot.
+type balanceHistoryEntry struct {
+	slot    iotago.SlotIndex
+	balance *Balance
+}
+
+// BalanceTracker maintains the balances of addresses based on the ledger updates.
+// It bootstraps from the latest snapshot in the BalanceStore, or from the unspent outputs of the node
+// if there is none, and keeps a history of the balances for the configured amount of slots.
+type BalanceTracker struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge NodeBridge
+	store      BalanceStore
+	events     *BalanceTrackerEvents
+
+	addresses        *AddressSet
+	historyRetention iotago.SlotIndex
+	snapshotInterval iotago.SlotIndex
+
+	mutex            sync.RWMutex
+	bootstrapped     bool
+	commitmentID     iotago.CommitmentID
+	historyStartSlot iotago.SlotIndex
+	lastSnapshotSlot iotago.SlotIndex
+	balances         map[string]*Balance
+	addressesByKey   map[string]iotago.Address
+	history          map[string][]*balanceHistoryEntry
+}
+
+// WithBalanceStore sets the store the snapshots of the balances are written to and restored from.
+func WithBalanceStore(store BalanceStore) options.Option[BalanceTracker] {
+	return func(t *BalanceTracker) {
+		t.store = store
+	}
+}
+
+// WithBalanceAddresses restricts the tracked balances to the given addresses.
+// If it is not set, the balances of all addresses are tracked.
+func WithBalanceAddresses(addresses ...iotago.Address) options.Option[BalanceTracker] {
+	return func(t *BalanceTracker) {
+		t.addresses = NewAddressSet(addresses...)
+	}
+}
+
+// WithBalanceHistoryRetention sets the amount of slots for which the balance history is kept.
+func WithBalanceHistoryRetention(retention iotago.SlotIndex) options.Option[BalanceTracker] {
+	return func(t *BalanceTracker) {
+		t.historyRetention = retention
+	}
+}
+
+// WithBalanceSnapshotInterval sets the amount of slots after which a snapshot is written to the BalanceStore.
+func WithBalanceSnapshotInterval(interval iotago.SlotIndex) options.Option[BalanceTracker] {
+	return func(t *BalanceTracker) {
+		t.snapshotInterval = interval
+	}
+}
+
+// NewBalanceTracker creates a new BalanceTracker.
+func NewBalanceTracker(logger log.Logger, nodeBridge NodeBridge, opts ...options.Option[BalanceTracker]) *BalanceTracker {
+	return options.Apply(&BalanceTracker{
+		Logger:     logger,
+		nodeBridge: nodeBridge,
+		store:      NewMemoryBalanceStore(),
+		events: &BalanceTrackerEvents{
+			BalanceChanged: event.New1[*BalanceChange](),
+		},
+		historyRetention: DefaultBalanceHistoryRetention,
+		snapshotInterval: DefaultBalanceSnapshotInterval,
+		balances:         make(map[string]*Balance),
+		addressesByKey:   make(map[string]iotago.Address),
+		history:          make(map[string][]*balanceHistoryEntry),
+	}, opts)
+}
+
+// Events returns the events of the BalanceTracker.
+func (t *BalanceTracker) Events() *BalanceTrackerEvents {
+	return t.events
+}
+
+// Run bootstraps the balances and follows the ledger updates until the context is canceled.
+// A snapshot of the balances is written to the BalanceStore in the configured interval and before Run returns.
+func (t *BalanceTracker) Run(ctx context.Context) error {
+	defer func() {
+		// the context is already canceled, so the final snapshot is stored with a fresh one
+		if err := t.storeSnapshot(context.WithoutCancel(ctx)); err != nil {
+			t.LogErrorf("failed to store balance snapshot: %s", err.Error())
+		}
+	}()
+
+	snapshot, err := t.store.LoadBalanceSnapshot(ctx)
+	if err != nil {
+		if !ierrors.Is(err, ErrBalanceSnapshotNotFound) {
+			return ierrors.Wrap(err, "failed to load balance snapshot")
+		}
+
+		// there is no snapshot, so the balances are bootstrapped from the unspent outputs of the node
+		return t.nodeBridge.SyncLedger(ctx, t)
+	}
+
+	if err := t.Restore(snapshot); err != nil {
+		return err
+	}
+
+	lastSlot := snapshot.CommitmentID.Slot()
+
+	return t.nodeBridge.ListenToLedgerUpdates(ctx, lastSlot+1, 0, func(update *LedgerUpdate) error {
+		slot := update.CommitmentID.Slot()
+		if slot <= lastSlot {
+			// the update is already part of the balances
+			return nil
+		}
+
+		if slot != lastSlot+1 {
+			return ierrors.Wrapf(ErrLedgerSyncGap, "expected slot %d, got %d", lastSlot+1, slot)
+		}
+
+		if err := t.LedgerUpdate(update); err != nil {
+			return err
+		}
+		lastSlot = slot
+
+		return nil
+	})
+}
+
+// BootstrapOutput adds the funds of an unspent output of the bootstrap ledger state.
+// It is part of the LedgerSyncHandler interface and should not be called directly.
+func (t *BalanceTracker) BootstrapOutput(output *Output) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if owner := t.trackedOwner(output.Output); owner != nil {
+		t.balanceForUpdate(owner).addOutput(output.Output)
+	}
+
+	return nil
+}
+
+// BootstrapDone marks the balances as bootstrapped as of the given commitment.
+// It is part of the LedgerSyncHandler interface and should not be called directly.
+func (t *BalanceTracker) BootstrapDone(commitmentID iotago.CommitmentID) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.bootstrapped = true
+	t.commitmentID = commitmentID
+	t.historyStartSlot = commitmentID.Slot()
+	t.lastSnapshotSlot = commitmentID.Slot()
+
+	for key, balance := range t.balances {
+		t.history[key] = []*balanceHistoryEntry{{slot: commitmentID.Slot(), balance: balance.Clone()}}
+	}
+
+	return nil
+}
+
+// LedgerUpdate applies the given ledger update to the balances and triggers the BalanceChanged events.
+// It is part of the LedgerSyncHandler interface and should not be called directly.
+func (t *BalanceTracker) LedgerUpdate(update *LedgerUpdate) error {
+	changes, err := t.applyLedgerUpdate(update)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		t.events.BalanceChanged.Trigger(change)
+	}
+
+	if update.CommitmentID.Slot() >= t.nextSnapshotSlot() {
+		if err := t.storeSnapshot(context.Background()); err != nil {
+			t.LogErrorf("failed to store balance snapshot: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (t *BalanceTracker) applyLedgerUpdate(update *LedgerUpdate) ([]*BalanceChange, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.bootstrapped {
+		return nil, ErrBalanceTrackerNotBootstrapped
+	}
+
+	slot := update.CommitmentID.Slot()
+	previousBalances := make(map[string]*Balance)
+
+	applyOutputs := func(outputs []*Output, apply func(balance *Balance, output iotago.Output)) {
+		for _, output := range outputs {
+			owner := t.trackedOwner(output.Output)
+			if owner == nil {
+				continue
+			}
+
+			balance := t.balanceForUpdate(owner)
+			if _, exists := previousBalances[owner.Key()]; !exists {
+				previousBalances[owner.Key()] = balance.Clone()
+			}
+			apply(balance, output.Output)
+		}
+	}
+	applyOutputs(update.Consumed, (*Balance).subOutput)
+	applyOutputs(update.Created, (*Balance).addOutput)
+
+	t.commitmentID = update.CommitmentID
+	if slot > t.historyRetention && slot-t.historyRetention > t.historyStartSlot {
+		t.historyStartSlot = slot - t.historyRetention
+	}
+
+	changes := make([]*BalanceChange, 0, len(previousBalances))
+	for key, previous := range previousBalances {
+		current := t.balances[key]
+		if current.Equal(previous) {
+			continue
+		}
+
+		changes = append(changes, &BalanceChange{
+			Address:      t.addressesByKey[key],
+			CommitmentID: update.CommitmentID,
+			Previous:     previous,
+			Current:      current.Clone(),
+		})
+
+		t.addHistoryEntry(key, slot, current.Clone())
+
+		if current.IsZero() {
+			// the history still contains the zero balance, so only the current balance is removed
+			delete(t.balances, key)
+		}
+	}
+
+	// the changes are sorted to trigger the events in a deterministic order
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Address.Key() < changes[j].Address.Key()
+	})
+
+	return changes, nil
+}
+
+// trackedOwner returns the owner of the given output, or nil if the owner is not tracked.
+func (t *BalanceTracker) trackedOwner(output iotago.Output) iotago.Address {
+	owner := balanceOwner(output)
+	if owner == nil {
+		return nil
+	}
+
+	if t.addresses != nil && !t.addresses.Contains(owner) {
+		return nil
+	}
+
+	return owner
+}
+
+// balanceForUpdate returns the current balance of the given address, which is created if it does not exist.
+func (t *BalanceTracker) balanceForUpdate(address iotago.Address) *Balance {
+	key := address.Key()
+
+	balance, exists := t.balances[key]
+	if !exists {
+		balance = NewBalance()
+		t.balances[key] = balance
+		t.addressesByKey[key] = address
+	}
+
+	return balance
+}
+
+// addHistoryEntry adds the balance of the given slot to the history of the address
+// and removes the entries that are no longer needed to answer queries within the retention.
+func (t *BalanceTracker) addHistoryEntry(key string, slot iotago.SlotIndex, balance *Balance) {
+	entries := append(t.history[key], &balanceHistoryEntry{slot: slot, balance: balance})
+
+	// the latest entry before the start of the history is kept, because it is still valid at the start
+	firstNeeded := 0
+	for i := 1; i < len(entries) && entries[i].slot <= t.historyStartSlot; i++ {
+		firstNeeded = i
+	}
+
+	if firstNeeded == len(entries)-1 && balance.IsZero() {
+		// the address does not hold funds anymore and the zero balance is older than the history
+		delete(t.history, key)
+		delete(t.addressesByKey, key)
+
+		return
+	}
+
+	t.history[key] = entries[firstNeeded:]
+}
+
+// CommitmentID returns the commitment the current balances belong to.
+func (t *BalanceTracker) CommitmentID() iotago.CommitmentID {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.commitmentID
+}
+
+// CurrentBalance returns the current balance of the given address.
+func (t *BalanceTracker) CurrentBalance(address iotago.Address) (*Balance, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if !t.bootstrapped {
+		return nil, ErrBalanceTrackerNotBootstrapped
+	}
+
+	balance, exists := t.balances[address.Key()]
+	if !exists {
+		return NewBalance(), nil
+	}
+
+	return balance.Clone(), nil
+}
+
+// BalanceAt returns the balance of the given address at the end of the given slot.
+// It returns ErrBalanceHistoryUnavailable if the slot is not part of the balance history.
+func (t *BalanceTracker) BalanceAt(address iotago.Address, slot iotago.SlotIndex) (*Balance, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if !t.bootstrapped {
+		return nil, ErrBalanceTrackerNotBootstrapped
+	}
+
+	if slot < t.historyStartSlot || slot > t.commitmentID.Slot() {
+		return nil, ierrors.Wrapf(ErrBalanceHistoryUnavailable, "slot %d, history is available from slot %d to %d", slot, t.historyStartSlot, t.commitmentID.Slot())
+	}
+
+	entries := t.history[address.Key()]
+
+	// find the latest entry at or before the slot
+	index := sort.Search(len(entries), func(i int) bool {
+		return entries[i].slot > slot
+	}) - 1
+	if index < 0 {
+		return NewBalance(), nil
+	}
+
+	return entries[index].balance.Clone(), nil
+}
+
+// Snapshot returns a snapshot of the current balances.
+func (t *BalanceTracker) Snapshot() (*BalanceSnapshot, error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if !t.bootstrapped {
+		return nil, ErrBalanceTrackerNotBootstrapped
+	}
+
+	return t.snapshot(), nil
+}
+
+func (t *BalanceTracker) snapshot() *BalanceSnapshot {
+	balances := make([]*AddressBalance, 0, len(t.balances))
+	for key, balance := range t.balances {
+		balances = append(balances, &AddressBalance{
+			Address: t.addressesByKey[key],
+			Balance: balance.Clone(),
+		})
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Address.Key() < balances[j].Address.Key()
+	})
+
+	return &BalanceSnapshot{
+		CommitmentID: t.commitmentID,
+		Balances:     balances,
+	}
+}
+
+// Restore replaces the balances with the given snapshot.
+// The balance history is reset and starts at the slot of the snapshot.
+func (t *BalanceTracker) Restore(snapshot *BalanceSnapshot) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.balances = make(map[string]*Balance, len(snapshot.Balances))
+	t.addressesByKey = make(map[string]iotago.Address, len(snapshot.Balances))
+	t.history = make(map[string][]*balanceHistoryEntry, len(snapshot.Balances))
+
+	slot := snapshot.CommitmentID.Slot()
+	for _, addressBalance := range snapshot.Balances {
+		if t.addresses != nil && !t.addresses.Contains(addressBalance.Address) {
+			continue
+		}
+
+		key := addressBalance.Address.Key()
+		t.balances[key] = addressBalance.Balance.Clone()
+		t.addressesByKey[key] = addressBalance.Address
+		t.history[key] = []*balanceHistoryEntry{{slot: slot, balance: addressBalance.Balance.Clone()}}
+	}
+
+	t.bootstrapped = true
+	t.commitmentID = snapshot.CommitmentID
+	t.historyStartSlot = slot
+	t.lastSnapshotSlot = slot
+
+	return nil
+}
+
+func (t *BalanceTracker) nextSnapshotSlot() iotago.SlotIndex {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.lastSnapshotSlot + t.snapshotInterval
+}
+
+// storeSnapshot writes a snapshot of the current balances to the BalanceStore.
+func (t *BalanceTracker) storeSnapshot(ctx context.Context) error {
+	t.mutex.Lock()
+	if !t.bootstrapped {
+		t.mutex.Unlock()
+		return nil
+	}
+	snapshot := t.snapshot()
+	t.lastSnapshotSlot = snapshot.CommitmentID.Slot()
+	t.mutex.Unlock()
+
+	return t.store.StoreBalanceSnapshot(ctx, snapshot)
+}
@@ -0,0 +1,109 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+var (
+	// ErrBlockAcceptanceTimeout is returned if the submitted block was not accepted before the timeout.
+	ErrBlockAcceptanceTimeout = ierrors.New("block was not accepted before the timeout")
+	// ErrBlockOrphaned is returned if the submitted block was orphaned, because its slot was committed without it.
+	ErrBlockOrphaned = ierrors.New("block was orphaned")
+	// ErrBlockDropped is returned if the submitted block was dropped by the node.
+	ErrBlockDropped = ierrors.New("block was dropped")
+)
+
+// SubmitBlockAndAwaitAcceptance submits the given block and blocks until it is accepted,
+// so that subsequent reads of the node already reflect the block (read-your-writes).
+// It returns the metadata of the accepted block.
+//
+// If the block is orphaned or dropped, ErrBlockOrphaned or ErrBlockDropped is returned together with the last known metadata.
+// If the block is not accepted within the timeout, ErrBlockAcceptanceTimeout is returned.
+// The TangleListener needs to be running to receive the accepted blocks.
+func (t *TangleListener) SubmitBlockAndAwaitAcceptance(ctx context.Context, block *iotago.Block, timeout time.Duration) (*api.BlockMetadataResponse, error) {
+	ctxTimeout, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+
+	blockID, err := t.nodeBridge.SubmitBlock(ctxTimeout, block)
+	if err != nil {
+		if ctx.Err() == nil && ierrors.Is(ctxTimeout.Err(), context.DeadlineExceeded) {
+			return nil, ierrors.Join(ErrBlockAcceptanceTimeout, err)
+		}
+
+		return nil, err
+	}
+
+	acceptedChan := make(chan *api.BlockMetadataResponse, 1)
+	if err := t.RegisterBlockAcceptedCallback(ctxTimeout, blockID, func(metadata *api.BlockMetadataResponse) {
+		acceptedChan <- metadata
+	}); err != nil {
+		return nil, err
+	}
+	defer t.DeregisterBlockAcceptedCallback(blockID)
+
+	// the block can only be orphaned once its slot is committed, so the metadata is checked for every new commitment
+	commitmentChan := make(chan struct{}, 1)
+	hook := t.nodeBridge.Events().LatestCommitmentChanged.Hook(func(commitment *Commitment) {
+		if commitment.CommitmentID.Slot() < blockID.Slot() {
+			return
+		}
+
+		select {
+		case commitmentChan <- struct{}{}:
+		default:
+		}
+	})
+	defer hook.Unhook()
+
+	for {
+		select {
+		case metadata := <-acceptedChan:
+			return metadata, nil
+
+		case <-commitmentChan:
+			metadata, done, err := t.blockAcceptanceOutcome(ctxTimeout, blockID)
+			if done {
+				return metadata, err
+			}
+
+		case <-ctxTimeout.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			// the acceptance might have been missed, so the metadata is checked a last time
+			metadata, done, err := t.blockAcceptanceOutcome(ctx, blockID)
+			if done {
+				return metadata, err
+			}
+
+			return nil, ierrors.Wrapf(ErrBlockAcceptanceTimeout, "block %s, timeout %s", blockID, timeout)
+		}
+	}
+}
+
+// blockAcceptanceOutcome checks via the block metadata if the block was accepted, orphaned or dropped.
+// It returns true if the outcome of the block is final.
+func (t *TangleListener) blockAcceptanceOutcome(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, bool, error) {
+	metadata, err := t.blockMetadataFunc(ctx, blockID)
+	if err != nil {
+		// the outcome is not known yet, the block might still be accepted
+		return nil, false, nil
+	}
+
+	switch metadata.BlockState {
+	case api.BlockStateAccepted, api.BlockStateConfirmed, api.BlockStateFinalized:
+		return metadata, true, nil
+	case api.BlockStateOrphaned:
+		return metadata, true, ierrors.Wrapf(ErrBlockOrphaned, "block %s", blockID)
+	case api.BlockStateDropped:
+		return metadata, true, ierrors.Wrapf(ErrBlockDropped, "block %s", blockID)
+	default:
+		return metadata, false, nil
+	}
+}
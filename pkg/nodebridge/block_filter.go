@@ -0,0 +1,244 @@
+package nodebridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// offsets and sizes of the serialized block that are needed to check a BlockFilter on the raw block data.
+const (
+	// the issuer ID follows the protocol version, network ID, issuing time, slot commitment ID and latest finalized slot.
+	rawBlockIssuerIDOffset = 1 + 8 + 8 + iotago.CommitmentIDLength + 4
+	rawBlockBodyTypeOffset = rawBlockIssuerIDOffset + iotago.AccountIDLength
+)
+
+// BlockFilter decides which blocks of the block stream are passed to the consumer.
+// All configured conditions have to match. A filter without conditions matches all blocks.
+//
+// The payload types, tag prefix and issuers are checked on the raw block data before the block is decoded,
+// so blocks that don't match are never deserialized. The predicates are checked on the decoded block.
+type BlockFilter struct {
+	payloadTypes map[iotago.PayloadType]struct{}
+	tagPrefix    []byte
+	issuerIDs    map[iotago.AccountID]struct{}
+	predicates   []func(block *iotago.Block) bool
+}
+
+// NewBlockFilter creates a new BlockFilter.
+func NewBlockFilter(opts ...options.Option[BlockFilter]) *BlockFilter {
+	return options.Apply(&BlockFilter{}, opts)
+}
+
+// WithBlockPayloadTypes only matches basic blocks that contain a payload of one of the given types.
+func WithBlockPayloadTypes(payloadTypes ...iotago.PayloadType) options.Option[BlockFilter] {
+	return func(f *BlockFilter) {
+		if f.payloadTypes == nil {
+			f.payloadTypes = make(map[iotago.PayloadType]struct{}, len(payloadTypes))
+		}
+
+		for _, payloadType := range payloadTypes {
+			f.payloadTypes[payloadType] = struct{}{}
+		}
+	}
+}
+
+// WithBlockTagPrefix only matches blocks with a tagged data payload whose tag starts with the given prefix.
+func WithBlockTagPrefix(tagPrefix []byte) options.Option[BlockFilter] {
+	return func(f *BlockFilter) {
+		f.tagPrefix = append([]byte{}, tagPrefix...)
+	}
+}
+
+// WithBlockIssuers only matches blocks that were issued by one of the given accounts.
+func WithBlockIssuers(issuerIDs ...iotago.AccountID) options.Option[BlockFilter] {
+	return func(f *BlockFilter) {
+		if f.issuerIDs == nil {
+			f.issuerIDs = make(map[iotago.AccountID]struct{}, len(issuerIDs))
+		}
+
+		for _, issuerID := range issuerIDs {
+			f.issuerIDs[issuerID] = struct{}{}
+		}
+	}
+}
+
+// WithBlockPredicate only matches blocks for which the given predicate returns true.
+func WithBlockPredicate(predicate func(block *iotago.Block) bool) options.Option[BlockFilter] {
+	return func(f *BlockFilter) {
+		f.predicates = append(f.predicates, predicate)
+	}
+}
+
+// Matches returns true if the given block matches all conditions of the filter.
+func (f *BlockFilter) Matches(block *iotago.Block) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.issuerIDs != nil {
+		if _, exists := f.issuerIDs[block.Header.IssuerID]; !exists {
+			return false
+		}
+	}
+
+	if f.payloadTypes != nil || f.tagPrefix != nil {
+		basicBlockBody, isBasicBlock := block.Body.(*iotago.BasicBlockBody)
+		if !isBasicBlock || basicBlockBody.Payload == nil {
+			return false
+		}
+
+		if f.payloadTypes != nil {
+			if _, exists := f.payloadTypes[basicBlockBody.Payload.PayloadType()]; !exists {
+				return false
+			}
+		}
+
+		if f.tagPrefix != nil {
+			taggedData, isTaggedData := basicBlockBody.Payload.(*iotago.TaggedData)
+			if !isTaggedData || !bytes.HasPrefix(taggedData.Tag, f.tagPrefix) {
+				return false
+			}
+		}
+	}
+
+	return f.matchesPredicates(block)
+}
+
+// matchesRaw checks the filter against the raw block data.
+// It returns whether the block matches and whether the result is final.
+// If it is not final, the block needs to be decoded and checked via Matches,
+// either because there are predicates or because the raw data couldn't be parsed.
+func (f *BlockFilter) matchesRaw(rawBlockData []byte) (matches bool, final bool) {
+	if f == nil {
+		return true, true
+	}
+
+	if len(rawBlockData) <= rawBlockBodyTypeOffset {
+		return true, false
+	}
+
+	if f.issuerIDs != nil {
+		var issuerID iotago.AccountID
+		copy(issuerID[:], rawBlockData[rawBlockIssuerIDOffset:rawBlockBodyTypeOffset])
+
+		if _, exists := f.issuerIDs[issuerID]; !exists {
+			return false, true
+		}
+	}
+
+	if f.payloadTypes != nil || f.tagPrefix != nil {
+		if iotago.BlockBodyType(rawBlockData[rawBlockBodyTypeOffset]) != iotago.BlockBodyTypeBasic {
+			// only basic blocks contain payloads
+			return false, true
+		}
+
+		payload, ok := rawBasicBlockPayload(rawBlockData[rawBlockBodyTypeOffset+1:])
+		if !ok {
+			return true, false
+		}
+		if len(payload) == 0 {
+			return false, true
+		}
+
+		if f.payloadTypes != nil {
+			if _, exists := f.payloadTypes[iotago.PayloadType(payload[0])]; !exists {
+				return false, true
+			}
+		}
+
+		if f.tagPrefix != nil {
+			if iotago.PayloadType(payload[0]) != iotago.PayloadTaggedData {
+				return false, true
+			}
+
+			// the tag of the tagged data is prefixed with its length as uint8
+			if len(payload) < 2 || len(payload) < 2+int(payload[1]) {
+				return true, false
+			}
+
+			if !bytes.HasPrefix(payload[2:2+int(payload[1])], f.tagPrefix) {
+				return false, true
+			}
+		}
+	}
+
+	return true, len(f.predicates) == 0
+}
+
+// rawBasicBlockPayload returns the serialized payload of the raw basic block body (without the body type),
+// or an empty slice if the block has no payload. It returns false if the data is malformed.
+func rawBasicBlockPayload(rawBasicBlockBody []byte) ([]byte, bool) {
+	offset := 0
+
+	// skip the strong, weak and shallow like parents, which are prefixed with their count as uint8
+	for range 3 {
+		if len(rawBasicBlockBody) <= offset {
+			return nil, false
+		}
+		offset += 1 + int(rawBasicBlockBody[offset])*iotago.BlockIDLength
+	}
+
+	// the optional payload is prefixed with its length as uint32, which is 0 if there is no payload
+	if len(rawBasicBlockBody) < offset+4 {
+		return nil, false
+	}
+	payloadLength := int(binary.LittleEndian.Uint32(rawBasicBlockBody[offset:]))
+	offset += 4
+
+	if len(rawBasicBlockBody) < offset+payloadLength {
+		return nil, false
+	}
+
+	return rawBasicBlockBody[offset : offset+payloadLength], true
+}
+
+func (f *BlockFilter) matchesPredicates(block *iotago.Block) bool {
+	for _, predicate := range f.predicates {
+		if !predicate(block) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListenToBlocksFiltered listens to the blocks that match the given filter.
+// The filter is checked on the raw block data, so only matching blocks are decoded.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost.
+// Blocks issued while the connection was lost are not delivered.
+func (n *nodeBridge) ListenToBlocksFiltered(ctx context.Context, filter *BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return n.listenWithReconnect(ctx, "ListenToBlocksFiltered", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		return n.listenToBlocksStream(ctx, filter, func(block *iotago.Block, rawData []byte) error {
+			if err := consumer(block, rawData); err != nil {
+				return err
+			}
+			delivered(block.API.TimeProvider().SlotFromTime(block.Header.IssuingTime))
+
+			return nil
+		})
+	})
+}
+
+// unwrapFilteredBlock decodes the INX block if it matches the filter, otherwise it returns nil.
+func (n *nodeBridge) unwrapFilteredBlock(filter *BlockFilter, inxBlock *inx.Block) (*iotago.Block, error) {
+	matches, final := filter.matchesRaw(inxBlock.GetBlock().GetData())
+	if !matches {
+		return nil, nil
+	}
+
+	block, err := inxBlock.UnwrapBlock(n.apiProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	if !final && !filter.Matches(block) {
+		return nil, nil
+	}
+
+	return block, nil
+}
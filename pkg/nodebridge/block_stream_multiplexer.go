@@ -0,0 +1,228 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	// ErrBlockSubscriberDisconnected is returned if a subscriber was disconnected because it could not keep up.
+	ErrBlockSubscriberDisconnected = ierrors.New("block stream subscriber disconnected because it could not keep up")
+	// ErrBlockStreamMultiplexerStopped is returned if the upstream of the multiplexer ended.
+	ErrBlockStreamMultiplexerStopped = ierrors.New("block stream multiplexer stopped")
+)
+
+// SlowConsumerPolicy defines how the BlockStreamMultiplexer handles subscribers whose buffer is full.
+type SlowConsumerPolicy byte
+
+const (
+	// SlowConsumerPolicyDrop drops the blocks that don't fit into the buffer of the subscriber.
+	SlowConsumerPolicyDrop SlowConsumerPolicy = iota
+	// SlowConsumerPolicyBlock waits until the subscriber has space in its buffer.
+	// This blocks the delivery of blocks to all other subscribers and the upstream.
+	SlowConsumerPolicyBlock
+	// SlowConsumerPolicyDisconnect disconnects the subscriber if its buffer is full.
+	SlowConsumerPolicyDisconnect
+)
+
+// StreamedBlock is a block that was received via the block stream.
+type StreamedBlock struct {
+	Block   *iotago.Block
+	RawData []byte
+}
+
+// BlockSubscription is a subscriber of the BlockStreamMultiplexer.
+type BlockSubscription struct {
+	multiplexer *BlockStreamMultiplexer
+	policy      SlowConsumerPolicy
+
+	blocks       chan *StreamedBlock
+	unsubscribed chan struct{}
+	unsubscribe  sync.Once
+	dropped      atomic.Uint64
+
+	errMutex sync.Mutex
+	err      error
+}
+
+// Blocks returns the channel the blocks are delivered to.
+// The channel is closed if the subscriber was disconnected or the multiplexer stopped, see Err.
+func (s *BlockSubscription) Blocks() <-chan *StreamedBlock {
+	return s.blocks
+}
+
+// Dropped returns the amount of blocks that were dropped because the buffer of the subscriber was full.
+func (s *BlockSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Err returns the reason why the blocks channel was closed.
+func (s *BlockSubscription) Err() error {
+	s.errMutex.Lock()
+	defer s.errMutex.Unlock()
+
+	return s.err
+}
+
+// Unsubscribe removes the subscriber from the multiplexer. No more blocks are delivered afterwards.
+func (s *BlockSubscription) Unsubscribe() {
+	s.unsubscribe.Do(func() {
+		close(s.unsubscribed)
+		s.multiplexer.removeSubscription(s)
+	})
+}
+
+// close closes the blocks channel with the given reason.
+// It must only be called by the multiplexer after the subscription was removed.
+func (s *BlockSubscription) close(err error) {
+	s.errMutex.Lock()
+	s.err = err
+	s.errMutex.Unlock()
+
+	close(s.blocks)
+}
+
+// deliver passes the block to the subscriber according to its slow consumer policy.
+// It returns false if the subscriber needs to be disconnected.
+func (s *BlockSubscription) deliver(ctx context.Context, block *StreamedBlock) bool {
+	select {
+	case s.blocks <- block:
+		return true
+	default:
+	}
+
+	switch s.policy {
+	case SlowConsumerPolicyBlock:
+		select {
+		case s.blocks <- block:
+		case <-s.unsubscribed:
+		case <-ctx.Done():
+		}
+
+		return true
+
+	case SlowConsumerPolicyDisconnect:
+		return false
+
+	default:
+		s.dropped.Add(1)
+
+		return true
+	}
+}
+
+// BlockStreamMultiplexer maintains a single upstream block stream to the node
+// and distributes the blocks to any number of local subscribers with independent buffers.
+type BlockStreamMultiplexer struct {
+	nodeBridge NodeBridge
+
+	mutex         sync.RWMutex
+	subscriptions map[*BlockSubscription]struct{}
+	stopped       bool
+}
+
+// NewBlockStreamMultiplexer creates a new BlockStreamMultiplexer.
+func NewBlockStreamMultiplexer(nodeBridge NodeBridge) *BlockStreamMultiplexer {
+	return &BlockStreamMultiplexer{
+		nodeBridge:    nodeBridge,
+		subscriptions: make(map[*BlockSubscription]struct{}),
+	}
+}
+
+// Subscribe adds a new subscriber with the given buffer size and slow consumer policy.
+// If the multiplexer already stopped, the blocks channel of the subscription is closed immediately.
+func (m *BlockStreamMultiplexer) Subscribe(bufferSize int, policy SlowConsumerPolicy) *BlockSubscription {
+	subscription := &BlockSubscription{
+		multiplexer:  m,
+		policy:       policy,
+		blocks:       make(chan *StreamedBlock, bufferSize),
+		unsubscribed: make(chan struct{}),
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stopped {
+		subscription.close(ErrBlockStreamMultiplexerStopped)
+
+		return subscription
+	}
+
+	m.subscriptions[subscription] = struct{}{}
+
+	return subscription
+}
+
+// SubscriberCount returns the amount of active subscribers.
+func (m *BlockStreamMultiplexer) SubscriberCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.subscriptions)
+}
+
+// Run listens to the blocks of the node and distributes them to the subscribers until the context is canceled.
+// Afterwards the blocks channels of all remaining subscribers are closed.
+func (m *BlockStreamMultiplexer) Run(ctx context.Context) error {
+	err := m.nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+		streamedBlock := &StreamedBlock{
+			Block:   block,
+			RawData: rawData,
+		}
+
+		for _, subscription := range m.activeSubscriptions() {
+			if !subscription.deliver(ctx, streamedBlock) {
+				m.disconnect(subscription)
+			}
+		}
+
+		return nil
+	})
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.stopped = true
+	for subscription := range m.subscriptions {
+		delete(m.subscriptions, subscription)
+		subscription.close(ierrors.Join(ErrBlockStreamMultiplexerStopped, err))
+	}
+
+	return err
+}
+
+func (m *BlockStreamMultiplexer) activeSubscriptions() []*BlockSubscription {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	subscriptions := make([]*BlockSubscription, 0, len(m.subscriptions))
+	for subscription := range m.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions
+}
+
+func (m *BlockStreamMultiplexer) removeSubscription(subscription *BlockSubscription) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.subscriptions, subscription)
+}
+
+func (m *BlockStreamMultiplexer) disconnect(subscription *BlockSubscription) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.subscriptions[subscription]; !exists {
+		// the subscriber already unsubscribed
+		return
+	}
+
+	delete(m.subscriptions, subscription)
+	subscription.close(ErrBlockSubscriberDisconnected)
+}
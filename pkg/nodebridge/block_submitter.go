@@ -0,0 +1,275 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultBlockSubmitterMaxRetries is the default amount of retries if the submission failed with a transient error.
+	DefaultBlockSubmitterMaxRetries = 5
+	// DefaultBlockSubmitterInitialBackoff is the default backoff before the first retry.
+	DefaultBlockSubmitterInitialBackoff = 500 * time.Millisecond
+	// DefaultBlockSubmitterMaxBackoff is the default maximum backoff between two retries.
+	DefaultBlockSubmitterMaxBackoff = 10 * time.Second
+	// DefaultBlockSubmitterTimeout is the default time after which a submission expires.
+	DefaultBlockSubmitterTimeout = 1 * time.Minute
+)
+
+var (
+	// ErrBlockSubmissionExpired is returned if the block could not be submitted before the submission timeout.
+	ErrBlockSubmissionExpired = ierrors.New("block submission expired")
+	// ErrBlockSubmissionFallbackNoPayload is returned if the block issuer fallback is used for a block without payload.
+	ErrBlockSubmissionFallbackNoPayload = ierrors.New("block issuer fallback requires a block with an application payload")
+)
+
+// BlockSignerFunc signs the given block after the parents were filled in.
+type BlockSignerFunc func(block *iotago.Block) error
+
+// BlockSubmitterEvents are the events of the BlockSubmitter.
+type BlockSubmitterEvents struct {
+	// BlockAccepted is triggered if the block was accepted by the node or the block issuer.
+	BlockAccepted *event.Event2[*iotago.Block, iotago.BlockID]
+	// BlockFailed is triggered if the block could not be submitted.
+	BlockFailed *event.Event2[*iotago.Block, error]
+	// BlockExpired is triggered if the block could not be submitted before the submission timeout.
+	BlockExpired *event.Event1[*iotago.Block]
+}
+
+// BlockSubmitter submits blocks to the node.
+// It fills in the parents of partial blocks, retries the submission on transient errors
+// with an exponential backoff and optionally falls back to the BlockIssuer plugin of the node.
+type BlockSubmitter struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge  NodeBridge
+	tipProvider TipProvider
+	events      *BlockSubmitterEvents
+
+	signerFunc          BlockSignerFunc
+	maxRetries          uint
+	initialBackoff      time.Duration
+	maxBackoff          time.Duration
+	timeout             time.Duration
+	blockIssuerFallback bool
+}
+
+// WithBlockSignerFunc sets the function that signs blocks after their parents were filled in.
+func WithBlockSignerFunc(signerFunc BlockSignerFunc) options.Option[BlockSubmitter] {
+	return func(s *BlockSubmitter) {
+		s.signerFunc = signerFunc
+	}
+}
+
+// WithTipProvider sets the TipProvider that fills in the parents of partial blocks.
+// It defaults to the tip pool of the node, see NewNodeTipProvider and NewFallbackTipProvider.
+func WithTipProvider(tipProvider TipProvider) options.Option[BlockSubmitter] {
+	return func(s *BlockSubmitter) {
+		s.tipProvider = tipProvider
+	}
+}
+
+// WithSubmitRetries sets the amount of retries and the backoff range of the submission.
+// The backoff starts at initialBackoff and is doubled after every retry until it reaches maxBackoff.
+func WithSubmitRetries(maxRetries uint, initialBackoff time.Duration, maxBackoff time.Duration) options.Option[BlockSubmitter] {
+	return func(s *BlockSubmitter) {
+		s.maxRetries = maxRetries
+		s.initialBackoff = initialBackoff
+		s.maxBackoff = maxBackoff
+	}
+}
+
+// WithSubmitTimeout sets the time after which a submission expires.
+func WithSubmitTimeout(timeout time.Duration) options.Option[BlockSubmitter] {
+	return func(s *BlockSubmitter) {
+		s.timeout = timeout
+	}
+}
+
+// WithBlockIssuerFallback sends the payload of the block via the BlockIssuer plugin of the node
+// if the direct submission failed.
+func WithBlockIssuerFallback() options.Option[BlockSubmitter] {
+	return func(s *BlockSubmitter) {
+		s.blockIssuerFallback = true
+	}
+}
+
+// NewBlockSubmitter creates a new BlockSubmitter.
+func NewBlockSubmitter(logger log.Logger, nodeBridge NodeBridge, opts ...options.Option[BlockSubmitter]) *BlockSubmitter {
+	return options.Apply(&BlockSubmitter{
+		Logger:      logger,
+		nodeBridge:  nodeBridge,
+		tipProvider: NewNodeTipProvider(nodeBridge),
+		events: &BlockSubmitterEvents{
+			BlockAccepted: event.New2[*iotago.Block, iotago.BlockID](),
+			BlockFailed:   event.New2[*iotago.Block, error](),
+			BlockExpired:  event.New1[*iotago.Block](),
+		},
+		maxRetries:     DefaultBlockSubmitterMaxRetries,
+		initialBackoff: DefaultBlockSubmitterInitialBackoff,
+		maxBackoff:     DefaultBlockSubmitterMaxBackoff,
+		timeout:        DefaultBlockSubmitterTimeout,
+	}, opts)
+}
+
+// Events returns the events.
+func (s *BlockSubmitter) Events() *BlockSubmitterEvents {
+	return s.events
+}
+
+// Submit submits the given block to the node.
+// If the block has no strong parents, the parents are filled in via the TipProvider before every attempt
+// and the block is signed with the BlockSignerFunc (if configured).
+func (s *BlockSubmitter) Submit(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+	ctxSubmit, cancelSubmit := context.WithTimeout(ctx, s.timeout)
+	defer cancelSubmit()
+
+	blockID, err := s.submitWithRetries(ctxSubmit, block)
+	if err != nil && s.blockIssuerFallback && ctxSubmit.Err() == nil {
+		s.LogWarnf("submitting block failed, falling back to the block issuer: %s", err)
+		blockID, err = s.submitViaBlockIssuer(ctxSubmit, block)
+	}
+
+	switch {
+	case err == nil:
+		s.events.BlockAccepted.Trigger(block, blockID)
+
+		return blockID, nil
+
+	case ctx.Err() == nil && ierrors.Is(ctxSubmit.Err(), context.DeadlineExceeded):
+		s.events.BlockExpired.Trigger(block)
+
+		return iotago.EmptyBlockID, ierrors.Join(ErrBlockSubmissionExpired, err)
+
+	default:
+		s.events.BlockFailed.Trigger(block, err)
+
+		return iotago.EmptyBlockID, err
+	}
+}
+
+func (s *BlockSubmitter) submitWithRetries(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+	fillParents := !hasStrongParents(block)
+
+	backoff := s.initialBackoff
+	for attempt := uint(0); ; attempt++ {
+		blockID, err := s.submit(ctx, block, fillParents)
+		if err == nil {
+			return blockID, nil
+		}
+
+		if attempt >= s.maxRetries || !isTransientSubmitError(err) {
+			return iotago.EmptyBlockID, err
+		}
+
+		s.LogDebugf("submitting block failed, retrying in %s: %s", backoff, err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return iotago.EmptyBlockID, ierrors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff = min(2*backoff, s.maxBackoff)
+	}
+}
+
+func (s *BlockSubmitter) submit(ctx context.Context, block *iotago.Block, fillParents bool) (iotago.BlockID, error) {
+	if fillParents {
+		// the tips become outdated quickly, so they are requested again for every attempt
+		if err := s.fillParents(ctx, block); err != nil {
+			return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to fill in the parents of the block")
+		}
+	}
+
+	if s.signerFunc != nil {
+		if err := s.signerFunc(block); err != nil {
+			return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to sign the block")
+		}
+	}
+
+	return s.nodeBridge.SubmitBlock(ctx, block)
+}
+
+func (s *BlockSubmitter) fillParents(ctx context.Context, block *iotago.Block) error {
+	switch body := block.Body.(type) {
+	case *iotago.BasicBlockBody:
+		strong, weak, shallowLike, err := s.tipProvider.Tips(ctx, iotago.BasicBlockMaxParents)
+		if err != nil {
+			return err
+		}
+		body.StrongParents, body.WeakParents, body.ShallowLikeParents = strong, weak, shallowLike
+
+	case *iotago.ValidationBlockBody:
+		strong, weak, shallowLike, err := s.tipProvider.Tips(ctx, iotago.ValidationBlockMaxParents)
+		if err != nil {
+			return err
+		}
+		body.StrongParents, body.WeakParents, body.ShallowLikeParents = strong, weak, shallowLike
+
+	default:
+		return ierrors.Errorf("unsupported block body type %T", block.Body)
+	}
+
+	return nil
+}
+
+func (s *BlockSubmitter) submitViaBlockIssuer(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+	body, isBasicBlock := block.Body.(*iotago.BasicBlockBody)
+	if !isBasicBlock || body.Payload == nil {
+		return iotago.EmptyBlockID, ErrBlockSubmissionFallbackNoPayload
+	}
+
+	blockIssuer, err := s.nodeBridge.BlockIssuer(ctx)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	response, err := blockIssuer.SendPayload(ctx, body.Payload, block.Header.SlotCommitmentID)
+	if err != nil {
+		return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to send the payload via the block issuer")
+	}
+
+	return response.BlockID, nil
+}
+
+func hasStrongParents(block *iotago.Block) bool {
+	switch body := block.Body.(type) {
+	case *iotago.BasicBlockBody:
+		return len(body.StrongParents) > 0
+	case *iotago.ValidationBlockBody:
+		return len(body.StrongParents) > 0
+	default:
+		return true
+	}
+}
+
+// isTransientSubmitError returns true if the submission might succeed if it is retried.
+func isTransientSubmitError(err error) bool {
+	if ierrors.Is(err, ErrMessageTooLarge) || isMessageTooLargeError(err) {
+		return false
+	}
+
+	if ierrors.Is(err, ErrUnavailable) || ierrors.Is(err, ErrTooManyRequests) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
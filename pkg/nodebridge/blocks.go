@@ -2,12 +2,19 @@ package nodebridge
 
 import (
 	"context"
+	"sort"
+	"sync"
 
+	"github.com/iotaledger/hive.go/ierrors"
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
 	"github.com/iotaledger/iota.go/v4/api"
 )
 
+// MaxPendingFinalizationSlots is the maximum amount of slots whose accepted blocks are buffered by ListenToFinalizedBlocks
+// until the slot is finalized.
+const MaxPendingFinalizationSlots = 256
+
 // ActiveRootBlocks returns the active root blocks.
 func (n *nodeBridge) ActiveRootBlocks(ctx context.Context) (map[iotago.BlockID]iotago.CommitmentID, error) {
 	response, err := n.client.ReadActiveRootBlocks(ctx, &inx.NoParams{})
@@ -19,7 +26,10 @@ func (n *nodeBridge) ActiveRootBlocks(ctx context.Context) (map[iotago.BlockID]i
 }
 
 // SubmitBlock submits the given block.
-func (n *nodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+func (n *nodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (blockID iotago.BlockID, err error) {
+	ctx, span := n.startSpan(ctx, "SubmitBlock")
+	defer func() { endSpan(span, err) }()
+
 	blk, err := inx.WrapBlock(block)
 	if err != nil {
 		return iotago.BlockID{}, err
@@ -30,7 +40,10 @@ func (n *nodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (iota
 		return iotago.BlockID{}, err
 	}
 
-	return response.Unwrap(), nil
+	blockID = response.Unwrap()
+	span.SetAttributes(blockIDAttribute(blockID), slotAttribute(blockID.Slot()))
+
+	return blockID, nil
 }
 
 // Block returns the block for the given block ID.
@@ -43,6 +56,32 @@ func (n *nodeBridge) Block(ctx context.Context, blockID iotago.BlockID) (*iotago
 	return inxBlock.UnwrapBlock(n.apiProvider)
 }
 
+// BlockWithRaw returns the block for the given block ID and its raw serialized bytes as sent by the node.
+func (n *nodeBridge) BlockWithRaw(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, []byte, error) {
+	inxBlock, err := n.client.ReadBlock(ctx, inx.NewBlockId(blockID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, err := inxBlock.UnwrapBlock(n.apiProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return block, inxBlock.GetData(), nil
+}
+
+// BlockRaw returns the raw serialized bytes of the block for the given block ID as sent by the node.
+// The block is not decoded, which saves the decoding if the bytes are only relayed.
+func (n *nodeBridge) BlockRaw(ctx context.Context, blockID iotago.BlockID) ([]byte, error) {
+	inxBlock, err := n.client.ReadBlock(ctx, inx.NewBlockId(blockID))
+	if err != nil {
+		return nil, err
+	}
+
+	return inxBlock.GetData(), nil
+}
+
 // BlockMetadata returns the block metadata for the given block ID.
 func (n *nodeBridge) BlockMetadata(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error) {
 	inxBlockMetadata, err := n.client.ReadBlockMetadata(ctx, inx.NewBlockId(blockID))
@@ -54,14 +93,66 @@ func (n *nodeBridge) BlockMetadata(ctx context.Context, blockID iotago.BlockID)
 }
 
 // ListenToBlocks listens to blocks.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost.
+// Blocks issued while the connection was lost are not delivered.
 func (n *nodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return n.listenWithReconnect(ctx, "ListenToBlocks", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		return n.listenToBlocksStream(ctx, nil, func(block *iotago.Block, rawData []byte) error {
+			if err := consumer(block, rawData); err != nil {
+				return err
+			}
+			delivered(block.API.TimeProvider().SlotFromTime(block.Header.IssuingTime))
+
+			return nil
+		})
+	})
+}
+
+// ListenToBlocksBuffered listens to blocks like ListenToBlocks, but passes the blocks to the consumer via a buffer
+// of the given size, so a slow consumer doesn't stall the stream.
+// If the buffer is full, blocks are dropped according to the drop policy and the StreamItemsDropped event is triggered.
+func (n *nodeBridge) ListenToBlocksBuffered(ctx context.Context, bufferSize int, dropPolicy BufferDropPolicy, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return ListenToBlocksBuffered(ctx, n, bufferSize, dropPolicy, consumer)
+}
+
+// ListenToBlocksBuffered listens to the blocks of the NodeBridge via ListenBuffered.
+// The StreamItemsDropped event of the NodeBridge is triggered if blocks are dropped.
+func ListenToBlocksBuffered(ctx context.Context, nodeBridge NodeBridge, bufferSize int, dropPolicy BufferDropPolicy, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return ListenBuffered(ctx, bufferSize, dropPolicy,
+		func(ctx context.Context, bufferConsumer func(block *StreamedBlock) error) error {
+			return nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+				return bufferConsumer(&StreamedBlock{Block: block, RawData: rawData})
+			})
+		},
+		func(block *StreamedBlock) error {
+			return consumer(block.Block, block.RawData)
+		},
+		func(dropped uint64) {
+			nodeBridge.Events().StreamItemsDropped.Trigger("ListenToBlocksBuffered", dropped)
+		},
+	)
+}
+
+func (n *nodeBridge) listenToBlocksStream(ctx context.Context, filter *BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error {
 	stream, err := n.client.ListenToBlocks(ctx, &inx.NoParams{})
 	if err != nil {
 		return err
 	}
 
-	if err := ListenToStream(ctx, stream.Recv, func(block *inx.Block) error {
-		return consumer(block.MustUnwrapBlock(n.apiProvider), block.GetBlock().GetData())
+	if err := ListenToStream(ctx, stream.Recv, func(inxBlock *inx.Block) error {
+		if filter == nil {
+			return consumer(inxBlock.MustUnwrapBlock(n.apiProvider), inxBlock.GetBlock().GetData())
+		}
+
+		block, err := n.unwrapFilteredBlock(filter, inxBlock)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return nil
+		}
+
+		return consumer(block, inxBlock.GetBlock().GetData())
 	}); err != nil {
 		n.LogErrorf("ListenToBlocks failed: %s", err.Error())
 		return err
@@ -71,7 +162,22 @@ func (n *nodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *io
 }
 
 // ListenToAcceptedBlocks listens to accepted blocks.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost.
+// Blocks issued while the connection was lost are not delivered.
 func (n *nodeBridge) ListenToAcceptedBlocks(ctx context.Context, consumer func(*api.BlockMetadataResponse) error) error {
+	return n.listenWithReconnect(ctx, "ListenToAcceptedBlocks", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		return n.listenToAcceptedBlocksStream(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			if err := consumer(blockMetadata); err != nil {
+				return err
+			}
+			delivered(blockMetadata.BlockID.Slot())
+
+			return nil
+		})
+	})
+}
+
+func (n *nodeBridge) listenToAcceptedBlocksStream(ctx context.Context, consumer func(*api.BlockMetadataResponse) error) error {
 	stream, err := n.client.ListenToAcceptedBlocks(ctx, &inx.NoParams{})
 	if err != nil {
 		return err
@@ -93,7 +199,22 @@ func (n *nodeBridge) ListenToAcceptedBlocks(ctx context.Context, consumer func(*
 }
 
 // ListenToConfirmedBlocks listens to confirmed blocks.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost.
+// Blocks issued while the connection was lost are not delivered.
 func (n *nodeBridge) ListenToConfirmedBlocks(ctx context.Context, consumer func(*api.BlockMetadataResponse) error) error {
+	return n.listenWithReconnect(ctx, "ListenToConfirmedBlocks", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		return n.listenToConfirmedBlocksStream(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			if err := consumer(blockMetadata); err != nil {
+				return err
+			}
+			delivered(blockMetadata.BlockID.Slot())
+
+			return nil
+		})
+	})
+}
+
+func (n *nodeBridge) listenToConfirmedBlocksStream(ctx context.Context, consumer func(*api.BlockMetadataResponse) error) error {
 	stream, err := n.client.ListenToConfirmedBlocks(ctx, &inx.NoParams{})
 	if err != nil {
 		return err
@@ -113,3 +234,135 @@ func (n *nodeBridge) ListenToConfirmedBlocks(ctx context.Context, consumer func(
 
 	return nil
 }
+
+// ListenToFinalizedBlocks listens to finalized blocks.
+// The IDs of accepted blocks are buffered until the slot of the block is finalized,
+// which is derived from the LatestFinalizedCommitmentChanged event. The metadata of the blocks is read again
+// once their slot is finalized, so it contains the finalized state. Blocks are delivered in slot order.
+// At most MaxPendingFinalizationSlots slots are buffered, if the finalization stalls, the blocks of the oldest slots
+// are dropped. Blocks of slots that were pruned by the node are dropped as well, since their metadata is not available anymore.
+func (n *nodeBridge) ListenToFinalizedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	var pendingMutex sync.Mutex
+	pendingBlocks := make(map[iotago.SlotIndex][]iotago.BlockID)
+
+	latestFinalizedSlot := func() iotago.SlotIndex {
+		if latestFinalizedCommitment := n.LatestFinalizedCommitment(); latestFinalizedCommitment != nil {
+			return latestFinalizedCommitment.CommitmentID.Slot()
+		}
+
+		return 0
+	}
+
+	// finalizedBlocksAvailable signals that pending blocks might be finalized, it is only written to if it is empty
+	finalizedBlocksAvailable := make(chan struct{}, 1)
+	notifyFinalizedBlocksAvailable := func() {
+		select {
+		case finalizedBlocksAvailable <- struct{}{}:
+		default:
+		}
+	}
+
+	hook := n.events.LatestFinalizedCommitmentChanged.Hook(func(_ *Commitment) {
+		notifyFinalizedBlocksAvailable()
+	})
+	defer hook.Unhook()
+
+	streamGroup := NewStreamGroup(ctx, DefaultStreamGroupDrainTimeout)
+	streamGroup.Go("accepted blocks", func(ctx context.Context) error {
+		return n.ListenToAcceptedBlocks(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			slot := blockMetadata.BlockID.Slot()
+
+			pendingMutex.Lock()
+			pendingBlocks[slot] = append(pendingBlocks[slot], blockMetadata.BlockID)
+			n.prunePendingFinalizationSlots(pendingBlocks)
+			pendingMutex.Unlock()
+
+			if slot <= latestFinalizedSlot() {
+				// the slot of the block is already finalized
+				notifyFinalizedBlocksAvailable()
+			}
+
+			return nil
+		})
+	})
+	streamGroup.Go("finalized blocks", func(ctx context.Context) error {
+		for {
+			finalizedSlot := latestFinalizedSlot()
+
+			pendingMutex.Lock()
+			n.prunePendingFinalizationSlots(pendingBlocks)
+
+			finalizedSlots := make([]iotago.SlotIndex, 0)
+			for slot := range pendingBlocks {
+				if slot <= finalizedSlot {
+					finalizedSlots = append(finalizedSlots, slot)
+				}
+			}
+			sort.Slice(finalizedSlots, func(i, j int) bool { return finalizedSlots[i] < finalizedSlots[j] })
+
+			finalizedBlockIDs := make([]iotago.BlockID, 0)
+			for _, slot := range finalizedSlots {
+				finalizedBlockIDs = append(finalizedBlockIDs, pendingBlocks[slot]...)
+				delete(pendingBlocks, slot)
+			}
+			pendingMutex.Unlock()
+
+			for _, blockID := range finalizedBlockIDs {
+				blockMetadata, err := n.BlockMetadata(ctx, blockID)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					if ierrors.Is(err, ErrNotFound) {
+						n.LogWarnf("ListenToFinalizedBlocks: metadata of finalized block %s not found, block is dropped", blockID)
+
+						continue
+					}
+
+					return ierrors.Wrapf(err, "failed to read the metadata of finalized block %s", blockID)
+				}
+
+				if err := consumer(blockMetadata); err != nil {
+					return err
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-finalizedBlocksAvailable:
+			}
+		}
+	})
+
+	return streamGroup.Wait()
+}
+
+// prunePendingFinalizationSlots drops the pending blocks of the slots that were pruned by the node
+// and of the oldest slots that exceed MaxPendingFinalizationSlots.
+func (n *nodeBridge) prunePendingFinalizationSlots(pendingBlocks map[iotago.SlotIndex][]iotago.BlockID) {
+	if pruningEpoch := n.PruningEpoch(); pruningEpoch > 0 {
+		prunedSlot := n.APIProvider().CommittedAPI().TimeProvider().EpochEnd(pruningEpoch)
+		for slot := range pendingBlocks {
+			if slot <= prunedSlot {
+				n.LogWarnf("ListenToFinalizedBlocks: slot %d was pruned before it was finalized, %d blocks are dropped", slot, len(pendingBlocks[slot]))
+				delete(pendingBlocks, slot)
+			}
+		}
+	}
+
+	if len(pendingBlocks) <= MaxPendingFinalizationSlots {
+		return
+	}
+
+	slots := make([]iotago.SlotIndex, 0, len(pendingBlocks))
+	for slot := range pendingBlocks {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	for _, slot := range slots[:len(slots)-MaxPendingFinalizationSlots] {
+		n.LogWarnf("ListenToFinalizedBlocks: finalization is stalled, %d blocks of slot %d are dropped", len(pendingBlocks[slot]), slot)
+		delete(pendingBlocks, slot)
+	}
+}
@@ -0,0 +1,137 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+const (
+	// capabilityCheckTimeout is the timeout of every check of a required capability.
+	capabilityCheckTimeout = 5 * time.Second
+)
+
+// ErrRequiredCapabilitiesMissing is returned by Connect if the node does not provide all required capabilities.
+var ErrRequiredCapabilitiesMissing = ierrors.New("node does not provide the required capabilities")
+
+// Capability is a feature of the node that an extension requires, see WithRequiredCapabilities.
+type Capability string
+
+const (
+	// CapabilityBaseToken requires the base token to be part of the node configuration.
+	CapabilityBaseToken Capability = "baseToken"
+	// CapabilityLedger requires the node to serve the outputs and the ledger updates via INX.
+	CapabilityLedger Capability = "ledger"
+	// CapabilityBlockIssuance requires the node to provide tips and to accept blocks via INX.
+	CapabilityBlockIssuance Capability = "blockIssuance"
+	// CapabilityIndexer requires the indexer plugin to be available at the node.
+	CapabilityIndexer Capability = "indexer"
+	// CapabilityMQTT requires the MQTT plugin to be available at the node.
+	CapabilityMQTT Capability = "mqtt"
+	// CapabilityBlockIssuer requires the block issuer plugin to be available at the node.
+	CapabilityBlockIssuer Capability = "blockIssuer"
+)
+
+// WithRequiredCapabilities sets the capabilities the node needs to provide.
+// They are checked at Connect, which fails with ErrRequiredCapabilitiesMissing if one of them is missing,
+// so an extension fails fast instead of discovering the missing features of the node at runtime.
+func WithRequiredCapabilities(capabilities ...Capability) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.requiredCapabilities = append(n.requiredCapabilities, capabilities...)
+	}
+}
+
+// checkRequiredCapabilities checks the required capabilities and returns an error that describes all missing ones.
+func (n *nodeBridge) checkRequiredCapabilities(ctx context.Context) error {
+	var err error
+	for _, capability := range n.requiredCapabilities {
+		if capabilityErr := n.checkCapability(ctx, capability); capabilityErr != nil {
+			err = ierrors.Join(err, ierrors.Wrapf(capabilityErr, "capability \"%s\"", capability))
+		}
+	}
+	if err != nil {
+		return ierrors.Join(ErrRequiredCapabilitiesMissing, err)
+	}
+
+	return nil
+}
+
+// checkCapability returns an error if the node does not provide the given capability.
+// Errors that are not caused by the missing capability, e.g. if the node is not synced, are ignored.
+func (n *nodeBridge) checkCapability(ctx context.Context, capability Capability) error {
+	ctx, cancel := context.WithTimeout(ctx, capabilityCheckTimeout)
+	defer cancel()
+
+	switch capability {
+	case CapabilityBaseToken:
+		if n.NodeConfig().GetBaseToken() == nil {
+			return ierrors.New("the node configuration contains no base token")
+		}
+
+		return nil
+
+	case CapabilityLedger:
+		// the output doesn't exist, but the call fails differently if the node does not serve the ledger
+		_, err := n.client.ReadOutput(ctx, inx.NewOutputId(iotago.EmptyOutputID))
+
+		return unimplementedCallError(err)
+
+	case CapabilityBlockIssuance:
+		_, err := n.client.RequestTips(ctx, &inx.TipsRequest{Count: 1})
+
+		return unimplementedCallError(err)
+
+	case CapabilityIndexer:
+		return n.checkPluginCapability(func(nodeClient *nodeclient.Client) error {
+			_, err := nodeClient.Indexer(ctx)
+			return err
+		}, nodeclient.ErrIndexerPluginNotAvailable)
+
+	case CapabilityMQTT:
+		return n.checkPluginCapability(func(nodeClient *nodeclient.Client) error {
+			_, err := nodeClient.EventAPI(ctx)
+			return err
+		}, nodeclient.ErrMQTTPluginNotAvailable)
+
+	case CapabilityBlockIssuer:
+		return n.checkPluginCapability(func(nodeClient *nodeclient.Client) error {
+			_, err := nodeClient.BlockIssuer(ctx)
+			return err
+		}, nodeclient.ErrBlockIssuerPluginNotAvailable)
+
+	default:
+		return ierrors.New("unknown capability")
+	}
+}
+
+// checkPluginCapability returns the notAvailableError if the plugin is not available at the node.
+// In contrast to getPluginClient, it does not wait for the plugin to become available.
+func (n *nodeBridge) checkPluginCapability(initClient func(nodeClient *nodeclient.Client) error, notAvailableError error) error {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return err
+	}
+
+	if err := initClient(nodeClient); ierrors.Is(err, notAvailableError) {
+		return err
+	}
+
+	return nil
+}
+
+// unimplementedCallError returns the error if the node does not implement the called INX method.
+func unimplementedCallError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package nodebridge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// ErrCommitmentChainBroken is returned if a commitment does not reference the previously delivered commitment.
+var ErrCommitmentChainBroken = ierrors.New("commitment does not follow the previous commitment")
+
+// CommitmentChainError is returned by ListenToCommitmentsChained if a commitment does not reference
+// the previously delivered commitment, e.g. because the node switched to another chain.
+type CommitmentChainError struct {
+	// PreviousCommitmentID is the ID of the previously delivered commitment.
+	PreviousCommitmentID iotago.CommitmentID
+	// CommitmentID is the ID of the commitment that does not follow the previously delivered commitment.
+	CommitmentID iotago.CommitmentID
+	// ReferencedCommitmentID is the previous commitment ID the commitment references.
+	ReferencedCommitmentID iotago.CommitmentID
+}
+
+// Error returns the error message.
+func (e *CommitmentChainError) Error() string {
+	return fmt.Sprintf("%s: commitment %s references %s instead of %s", ErrCommitmentChainBroken, e.CommitmentID, e.ReferencedCommitmentID, e.PreviousCommitmentID)
+}
+
+// Unwrap returns ErrCommitmentChainBroken, so ierrors.Is matches it.
+func (e *CommitmentChainError) Unwrap() error {
+	return ErrCommitmentChainBroken
+}
+
+// ListenToCommitmentsChained listens to the commitments of the given NodeBridge and guarantees that every commitment
+// references the previously delivered one. Commitments are delivered in order and exactly once.
+//
+// Missing slots, e.g. after a stream restart, trigger the CommitmentGapDetected event and are backfilled by reading
+// the missing commitments. If startSlot is 0, the first received commitment is delivered without a check.
+// A commitment that does not follow the previous one triggers the CommitmentChainBroken event
+// and stops the listener with a CommitmentChainError.
+func ListenToCommitmentsChained(ctx context.Context, nodeBridge NodeBridge, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
+	var lastCommitment *Commitment
+	nextSlot := startSlot
+
+	chainBroken := func(commitment *Commitment) error {
+		chainErr := &CommitmentChainError{
+			PreviousCommitmentID:   lastCommitment.CommitmentID,
+			CommitmentID:           commitment.CommitmentID,
+			ReferencedCommitmentID: commitment.Commitment.PreviousCommitmentID,
+		}
+		nodeBridge.Events().CommitmentChainBroken.Trigger(chainErr)
+
+		return chainErr
+	}
+
+	deliver := func(commitment *Commitment, rawData []byte) error {
+		if lastCommitment != nil && commitment.Commitment.PreviousCommitmentID != lastCommitment.CommitmentID {
+			return chainBroken(commitment)
+		}
+
+		if err := consumer(commitment, rawData); err != nil {
+			return err
+		}
+		lastCommitment = commitment
+		nextSlot = commitment.CommitmentID.Slot() + 1
+
+		return nil
+	}
+
+	backfill := func(firstSlot, lastSlot iotago.SlotIndex) error {
+		nodeBridge.Events().CommitmentGapDetected.Trigger(firstSlot, lastSlot)
+
+		for slot := firstSlot; slot <= lastSlot; slot++ {
+			commitment, err := nodeBridge.Commitment(ctx, slot)
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to backfill commitment of slot %d", slot)
+			}
+			if commitment == nil {
+				return ierrors.Wrapf(ErrNotFound, "failed to backfill commitment of slot %d", slot)
+			}
+
+			rawData, err := nodeBridge.APIProvider().APIForSlot(slot).Encode(commitment.Commitment)
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to encode backfilled commitment of slot %d", slot)
+			}
+
+			if err := deliver(commitment, rawData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return nodeBridge.ListenToCommitments(ctx, startSlot, endSlot, func(commitment *Commitment, rawData []byte) error {
+		slot := commitment.CommitmentID.Slot()
+
+		if slot < nextSlot {
+			// the commitment was already delivered, e.g. because the stream was restarted,
+			// but the node must not have changed its mind about the latest delivered slot
+			if lastCommitment != nil && slot == lastCommitment.CommitmentID.Slot() && commitment.CommitmentID != lastCommitment.CommitmentID {
+				return chainBroken(commitment)
+			}
+
+			return nil
+		}
+
+		if slot > nextSlot && (lastCommitment != nil || startSlot != 0) {
+			if err := backfill(nextSlot, slot-1); err != nil {
+				return err
+			}
+		}
+
+		return deliver(commitment, rawData)
+	})
+}
+
+// ListenToCommitmentsChained listens to commitments and guarantees that every commitment references the previously delivered one.
+// Missing slots are backfilled, see ListenToCommitmentsChained.
+func (n *nodeBridge) ListenToCommitmentsChained(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
+	return ListenToCommitmentsChained(ctx, n, startSlot, endSlot, consumer)
+}
@@ -0,0 +1,173 @@
+package nodebridge
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"strings"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/merklehasher"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+const (
+	// ProofsPluginRoute is the route of the plugin of the node that serves the commitment proofs.
+	ProofsPluginRoute = "proofs/v1"
+
+	// ProofsRouteCommitmentRoots is the route for getting the roots of a commitment.
+	// The roots are returned serialized via IOTASerializerV2.
+	ProofsRouteCommitmentRoots = "/api/" + ProofsPluginRoute + "/commitments/{" + api.ParameterCommitmentID + "}/roots"
+	// ProofsRouteBlockInclusionProof is the route for getting the inclusion proof of an accepted block in the tangle root of its slot.
+	// The proof is returned serialized via IOTASerializerV2, prefixed with the ID of the commitment of the slot.
+	ProofsRouteBlockInclusionProof = "/api/" + ProofsPluginRoute + "/blocks/{" + api.ParameterBlockID + "}/inclusion-proof"
+)
+
+var (
+	// ErrCommitmentProofsNotSupported is returned if the node does not serve the commitment proofs.
+	ErrCommitmentProofsNotSupported = ierrors.New("node does not support commitment proofs")
+	// ErrInvalidCommitmentProof is returned if a proof that was received from the node could not be verified.
+	ErrInvalidCommitmentProof = ierrors.New("invalid commitment proof")
+)
+
+// CommitmentRoots contains a commitment and the roots it commits to.
+type CommitmentRoots struct {
+	Commitment *Commitment
+	Roots      *iotago.Roots
+}
+
+// Verify checks that the roots are the ones the commitment commits to.
+func (r *CommitmentRoots) Verify() error {
+	if rootsID := r.Roots.ID(); rootsID != r.Commitment.Commitment.RootsID {
+		return ierrors.Wrapf(ErrInvalidCommitmentProof, "roots ID %s does not match the roots ID %s of commitment %s", rootsID, r.Commitment.Commitment.RootsID, r.Commitment.CommitmentID)
+	}
+
+	return nil
+}
+
+// BlockInclusionProof proves that a block was accepted in the slot of a commitment.
+// It can be verified without trusting the node, given the commitment is known to be valid, e.g. by a light client.
+type BlockInclusionProof struct {
+	*CommitmentRoots
+
+	BlockID     iotago.BlockID
+	TangleProof *merklehasher.Proof[iotago.BlockID]
+}
+
+// Verify checks that the roots belong to the commitment and that the block ID is contained in the tangle root.
+func (p *BlockInclusionProof) Verify() error {
+	if err := p.CommitmentRoots.Verify(); err != nil {
+		return err
+	}
+
+	if p.BlockID.Slot() != p.Commitment.CommitmentID.Slot() {
+		return ierrors.Wrapf(ErrInvalidCommitmentProof, "block %s is not part of the slot of commitment %s", p.BlockID, p.Commitment.CommitmentID)
+	}
+
+	//nolint:nosnakecase // false positive
+	hasher := merklehasher.NewHasher[iotago.BlockID](crypto.BLAKE2b_256)
+
+	contained, err := p.TangleProof.ContainsValue(p.BlockID, hasher)
+	if err != nil {
+		return ierrors.Errorf("%w: failed to hash block %s: %w", ErrInvalidCommitmentProof, p.BlockID, err)
+	}
+	if !contained {
+		return ierrors.Wrapf(ErrInvalidCommitmentProof, "tangle proof does not contain block %s", p.BlockID)
+	}
+
+	if tangleRoot := iotago.Identifier(p.TangleProof.Hash(hasher)); tangleRoot != p.Roots.TangleRoot {
+		return ierrors.Wrapf(ErrInvalidCommitmentProof, "tangle proof of block %s does not match the tangle root of commitment %s", p.BlockID, p.Commitment.CommitmentID)
+	}
+
+	return nil
+}
+
+// CommitmentRoots returns the roots of the given commitment, verified against the commitment of the node.
+// It returns ErrCommitmentProofsNotSupported if the node does not serve the commitment proofs.
+func (n *nodeBridge) CommitmentRoots(ctx context.Context, commitmentID iotago.CommitmentID) (*CommitmentRoots, error) {
+	commitment, err := n.CommitmentByID(ctx, commitmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRoots, err := n.readProof(ctx, strings.Replace(ProofsRouteCommitmentRoots, "{"+api.ParameterCommitmentID+"}", commitmentID.ToHex(), 1))
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to read the roots of commitment %s", commitmentID)
+	}
+
+	roots := new(iotago.Roots)
+	if _, err := n.APIProvider().APIForSlot(commitmentID.Slot()).Decode(rawRoots, roots); err != nil {
+		return nil, ierrors.Errorf("%w: failed to decode the roots of commitment %s: %w", ErrInvalidCommitmentProof, commitmentID, err)
+	}
+
+	commitmentRoots := &CommitmentRoots{
+		Commitment: commitment,
+		Roots:      roots,
+	}
+	if err := commitmentRoots.Verify(); err != nil {
+		return nil, err
+	}
+
+	return commitmentRoots, nil
+}
+
+// BlockInclusionProof returns the proof that the given block was accepted in its slot, verified against the commitment of the node.
+// It returns ErrCommitmentProofsNotSupported if the node does not serve the commitment proofs.
+func (n *nodeBridge) BlockInclusionProof(ctx context.Context, blockID iotago.BlockID) (*BlockInclusionProof, error) {
+	rawProof, err := n.readProof(ctx, strings.Replace(ProofsRouteBlockInclusionProof, "{"+api.ParameterBlockID+"}", blockID.ToHex(), 1))
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "failed to read the inclusion proof of block %s", blockID)
+	}
+
+	commitmentID, consumed, err := iotago.CommitmentIDFromBytes(rawProof)
+	if err != nil {
+		return nil, ierrors.Errorf("%w: failed to decode the commitment ID of the inclusion proof of block %s: %w", ErrInvalidCommitmentProof, blockID, err)
+	}
+
+	tangleProof, _, err := merklehasher.ProofFromBytes[iotago.BlockID](rawProof[consumed:])
+	if err != nil {
+		return nil, ierrors.Errorf("%w: failed to decode the inclusion proof of block %s: %w", ErrInvalidCommitmentProof, blockID, err)
+	}
+
+	commitmentRoots, err := n.CommitmentRoots(ctx, commitmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &BlockInclusionProof{
+		CommitmentRoots: commitmentRoots,
+		BlockID:         blockID,
+		TangleProof:     tangleProof,
+	}
+	if err := proof.Verify(); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// readProof reads the serialized proof of the given route from the proofs plugin of the node.
+func (n *nodeBridge) readProof(ctx context.Context, route string) ([]byte, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	supported, err := nodeClient.NodeSupportsRoute(ctx, ProofsPluginRoute)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return nil, ErrCommitmentProofsNotSupported
+	}
+
+	res := new(nodeclient.RawDataEnvelope)
+	//nolint:bodyclose // false positive
+	if _, err := nodeClient.DoWithRequestHeaderHook(ctx, http.MethodGet, route, nodeclient.RequestHeaderHookAcceptIOTASerializerV2, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res.Data, nil
+}
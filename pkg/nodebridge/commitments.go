@@ -64,8 +64,48 @@ func (n *nodeBridge) CommitmentByID(ctx context.Context, id iotago.CommitmentID)
 	return commitmentFromINXCommitment(inxCommitment, n.apiProvider.APIForSlot(id.Index()))
 }
 
+// CommitmentRaw returns the commitment for the given commitment ID and its raw serialized bytes as sent by the node.
+func (n *nodeBridge) CommitmentRaw(ctx context.Context, id iotago.CommitmentID) (*Commitment, []byte, error) {
+	req := &inx.CommitmentRequest{
+		CommitmentId: inx.NewCommitmentId(id),
+	}
+
+	inxCommitment, err := n.client.ReadCommitment(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commitment, err := commitmentFromINXCommitment(inxCommitment, n.apiProvider.APIForSlot(id.Index()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commitment, inxCommitment.GetCommitment().GetData(), nil
+}
+
 // ListenToCommitments listens to commitments.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost
+// and resumes after the last received commitment.
 func (n *nodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
+	return n.listenWithReconnect(ctx, "ListenToCommitments", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		if endSlot != 0 && startSlot > endSlot {
+			// all commitments of the range were already received
+			return nil
+		}
+
+		return n.listenToCommitmentsStream(ctx, startSlot, endSlot, func(commitment *Commitment, rawData []byte) error {
+			if err := consumer(commitment, rawData); err != nil {
+				return err
+			}
+			startSlot = commitment.CommitmentID.Slot() + 1
+			delivered(commitment.CommitmentID.Slot())
+
+			return nil
+		})
+	})
+}
+
+func (n *nodeBridge) listenToCommitmentsStream(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
 	req := &inx.SlotRangeRequest{
 		StartSlot: uint32(startSlot),
 		EndSlot:   uint32(endSlot),
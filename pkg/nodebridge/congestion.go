@@ -0,0 +1,73 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// Congestion returns the congestion of the given account for the next block it issues,
+// which contains the reference mana cost, the block issuance credits of the account and the slot of the estimate.
+func (n *nodeBridge) Congestion(ctx context.Context, accountID iotago.AccountID) (*api.CongestionResponse, error) {
+	nodeClient, err := n.INXNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	accountAddress, ok := accountID.ToAddress().(*iotago.AccountAddress)
+	if !ok {
+		return nil, ierrors.Errorf("unable to convert account ID %s to an account address", accountID)
+	}
+
+	return nodeClient.Congestion(ctx, accountAddress, 0)
+}
+
+// ListenToCongestion passes the congestion of the given account to the consumer for every new commitment,
+// since the reference mana cost only changes per committed slot.
+func (n *nodeBridge) ListenToCongestion(ctx context.Context, accountID iotago.AccountID, consumer func(congestion *api.CongestionResponse) error) error {
+	return ListenToCongestionPerCommitment(ctx, n, accountID, consumer)
+}
+
+// ListenToCongestionPerCommitment queries the congestion of the given account via the NodeBridge after every
+// LatestCommitmentChanged event and passes it to the consumer if the slot of the estimate changed.
+// The current congestion is passed to the consumer right away.
+func ListenToCongestionPerCommitment(ctx context.Context, nodeBridge NodeBridge, accountID iotago.AccountID, consumer func(congestion *api.CongestionResponse) error) error {
+	// the channel only needs to signal that there is a newer commitment, so it is not blocking the event
+	commitmentChan := make(chan struct{}, 1)
+	hook := nodeBridge.Events().LatestCommitmentChanged.Hook(func(_ *Commitment) {
+		select {
+		case commitmentChan <- struct{}{}:
+		default:
+		}
+	})
+	defer hook.Unhook()
+
+	var lastSlot iotago.SlotIndex
+	var delivered bool
+	for {
+		congestion, err := nodeBridge.Congestion(ctx, accountID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return ierrors.Wrapf(err, "failed to read the congestion of account %s", accountID)
+		}
+
+		if !delivered || congestion.Slot != lastSlot {
+			if err := consumer(congestion); err != nil {
+				return err
+			}
+			lastSlot = congestion.Slot
+			delivered = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-commitmentChan:
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package nodebridge
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/requestid"
+	inx "github.com/iotaledger/inx/go"
+)
+
+const (
+	// DefaultMaxRecvMsgSize is the default maximum size of gRPC messages received from the node.
+	// It is higher than the gRPC default of 4 MiB, so big ledger update batches don't break the stream.
+	DefaultMaxRecvMsgSize = 64 << 20 // 64 MiB
+	// DefaultMaxSendMsgSize is the default maximum size of gRPC messages sent to the node.
+	DefaultMaxSendMsgSize = 64 << 20 // 64 MiB
+)
+
+const (
+	// AddressSchemeTCP is the scheme of INX addresses that connect via TCP, it is optional.
+	AddressSchemeTCP = "tcp"
+	// AddressSchemeUnix is the scheme of INX addresses that connect via a Unix domain socket.
+	AddressSchemeUnix = "unix"
+)
+
+// ErrInvalidAddress is returned if the INX address can not be parsed.
+var ErrInvalidAddress = ierrors.New("invalid INX address")
+
+// grpcResolverSchemes are the schemes of gRPC targets that are passed to gRPC unchanged,
+// so the address can still select the name resolver, e.g. "dns:///node:9029" or "ipv4:10.0.0.1:9029".
+var grpcResolverSchemes = map[string]struct{}{
+	"dns":           {},
+	"passthrough":   {},
+	"ipv4":          {},
+	"ipv6":          {},
+	"unix-abstract": {},
+	"xds":           {},
+}
+
+// DialerFunc creates the connection to the given address.
+type DialerFunc func(ctx context.Context, address string) (net.Conn, error)
+
+// WithDialer sets a custom dialer that is used to create the connection to the node.
+// The address passed to Connect is passed to the dialer unchanged and is not validated, except that it must not be empty.
+func WithDialer(dialer DialerFunc) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.dialer = dialer
+	}
+}
+
+// WithInMemoryListener connects to a gRPC server that is served on the given in-memory listener,
+// e.g. for tests or if the extension runs in the same process as the node.
+func WithInMemoryListener(listener *bufconn.Listener) options.Option[nodeBridge] {
+	return WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	})
+}
+
+// ParseAddress validates the given INX address and returns the gRPC target of it.
+// Supported formats are "host:port", "tcp://host:port", "unix:relative/path" and "unix:///absolute/path".
+// gRPC targets with a resolver scheme, e.g. "dns:///host:port" or "passthrough:///host:port", are returned unchanged.
+func ParseAddress(address string) (string, error) {
+	scheme, rest, hasScheme := strings.Cut(address, ":")
+	if !hasScheme {
+		return "", ierrors.Wrapf(ErrInvalidAddress, "missing port in address \"%s\"", address)
+	}
+
+	if _, isResolverScheme := grpcResolverSchemes[strings.ToLower(scheme)]; isResolverScheme {
+		// a host that is named like a resolver scheme is followed by the port only, e.g. "dns:9029"
+		if _, err := strconv.ParseUint(rest, 10, 16); err != nil {
+			if rest == "" {
+				return "", ierrors.Wrapf(ErrInvalidAddress, "missing endpoint in address \"%s\"", address)
+			}
+
+			return address, nil
+		}
+	}
+
+	switch strings.ToLower(scheme) {
+	case AddressSchemeUnix:
+		path := strings.TrimPrefix(rest, "//")
+		if path == "" {
+			return "", ierrors.Wrapf(ErrInvalidAddress, "missing socket path in address \"%s\"", address)
+		}
+
+		return AddressSchemeUnix + ":" + path, nil
+
+	case AddressSchemeTCP:
+		if !strings.HasPrefix(rest, "//") {
+			return "", ierrors.Wrapf(ErrInvalidAddress, "missing \"//\" after scheme in address \"%s\"", address)
+		}
+		address = strings.TrimPrefix(rest, "//")
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", ierrors.Errorf("%w: address \"%s\": %w", ErrInvalidAddress, address, err)
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", ierrors.Wrapf(ErrInvalidAddress, "invalid port in address \"%s\"", address)
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+// dialTarget returns the gRPC target of the given address.
+func (n *nodeBridge) dialTarget(address string) (string, error) {
+	if n.dialer == nil {
+		return ParseAddress(address)
+	}
+
+	if address == "" {
+		return "", ierrors.Wrap(ErrInvalidAddress, "empty address")
+	}
+
+	// the address is passed to the custom dialer unchanged
+	return "passthrough:///" + address, nil
+}
+
+// WithKeepalive enables gRPC keepalive pings on the connection to the node,
+// which detects connections that were silently dropped by NATs or load balancers.
+// A ping is sent after the given time without activity, and the connection is closed
+// if the ping is not acknowledged within the given timeout.
+// If permitWithoutStream is true, pings are also sent if there are no active streams.
+// Keepalive is disabled if time is 0.
+func WithKeepalive(time time.Duration, timeout time.Duration, permitWithoutStream bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		if time == 0 {
+			n.keepaliveParams = nil
+			return
+		}
+
+		n.keepaliveParams = &keepalive.ClientParameters{
+			Time:                time,
+			Timeout:             timeout,
+			PermitWithoutStream: permitWithoutStream,
+		}
+	}
+}
+
+// WithMaxRecvMsgSize sets the maximum size in bytes of gRPC messages received from the node.
+// Streams and calls fail with ErrMessageTooLarge if a message exceeds the limit.
+func WithMaxRecvMsgSize(size int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.maxRecvMsgSize = size
+	}
+}
+
+// WithMaxSendMsgSize sets the maximum size in bytes of gRPC messages sent to the node.
+// Calls fail with ErrMessageTooLarge if a message exceeds the limit.
+func WithMaxSendMsgSize(size int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.maxSendMsgSize = size
+	}
+}
+
+// WithCallTimeout sets the default timeout of unary INX calls whose context has no deadline.
+// The timeout is disabled if it is 0. The timeouts per call class of WithDefaultTimeouts take precedence.
+func WithCallTimeout(timeout time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.callTimeout = timeout
+	}
+}
+
+// WithDefaultTimeouts sets the default timeouts per call class, which are applied if the context of the call has no deadline,
+// so calls with context.Background() don't hang forever on a wedged node.
+// The read timeout applies to unary calls that only read data, the write timeout to unary calls that change the state
+// of the node (e.g. SubmitBlock, RegisterAPIRoute), and the stream establish timeout to the creation of streams.
+// Established streams are not affected. A timeout of 0 disables it, unary calls then fall back to the call timeout.
+func WithDefaultTimeouts(read time.Duration, write time.Duration, streamEstablish time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.readTimeout = read
+		n.writeTimeout = write
+		n.streamEstablishTimeout = streamEstablish
+	}
+}
+
+// dialOptions returns the connection related dial options.
+func (n *nodeBridge) dialOptions() []grpc.DialOption {
+	dialOptions := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(n.maxRecvMsgSize), grpc.MaxCallSendMsgSize(n.maxSendMsgSize)),
+	}
+
+	if n.keepaliveParams != nil {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(*n.keepaliveParams))
+	}
+
+	if n.dialer != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(n.dialer))
+	}
+
+	dialOptions = append(dialOptions, n.tracingDialOptions()...)
+
+	return dialOptions
+}
+
+// callTimeoutUnaryClientInterceptor applies the timeout of the call class to unary calls whose context has no deadline.
+// It needs to be placed in front of the retry interceptor, so the timeout covers all attempts.
+func (n *nodeBridge) callTimeoutUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	timeout := n.unaryCallTimeout(method, req)
+	if timeout == 0 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	ctxTimeout, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+
+	return invoker(ctxTimeout, method, req, reply, cc, opts...)
+}
+
+// unaryCallTimeout returns the timeout of the class of the given unary call, or the call timeout if it is not set.
+func (n *nodeBridge) unaryCallTimeout(method string, req any) time.Duration {
+	timeout := n.readTimeout
+	if isWriteCall(method, req) {
+		timeout = n.writeTimeout
+	}
+
+	if timeout == 0 {
+		return n.callTimeout
+	}
+
+	return timeout
+}
+
+// isWriteCall returns true if the unary call changes the state of the node.
+func isWriteCall(method string, req any) bool {
+	switch method {
+	case inx.INX_SubmitBlock_FullMethodName,
+		inx.INX_ForceCommitUntil_FullMethodName,
+		inx.INX_RegisterAPIRoute_FullMethodName,
+		inx.INX_UnregisterAPIRoute_FullMethodName:
+		return true
+
+	case inx.INX_PerformAPIRequest_FullMethodName:
+		// API requests are proxied to the REST API of the node, only GET requests are reads
+		if apiRequest, ok := req.(*inx.APIRequest); ok {
+			return !strings.EqualFold(apiRequest.GetMethod(), http.MethodGet)
+		}
+
+		return false
+
+	default:
+		return false
+	}
+}
+
+// streamEstablishTimeoutStreamClientInterceptor limits the time to establish streams whose context has no deadline.
+// The timeout is only applied until the stream was created, the established stream is not affected.
+func (n *nodeBridge) streamEstablishTimeoutStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if n.streamEstablishTimeout == 0 {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	// a deadline would also end the established stream, so the context is canceled by a timer instead
+	ctxStream, cancelStream := context.WithCancel(ctx)
+	timer := time.AfterFunc(n.streamEstablishTimeout, cancelStream)
+
+	stream, err := streamer(ctxStream, desc, cc, method, opts...)
+	if !timer.Stop() {
+		cancelStream()
+
+		return nil, status.Errorf(codes.DeadlineExceeded, "establishing stream %s timed out after %s", method, n.streamEstablishTimeout)
+	}
+	if err != nil {
+		cancelStream()
+
+		return nil, err
+	}
+
+	return &cancelOnEndClientStream{ClientStream: stream, cancel: cancelStream}, nil
+}
+
+// cancelOnEndClientStream releases the context of the stream once the stream ended.
+type cancelOnEndClientStream struct {
+	grpc.ClientStream
+
+	cancel context.CancelFunc
+}
+
+func (s *cancelOnEndClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+	}
+
+	return err
+}
+
+// requestIDUnaryClientInterceptor propagates the request ID of the context to the node via the gRPC metadata,
+// so the calls of a REST API request can be correlated node-side.
+func requestIDUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingRequestIDContext(ctx), method, req, reply, cc, opts...)
+}
+
+// requestIDStreamClientInterceptor propagates the request ID of the context to the node via the gRPC metadata.
+func requestIDStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingRequestIDContext(ctx), desc, cc, method, opts...)
+}
+
+func outgoingRequestIDContext(ctx context.Context) context.Context {
+	id, exists := requestid.FromContext(ctx)
+	if !exists {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+}
+
+// watchConnectionState triggers the ConnectionStateChanged event on every state change of the connection to the node.
+// If the connection was re-established, the known API routes are registered again.
+func (n *nodeBridge) watchConnectionState(ctx context.Context) error {
+	state := n.conn.GetState()
+	for n.conn.WaitForStateChange(ctx, state) {
+		previousState := state
+		state = n.conn.GetState()
+
+		n.LogDebugf("INX connection state changed: %s", state)
+		n.events.ConnectionStateChanged.Trigger(state)
+
+		if state == connectivity.Ready && previousState != connectivity.Ready {
+			n.reregisterAPIRoutes(ctx)
+		}
+	}
+
+	// WaitForStateChange only returns false if the context is done
+	return nil
+}
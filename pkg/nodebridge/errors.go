@@ -0,0 +1,95 @@
+package nodebridge
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+var (
+	// ErrNotFound is returned if the requested object was not found on the node.
+	ErrNotFound = ierrors.New("not found")
+	// ErrUnavailable is returned if the node is currently unavailable.
+	ErrUnavailable = ierrors.New("unavailable")
+	// ErrAlreadyExists is returned if the object already exists on the node.
+	ErrAlreadyExists = ierrors.New("already exists")
+	// ErrTooManyRequests is returned if the node rejected the request because of resource exhaustion.
+	ErrTooManyRequests = ierrors.New("too many requests")
+	// ErrMessageTooLarge is returned if a gRPC message exceeded the configured message size limit,
+	// see WithMaxRecvMsgSize and WithMaxSendMsgSize.
+	ErrMessageTooLarge = ierrors.New("gRPC message size limit exceeded, increase the limit via WithMaxRecvMsgSize or WithMaxSendMsgSize")
+)
+
+// isMessageTooLargeError returns true if the gRPC error was caused by a message that exceeded the message size limit.
+func isMessageTooLargeError(err error) bool {
+	if status.Code(err) != codes.ResourceExhausted {
+		return false
+	}
+
+	// gRPC reports exceeded message size limits as resource exhaustion without a dedicated code,
+	// so the message of the status needs to be checked
+	return strings.Contains(status.Convert(err).Message(), "message larger than max")
+}
+
+// wrapGRPCError wraps gRPC status errors with the matching sentinel error.
+// The gRPC status of the original error is preserved, so status.Code still works on the wrapped error.
+func wrapGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var sentinel error
+	switch status.Code(err) {
+	case codes.NotFound:
+		sentinel = ErrNotFound
+	case codes.Unavailable:
+		sentinel = ErrUnavailable
+	case codes.AlreadyExists:
+		sentinel = ErrAlreadyExists
+	case codes.ResourceExhausted:
+		sentinel = ErrTooManyRequests
+		if isMessageTooLargeError(err) {
+			sentinel = ErrMessageTooLarge
+		}
+	default:
+		return err
+	}
+
+	if ierrors.Is(err, sentinel) {
+		return err
+	}
+
+	return ierrors.Errorf("%w: %w", sentinel, err)
+}
+
+// errorWrappingUnaryClientInterceptor wraps the errors of unary calls with the matching sentinel error.
+func errorWrappingUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return wrapGRPCError(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// errorWrappingStreamClientInterceptor wraps the errors of streams with the matching sentinel error.
+func errorWrappingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, wrapGRPCError(err)
+	}
+
+	return &errorWrappingClientStream{ClientStream: stream}, nil
+}
+
+type errorWrappingClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *errorWrappingClientStream) RecvMsg(m any) error {
+	return wrapGRPCError(s.ClientStream.RecvMsg(m))
+}
+
+func (s *errorWrappingClientStream) SendMsg(m any) error {
+	return wrapGRPCError(s.ClientStream.SendMsg(m))
+}
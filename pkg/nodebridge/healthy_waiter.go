@@ -0,0 +1,46 @@
+package nodebridge
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// healthyWaiterCheckInterval is the interval in which the node health is checked while waiting.
+	healthyWaiterCheckInterval = 1 * time.Second
+)
+
+// HealthyWaiter waits until the node is healthy.
+type HealthyWaiter struct {
+	nodeBridge NodeBridge
+	enabled    bool
+}
+
+// NewHealthyWaiter creates a new HealthyWaiter.
+// If enabled is false, Wait returns immediately.
+func NewHealthyWaiter(nodeBridge NodeBridge, enabled bool) *HealthyWaiter {
+	return &HealthyWaiter{
+		nodeBridge: nodeBridge,
+		enabled:    enabled,
+	}
+}
+
+// Wait blocks until the node is healthy or the context is canceled.
+func (w *HealthyWaiter) Wait(ctx context.Context) error {
+	if !w.enabled {
+		return nil
+	}
+
+	ticker := time.NewTicker(healthyWaiterCheckInterval)
+	defer ticker.Stop()
+
+	for !w.nodeBridge.IsNodeHealthy() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
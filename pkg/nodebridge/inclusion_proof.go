@@ -0,0 +1,101 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// ErrTransactionNotCommitted is returned if the slot of the block that included the transaction was not committed yet.
+var ErrTransactionNotCommitted = ierrors.New("transaction not committed yet")
+
+// InclusionProof contains everything that is needed to prove the inclusion of a transaction in the ledger.
+type InclusionProof struct {
+	// TransactionID is the ID of the transaction.
+	TransactionID iotago.TransactionID
+	// BlockID is the ID of the block that included the transaction.
+	BlockID iotago.BlockID
+	// Block is the block that included the transaction.
+	Block *iotago.Block
+	// OutputIDProofs are the output ID proofs of the outputs created by the transaction, ordered by output index.
+	OutputIDProofs []*iotago.OutputIDProof
+	// Commitment is the commitment of the slot of the block that included the transaction.
+	Commitment *Commitment
+}
+
+// NewInclusionProof creates an InclusionProof from the outputs created by a transaction,
+// the block that included the transaction and the commitment of the slot of the block.
+func NewInclusionProof(transactionID iotago.TransactionID, created []*Output, block *iotago.Block, commitment *Commitment) (*InclusionProof, error) {
+	if len(created) == 0 {
+		return nil, ierrors.Errorf("transaction %s has no created outputs", transactionID.ToHex())
+	}
+
+	blockID := created[0].Metadata.BlockID
+	if commitment == nil {
+		return nil, ierrors.Wrapf(ErrTransactionNotCommitted, "slot %d of block %s", blockID.Slot(), blockID.ToHex())
+	}
+	if commitment.CommitmentID.Slot() != blockID.Slot() {
+		return nil, ierrors.Errorf("commitment %s does not belong to the slot of block %s", commitment.CommitmentID, blockID.ToHex())
+	}
+
+	outputIDProofs := make([]*iotago.OutputIDProof, 0, len(created))
+	for _, output := range created {
+		if output.OutputID.TransactionID() != transactionID {
+			return nil, ierrors.Errorf("output %s was not created by transaction %s", output.OutputID.ToHex(), transactionID.ToHex())
+		}
+
+		// the proof is verified here, since the verification might have been skipped while unwrapping the output
+		outputIDProof, err := output.VerifiedOutputIDProof()
+		if err != nil {
+			return nil, err
+		}
+
+		outputIDProofs = append(outputIDProofs, outputIDProof)
+	}
+
+	return &InclusionProof{
+		TransactionID:  transactionID,
+		BlockID:        blockID,
+		Block:          block,
+		OutputIDProofs: outputIDProofs,
+		Commitment:     commitment,
+	}, nil
+}
+
+// InclusionProof returns the block that included the transaction with the given transaction ID,
+// the output ID proofs of all outputs created by the transaction and the commitment of the slot of the block.
+// It returns ErrTransactionNotCommitted if the slot of the block was not committed yet.
+func (n *nodeBridge) InclusionProof(ctx context.Context, transactionID iotago.TransactionID) (*InclusionProof, error) {
+	firstOutput, err := n.Output(ctx, iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	blockID := firstOutput.Metadata.BlockID
+	if latestCommitment := n.LatestCommitment(); latestCommitment == nil || latestCommitment.CommitmentID.Slot() < blockID.Slot() {
+		return nil, ierrors.Wrapf(ErrTransactionNotCommitted, "slot %d of block %s", blockID.Slot(), blockID.ToHex())
+	}
+
+	block, err := n.Block(ctx, blockID)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to read block %s of transaction %s", blockID.ToHex(), transactionID.ToHex())
+	}
+
+	signedTransaction, err := blockSignedTransaction(blockID, block)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := n.transactionCreatedOutputs(ctx, transactionID, firstOutput, signedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := n.Commitment(ctx, blockID.Slot())
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to read commitment of slot %d", blockID.Slot())
+	}
+
+	return NewInclusionProof(transactionID, created, block, commitment)
+}
@@ -2,6 +2,10 @@ package nodebridge
 
 import (
 	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/iotaledger/hive.go/ierrors"
 	inx "github.com/iotaledger/inx/go"
@@ -28,6 +32,20 @@ type Output struct {
 	RawOutputData []byte
 }
 
+// VerifiedOutputIDProof verifies that the output ID proof belongs to the output and the output ID and returns it.
+// The proofs of unwrapped outputs are already verified, unless WithSkipOutputProofVerification is enabled.
+func (o *Output) VerifiedOutputIDProof() (*iotago.OutputIDProof, error) {
+	if o.OutputIDProof == nil {
+		return nil, ierrors.Errorf("output %s has no output ID proof", o.OutputID.ToHex())
+	}
+
+	if err := verifyOutputIDProof(o.OutputID, o.Output, o.OutputIDProof); err != nil {
+		return nil, err
+	}
+
+	return o.OutputIDProof, nil
+}
+
 type LedgerUpdate struct {
 	API          iotago.API
 	CommitmentID iotago.CommitmentID
@@ -36,7 +54,32 @@ type LedgerUpdate struct {
 }
 
 // ListenToLedgerUpdates listens to ledger updates.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost
+// and resumes after the slot of the last received ledger update.
 func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *LedgerUpdate) error) error {
+	return n.listenToLedgerUpdates(ctx, startSlot, endSlot, nil, consumer)
+}
+
+func (n *nodeBridge) listenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *OutputFilter, consumer func(update *LedgerUpdate) error) error {
+	return n.listenWithReconnect(ctx, "ListenToLedgerUpdates", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		if endSlot != 0 && startSlot > endSlot {
+			// all ledger updates of the range were already received
+			return nil
+		}
+
+		return n.listenToLedgerUpdatesStream(ctx, startSlot, endSlot, filter, func(update *LedgerUpdate) error {
+			if err := consumer(update); err != nil {
+				return err
+			}
+			startSlot = update.CommitmentID.Slot() + 1
+			delivered(update.CommitmentID.Slot())
+
+			return nil
+		})
+	})
+}
+
+func (n *nodeBridge) listenToLedgerUpdatesStream(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *OutputFilter, consumer func(update *LedgerUpdate) error) error {
 	req := &inx.SlotRangeRequest{
 		StartSlot: uint32(startSlot),
 		EndSlot:   uint32(endSlot),
@@ -49,6 +92,18 @@ func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSl
 
 	var update *LedgerUpdate
 	var latestCommitmentID iotago.CommitmentID
+	// every batch is traced from its begin marker until it was processed by the consumer
+	var batchSpan trace.Span
+	defer func() {
+		if batchSpan != nil {
+			// the stream ended within a batch
+			endSpan(batchSpan, ErrLedgerUpdateEndedAbruptly)
+		}
+	}()
+	// the received operations are counted separately, because filtered outputs are not added to the update
+	var consumedCount, createdCount uint32
+	// the outputs are unwrapped in parallel once the batch is complete
+	var pendingConsumed, pendingCreated []*pendingOutput
 	if err := ListenToStream(ctx, stream.Recv, func(payload *inx.LedgerUpdate) error {
 		switch op := payload.GetOp().(type) {
 		case *inx.LedgerUpdate_BatchMarker:
@@ -67,6 +122,9 @@ func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSl
 					Created:      make([]*Output, 0),
 				}
 				latestCommitmentID = n.LatestCommitment().CommitmentID
+				consumedCount, createdCount = 0, 0
+				pendingConsumed, pendingCreated = make([]*pendingOutput, 0), make([]*pendingOutput, 0)
+				_, batchSpan = n.startSpan(ctx, "LedgerUpdate", slotAttribute(commitmentID.Slot()), commitmentIDAttribute(commitmentID))
 
 			case inx.LedgerUpdate_Marker_END:
 				commitmentID := op.BatchMarker.GetCommitmentId().Unwrap()
@@ -75,13 +133,26 @@ func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSl
 					return ErrLedgerUpdateInvalidOperation
 				}
 
-				if uint32(len(update.Consumed)) != op.BatchMarker.GetConsumedCount() ||
-					uint32(len(update.Created)) != op.BatchMarker.GetCreatedCount() ||
+				if consumedCount != op.BatchMarker.GetConsumedCount() ||
+					createdCount != op.BatchMarker.GetCreatedCount() ||
 					update.CommitmentID != commitmentID {
 					return ErrLedgerUpdateEndedAbruptly
 				}
 
-				if err := consumer(update); err != nil {
+				var err error
+				if update.Consumed, err = n.unwrapOutputs(pendingConsumed, latestCommitmentID); err != nil {
+					return ierrors.Wrap(err, "unable to unwrap consumed output")
+				}
+				if update.Created, err = n.unwrapOutputs(pendingCreated, latestCommitmentID); err != nil {
+					return ierrors.Wrap(err, "unable to unwrap created output")
+				}
+				pendingConsumed, pendingCreated = nil, nil
+
+				batchSpan.SetAttributes(AttributeKeyConsumedCount.Int64(int64(consumedCount)), AttributeKeyCreatedCount.Int64(int64(createdCount)))
+				err = consumer(update)
+				endSpan(batchSpan, err)
+				batchSpan = nil
+				if err != nil {
 					return err
 				}
 				update = nil
@@ -91,25 +162,33 @@ func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSl
 			if update == nil {
 				return ErrLedgerUpdateInvalidOperation
 			}
+			consumedCount++
 
-			output, err := n.unwrapOutput(op.Consumed.GetOutput(), op.Consumed, latestCommitmentID)
+			matches, err := filter.matchesRaw(update.API, op.Consumed.GetOutput().GetOutput().GetData())
 			if err != nil {
-				return ierrors.Wrap(err, "unable to unwrap consumed output")
+				return ierrors.Wrap(err, "unable to filter consumed output")
+			}
+			if !matches {
+				return nil
 			}
 
-			update.Consumed = append(update.Consumed, output)
+			pendingConsumed = append(pendingConsumed, &pendingOutput{output: op.Consumed.GetOutput(), spent: op.Consumed})
 
 		case *inx.LedgerUpdate_Created:
 			if update == nil {
 				return ErrLedgerUpdateInvalidOperation
 			}
+			createdCount++
 
-			output, err := n.unwrapOutput(op.Created, nil, latestCommitmentID)
+			matches, err := filter.matchesRaw(update.API, op.Created.GetOutput().GetData())
 			if err != nil {
-				return ierrors.Wrap(err, "unable to unwrap created output")
+				return ierrors.Wrap(err, "unable to filter created output")
+			}
+			if !matches {
+				return nil
 			}
 
-			update.Created = append(update.Created, output)
+			pendingCreated = append(pendingCreated, &pendingOutput{output: op.Created})
 		}
 
 		return nil
@@ -121,6 +200,61 @@ func (n *nodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSl
 	return nil
 }
 
+// pendingOutput is an output of a ledger update or an accepted transaction that was not unwrapped yet.
+type pendingOutput struct {
+	output *inx.LedgerOutput
+	spent  *inx.LedgerSpent
+}
+
+// unwrapOutputs unwraps the pending outputs in parallel, bounded by the ledger update unwrap concurrency.
+// The order of the outputs is kept.
+func (n *nodeBridge) unwrapOutputs(pending []*pendingOutput, latestCommitmentID iotago.CommitmentID) ([]*Output, error) {
+	outputs := make([]*Output, len(pending))
+
+	workers := min(n.ledgerUpdateUnwrapConcurrency, len(pending))
+	if workers <= 1 {
+		for i, pendingOutput := range pending {
+			output, err := n.unwrapOutput(pendingOutput.output, pendingOutput.spent, latestCommitmentID)
+			if err != nil {
+				return nil, err
+			}
+			outputs[i] = output
+		}
+
+		return outputs, nil
+	}
+
+	// every worker takes the next output that was not unwrapped yet, which avoids a goroutine per output
+	var next atomic.Int64
+	var failed atomic.Bool
+	var group errgroup.Group
+	for range workers {
+		group.Go(func() error {
+			for !failed.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= len(pending) {
+					return nil
+				}
+
+				output, err := n.unwrapOutput(pending[i].output, pending[i].spent, latestCommitmentID)
+				if err != nil {
+					failed.Store(true)
+					return err
+				}
+				outputs[i] = output
+			}
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
 type AcceptedTransaction struct {
 	API           iotago.API
 	Slot          iotago.SlotIndex
@@ -130,7 +264,22 @@ type AcceptedTransaction struct {
 }
 
 // ListenToAcceptedTransactions listens to accepted transactions.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost.
+// Transactions accepted while the connection was lost are not delivered.
 func (n *nodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer func(*AcceptedTransaction) error) error {
+	return n.listenWithReconnect(ctx, "ListenToAcceptedTransactions", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		return n.listenToAcceptedTransactionsStream(ctx, func(tx *AcceptedTransaction) error {
+			if err := consumer(tx); err != nil {
+				return err
+			}
+			delivered(tx.Slot)
+
+			return nil
+		})
+	})
+}
+
+func (n *nodeBridge) listenToAcceptedTransactionsStream(ctx context.Context, consumer func(*AcceptedTransaction) error) error {
 	stream, err := n.client.ListenToAcceptedTransactions(ctx, &inx.NoParams{})
 	if err != nil {
 		return err
@@ -142,25 +291,25 @@ func (n *nodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer
 		latestCommitmentID := n.LatestCommitment().CommitmentID
 
 		inxSpents := tx.GetConsumed()
-		consumed := make([]*Output, 0, len(inxSpents))
+		pendingConsumed := make([]*pendingOutput, 0, len(inxSpents))
 		for _, inxSpent := range inxSpents {
-			output, err := n.unwrapOutput(inxSpent.GetOutput(), inxSpent, latestCommitmentID)
-			if err != nil {
-				return ierrors.Wrap(err, "unable to unwrap consumed output")
-			}
+			pendingConsumed = append(pendingConsumed, &pendingOutput{output: inxSpent.GetOutput(), spent: inxSpent})
+		}
 
-			consumed = append(consumed, output)
+		consumed, err := n.unwrapOutputs(pendingConsumed, latestCommitmentID)
+		if err != nil {
+			return ierrors.Wrap(err, "unable to unwrap consumed output")
 		}
 
 		inxOutputs := tx.GetCreated()
-		created := make([]*Output, 0, len(inxOutputs))
+		pendingCreated := make([]*pendingOutput, 0, len(inxOutputs))
 		for _, inxOutput := range inxOutputs {
-			output, err := n.unwrapOutput(inxOutput, nil, latestCommitmentID)
-			if err != nil {
-				return ierrors.Wrap(err, "unable to unwrap created output")
-			}
+			pendingCreated = append(pendingCreated, &pendingOutput{output: inxOutput})
+		}
 
-			created = append(created, output)
+		created, err := n.unwrapOutputs(pendingCreated, latestCommitmentID)
+		if err != nil {
+			return ierrors.Wrap(err, "unable to unwrap created output")
 		}
 
 		return consumer(&AcceptedTransaction{
@@ -0,0 +1,106 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+var (
+	ErrLedgerSyncBootstrapInconsistent = ierrors.New("unspent outputs of the bootstrap ledger state belong to different commitments")
+	ErrLedgerSyncGap                   = ierrors.New("ledger update does not follow the previous ledger state")
+	ErrLedgerSyncCommitmentUnknown     = ierrors.New("commitment of the empty bootstrap ledger state unknown")
+)
+
+// LedgerSyncHandler handles the bootstrap ledger state and the following ledger updates of SyncLedger.
+type LedgerSyncHandler interface {
+	// BootstrapOutput is called for every unspent output of the bootstrap ledger state.
+	BootstrapOutput(output *Output) error
+	// BootstrapDone is called after all unspent outputs of the bootstrap ledger state were handled.
+	// The commitment ID is the commitment the bootstrap ledger state belongs to.
+	BootstrapDone(commitmentID iotago.CommitmentID) error
+	// LedgerUpdate is called for every ledger update after the bootstrap commitment.
+	LedgerUpdate(update *LedgerUpdate) error
+}
+
+// SyncLedger streams the current unspent outputs to the handler and afterwards
+// follows the ledger updates starting right after the commitment of the bootstrap ledger state.
+// Every ledger update is delivered exactly once, in order and without gaps.
+func (n *nodeBridge) SyncLedger(ctx context.Context, handler LedgerSyncHandler) error {
+	bootstrapCommitmentID, err := n.bootstrapLedger(ctx, handler)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.BootstrapDone(bootstrapCommitmentID); err != nil {
+		return err
+	}
+
+	lastSlot := bootstrapCommitmentID.Slot()
+
+	return n.ListenToLedgerUpdates(ctx, lastSlot+1, 0, func(update *LedgerUpdate) error {
+		slot := update.CommitmentID.Slot()
+		if slot <= lastSlot {
+			// the update is already part of the ledger state
+			return nil
+		}
+
+		if slot != lastSlot+1 {
+			return ierrors.Wrapf(ErrLedgerSyncGap, "expected slot %d, got %d", lastSlot+1, slot)
+		}
+
+		if err := handler.LedgerUpdate(update); err != nil {
+			return err
+		}
+		lastSlot = slot
+
+		return nil
+	})
+}
+
+// bootstrapLedger streams the current unspent outputs to the handler and returns the commitment ID of the ledger state.
+func (n *nodeBridge) bootstrapLedger(ctx context.Context, handler LedgerSyncHandler) (iotago.CommitmentID, error) {
+	stream, err := n.client.ReadUnspentOutputs(ctx, &inx.NoParams{})
+	if err != nil {
+		return iotago.EmptyCommitmentID, err
+	}
+
+	var bootstrapCommitmentID iotago.CommitmentID
+	var bootstrapCommitmentIDSet bool
+	if err := ListenToStream(ctx, stream.Recv, func(unspentOutput *inx.UnspentOutput) error {
+		latestCommitmentID := unspentOutput.GetLatestCommitmentId().Unwrap()
+		if !bootstrapCommitmentIDSet {
+			bootstrapCommitmentID = latestCommitmentID
+			bootstrapCommitmentIDSet = true
+		} else if latestCommitmentID != bootstrapCommitmentID {
+			return ierrors.Wrapf(ErrLedgerSyncBootstrapInconsistent, "expected %s, got %s", bootstrapCommitmentID, latestCommitmentID)
+		}
+
+		output, err := n.unwrapOutput(unspentOutput.GetOutput(), nil, latestCommitmentID)
+		if err != nil {
+			return ierrors.Wrap(err, "unable to unwrap unspent output")
+		}
+
+		return handler.BootstrapOutput(output)
+	}); err != nil {
+		n.LogErrorf("SyncLedger failed to read unspent outputs: %s", err.Error())
+		return iotago.EmptyCommitmentID, err
+	}
+
+	if ctx.Err() != nil {
+		return iotago.EmptyCommitmentID, ctx.Err()
+	}
+
+	if !bootstrapCommitmentIDSet {
+		// the ledger is empty, so the ledger state belongs to the latest commitment
+		latestCommitment := n.LatestCommitment()
+		if latestCommitment == nil {
+			return iotago.EmptyCommitmentID, ierrors.Wrap(ErrLedgerSyncCommitmentUnknown, "no commitment received from the node yet")
+		}
+		bootstrapCommitmentID = latestCommitment.CommitmentID
+	}
+
+	return bootstrapCommitmentID, nil
+}
@@ -0,0 +1,90 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// LedgerCheckpointStore persists the slot of the last processed ledger update.
+type LedgerCheckpointStore interface {
+	// Load returns the slot of the last processed ledger update.
+	// exists is false if no ledger update was processed yet.
+	Load(ctx context.Context) (slot iotago.SlotIndex, exists bool, err error)
+	// Store persists the slot of the last processed ledger update.
+	Store(ctx context.Context, slot iotago.SlotIndex) error
+}
+
+// LedgerUpdateProcessor delivers the ledger updates of the node to a consumer and keeps track of
+// the last processed slot in a LedgerCheckpointStore, so the processing resumes at the correct slot after a restart.
+//
+// Every ledger update is delivered exactly once, in order and without gaps, as long as the effects of the consumer
+// and the checkpoint are persisted atomically. Otherwise the last update might be delivered again after a crash.
+type LedgerUpdateProcessor struct {
+	nodeBridge NodeBridge
+	store      LedgerCheckpointStore
+	consumer   func(update *LedgerUpdate) error
+
+	// startSlot is the slot the processing starts at if no checkpoint exists.
+	startSlot iotago.SlotIndex
+}
+
+// WithLedgerUpdateStartSlot sets the slot the processing starts at if no checkpoint exists.
+// By default the processing starts at the latest ledger update of the node.
+func WithLedgerUpdateStartSlot(startSlot iotago.SlotIndex) options.Option[LedgerUpdateProcessor] {
+	return func(p *LedgerUpdateProcessor) {
+		p.startSlot = startSlot
+	}
+}
+
+// NewLedgerUpdateProcessor creates a new LedgerUpdateProcessor.
+func NewLedgerUpdateProcessor(nodeBridge NodeBridge, store LedgerCheckpointStore, consumer func(update *LedgerUpdate) error, opts ...options.Option[LedgerUpdateProcessor]) *LedgerUpdateProcessor {
+	return options.Apply(&LedgerUpdateProcessor{
+		nodeBridge: nodeBridge,
+		store:      store,
+		consumer:   consumer,
+	}, opts)
+}
+
+// Run processes the ledger updates until the context is canceled or an error occurs.
+func (p *LedgerUpdateProcessor) Run(ctx context.Context) error {
+	lastSlot, checkpointExists, err := p.store.Load(ctx)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to load ledger checkpoint")
+	}
+
+	startSlot := p.startSlot
+	if checkpointExists {
+		startSlot = lastSlot + 1
+	}
+
+	return p.nodeBridge.ListenToLedgerUpdates(ctx, startSlot, 0, func(update *LedgerUpdate) error {
+		slot := update.CommitmentID.Slot()
+
+		if checkpointExists {
+			if slot <= lastSlot {
+				// the update was already processed
+				return nil
+			}
+
+			if slot != lastSlot+1 {
+				return ierrors.Wrapf(ErrLedgerSyncGap, "expected slot %d, got %d", lastSlot+1, slot)
+			}
+		}
+
+		if err := p.consumer(update); err != nil {
+			return err
+		}
+
+		if err := p.store.Store(ctx, slot); err != nil {
+			return ierrors.Wrapf(err, "unable to store ledger checkpoint for slot %d", slot)
+		}
+
+		lastSlot = slot
+		checkpointExists = true
+
+		return nil
+	})
+}
@@ -0,0 +1,436 @@
+package nodebridge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+// LoggingNodeBridge is a NodeBridge decorator that logs every call to the node
+// with its arguments, duration and result status.
+// Methods that only return locally cached state are not logged.
+type LoggingNodeBridge struct {
+	NodeBridge
+
+	logger log.Logger
+	level  log.Level
+}
+
+var _ NodeBridge = &LoggingNodeBridge{}
+
+// NewLoggingNodeBridge creates a new LoggingNodeBridge that logs the calls to the given NodeBridge with the given log level.
+func NewLoggingNodeBridge(logger log.Logger, level log.Level, nodeBridge NodeBridge) *LoggingNodeBridge {
+	return &LoggingNodeBridge{
+		NodeBridge: nodeBridge,
+		logger:     logger,
+		level:      level,
+	}
+}
+
+// logCall logs the call of the given method with its arguments, duration and result status.
+func (l *LoggingNodeBridge) logCall(method string, start time.Time, err error, args ...any) {
+	argStrings := make([]string, 0, len(args))
+	for _, arg := range args {
+		argStrings = append(argStrings, fmt.Sprintf("%v", arg))
+	}
+
+	result := "OK"
+	if err != nil {
+		result = fmt.Sprintf("error: %s", err)
+	}
+
+	l.logger.Logf("%s(%s) took %v, %s", l.level, method, strings.Join(argStrings, ", "), time.Since(start).Truncate(time.Microsecond), result)
+}
+
+// Connect connects to the given address and reads the node configuration.
+func (l *LoggingNodeBridge) Connect(ctx context.Context, address string, maxConnectionAttempts uint) (err error) {
+	defer func(start time.Time) { l.logCall("Connect", start, err, address, maxConnectionAttempts) }(time.Now())
+
+	return l.NodeBridge.Connect(ctx, address, maxConnectionAttempts)
+}
+
+// Run starts the node bridge.
+func (l *LoggingNodeBridge) Run(ctx context.Context) {
+	defer l.logCall("Run", time.Now(), nil)
+
+	l.NodeBridge.Run(ctx)
+}
+
+// Management returns the ManagementClient.
+func (l *LoggingNodeBridge) Management(ctx context.Context) (client nodeclient.ManagementClient, err error) {
+	defer func(start time.Time) { l.logCall("Management", start, err) }(time.Now())
+
+	return l.NodeBridge.Management(ctx)
+}
+
+// Indexer returns the IndexerClient.
+func (l *LoggingNodeBridge) Indexer(ctx context.Context) (client nodeclient.IndexerClient, err error) {
+	defer func(start time.Time) { l.logCall("Indexer", start, err) }(time.Now())
+
+	return l.NodeBridge.Indexer(ctx)
+}
+
+// EventAPI returns the EventAPIClient if supported by the node.
+func (l *LoggingNodeBridge) EventAPI(ctx context.Context) (client *nodeclient.EventAPIClient, err error) {
+	defer func(start time.Time) { l.logCall("EventAPI", start, err) }(time.Now())
+
+	return l.NodeBridge.EventAPI(ctx)
+}
+
+// BlockIssuer returns the BlockIssuerClient.
+func (l *LoggingNodeBridge) BlockIssuer(ctx context.Context) (client nodeclient.BlockIssuerClient, err error) {
+	defer func(start time.Time) { l.logCall("BlockIssuer", start, err) }(time.Now())
+
+	return l.NodeBridge.BlockIssuer(ctx)
+}
+
+// ReadIsCandidate returns true if the given account is a candidate.
+func (l *LoggingNodeBridge) ReadIsCandidate(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (isCandidate bool, err error) {
+	defer func(start time.Time) { l.logCall("ReadIsCandidate", start, err, id, slot) }(time.Now())
+
+	return l.NodeBridge.ReadIsCandidate(ctx, id, slot)
+}
+
+// ReadIsCommitteeMember returns true if the given account is a committee member.
+func (l *LoggingNodeBridge) ReadIsCommitteeMember(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (isCommitteeMember bool, err error) {
+	defer func(start time.Time) { l.logCall("ReadIsCommitteeMember", start, err, id, slot) }(time.Now())
+
+	return l.NodeBridge.ReadIsCommitteeMember(ctx, id, slot)
+}
+
+// ReadIsValidatorAccount returns true if the given account is a validator account.
+func (l *LoggingNodeBridge) ReadIsValidatorAccount(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (isValidator bool, err error) {
+	defer func(start time.Time) { l.logCall("ReadIsValidatorAccount", start, err, id, slot) }(time.Now())
+
+	return l.NodeBridge.ReadIsValidatorAccount(ctx, id, slot)
+}
+
+// ReadCommittee returns the committee of the given epoch.
+func (l *LoggingNodeBridge) ReadCommittee(ctx context.Context, epoch iotago.EpochIndex) (committee *api.CommitteeResponse, err error) {
+	defer func(start time.Time) { l.logCall("ReadCommittee", start, err, epoch) }(time.Now())
+
+	return l.NodeBridge.ReadCommittee(ctx, epoch)
+}
+
+// ReadValidators returns a page of the validators of the given epoch, starting at the cursor (empty for the first page).
+func (l *LoggingNodeBridge) ReadValidators(ctx context.Context, epoch iotago.EpochIndex, cursor string) (validators *api.ValidatorsResponse, err error) {
+	defer func(start time.Time) { l.logCall("ReadValidators", start, err, epoch, cursor) }(time.Now())
+
+	return l.NodeBridge.ReadValidators(ctx, epoch, cursor)
+}
+
+// ReadAllValidators returns all validators of the given epoch.
+func (l *LoggingNodeBridge) ReadAllValidators(ctx context.Context, epoch iotago.EpochIndex) (validators []*api.ValidatorResponse, err error) {
+	defer func(start time.Time) { l.logCall("ReadAllValidators", start, err, epoch) }(time.Now())
+
+	return l.NodeBridge.ReadAllValidators(ctx, epoch)
+}
+
+// StakingInfo returns the staking data of the given validator account.
+func (l *LoggingNodeBridge) StakingInfo(ctx context.Context, accountID iotago.AccountID) (stakingInfo *api.ValidatorResponse, err error) {
+	defer func(start time.Time) { l.logCall("StakingInfo", start, err, accountID) }(time.Now())
+
+	return l.NodeBridge.StakingInfo(ctx, accountID)
+}
+
+// RewardsForOutput returns the mana rewards the given staking account or delegation output can claim in the given epoch (0 for the latest).
+func (l *LoggingNodeBridge) RewardsForOutput(ctx context.Context, outputID iotago.OutputID, claimEpoch iotago.EpochIndex) (rewards *api.ManaRewardsResponse, err error) {
+	defer func(start time.Time) { l.logCall("RewardsForOutput", start, err, outputID, claimEpoch) }(time.Now())
+
+	return l.NodeBridge.RewardsForOutput(ctx, outputID, claimEpoch)
+}
+
+// Congestion returns the congestion of the given account.
+func (l *LoggingNodeBridge) Congestion(ctx context.Context, accountID iotago.AccountID) (congestion *api.CongestionResponse, err error) {
+	defer func(start time.Time) { l.logCall("Congestion", start, err, accountID) }(time.Now())
+
+	return l.NodeBridge.Congestion(ctx, accountID)
+}
+
+// ListenToCongestion passes the congestion of the given account to the consumer for every new commitment.
+func (l *LoggingNodeBridge) ListenToCongestion(ctx context.Context, accountID iotago.AccountID, consumer func(congestion *api.CongestionResponse) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToCongestion", start, err, accountID) }(time.Now())
+
+	return l.NodeBridge.ListenToCongestion(ctx, accountID, consumer)
+}
+
+// RegisterAPIRoute registers the given API route.
+func (l *LoggingNodeBridge) RegisterAPIRoute(ctx context.Context, route string, address string, path string) (err error) {
+	defer func(start time.Time) { l.logCall("RegisterAPIRoute", start, err, route, address, path) }(time.Now())
+
+	return l.NodeBridge.RegisterAPIRoute(ctx, route, address, path)
+}
+
+// UnregisterAPIRoute unregisters the given API route.
+func (l *LoggingNodeBridge) UnregisterAPIRoute(ctx context.Context, route string) (err error) {
+	defer func(start time.Time) { l.logCall("UnregisterAPIRoute", start, err, route) }(time.Now())
+
+	return l.NodeBridge.UnregisterAPIRoute(ctx, route)
+}
+
+// ActiveRootBlocks returns the active root blocks.
+func (l *LoggingNodeBridge) ActiveRootBlocks(ctx context.Context) (rootBlocks map[iotago.BlockID]iotago.CommitmentID, err error) {
+	defer func(start time.Time) { l.logCall("ActiveRootBlocks", start, err) }(time.Now())
+
+	return l.NodeBridge.ActiveRootBlocks(ctx)
+}
+
+// SubmitBlock submits the given block.
+func (l *LoggingNodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (blockID iotago.BlockID, err error) {
+	defer func(start time.Time) { l.logCall("SubmitBlock", start, err, blockID) }(time.Now())
+
+	return l.NodeBridge.SubmitBlock(ctx, block)
+}
+
+// Block returns the block for the given block ID.
+func (l *LoggingNodeBridge) Block(ctx context.Context, blockID iotago.BlockID) (block *iotago.Block, err error) {
+	defer func(start time.Time) { l.logCall("Block", start, err, blockID) }(time.Now())
+
+	return l.NodeBridge.Block(ctx, blockID)
+}
+
+// BlockWithRaw returns the block for the given block ID and its raw serialized bytes as sent by the node.
+func (l *LoggingNodeBridge) BlockWithRaw(ctx context.Context, blockID iotago.BlockID) (block *iotago.Block, rawData []byte, err error) {
+	defer func(start time.Time) { l.logCall("BlockWithRaw", start, err, blockID) }(time.Now())
+
+	return l.NodeBridge.BlockWithRaw(ctx, blockID)
+}
+
+// BlockRaw returns the raw serialized bytes of the block for the given block ID as sent by the node.
+func (l *LoggingNodeBridge) BlockRaw(ctx context.Context, blockID iotago.BlockID) (rawData []byte, err error) {
+	defer func(start time.Time) { l.logCall("BlockRaw", start, err, blockID) }(time.Now())
+
+	return l.NodeBridge.BlockRaw(ctx, blockID)
+}
+
+// BlockMetadata returns the block metadata for the given block ID.
+func (l *LoggingNodeBridge) BlockMetadata(ctx context.Context, blockID iotago.BlockID) (metadata *api.BlockMetadataResponse, err error) {
+	defer func(start time.Time) { l.logCall("BlockMetadata", start, err, blockID) }(time.Now())
+
+	return l.NodeBridge.BlockMetadata(ctx, blockID)
+}
+
+// ListenToBlocks listens to blocks.
+func (l *LoggingNodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToBlocks", start, err) }(time.Now())
+
+	return l.NodeBridge.ListenToBlocks(ctx, consumer)
+}
+
+// ListenToBlocksFiltered listens to the blocks that match the given filter.
+func (l *LoggingNodeBridge) ListenToBlocksFiltered(ctx context.Context, filter *BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToBlocksFiltered", start, err) }(time.Now())
+
+	return l.NodeBridge.ListenToBlocksFiltered(ctx, filter, consumer)
+}
+
+// ListenToBlocksBuffered listens to blocks and passes them to the consumer via a buffer of the given size.
+func (l *LoggingNodeBridge) ListenToBlocksBuffered(ctx context.Context, bufferSize int, dropPolicy BufferDropPolicy, consumer func(block *iotago.Block, rawData []byte) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToBlocksBuffered", start, err, bufferSize, dropPolicy) }(time.Now())
+
+	return l.NodeBridge.ListenToBlocksBuffered(ctx, bufferSize, dropPolicy, consumer)
+}
+
+// ListenToAcceptedBlocks listens to accepted blocks.
+func (l *LoggingNodeBridge) ListenToAcceptedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToAcceptedBlocks", start, err) }(time.Now())
+
+	return l.NodeBridge.ListenToAcceptedBlocks(ctx, consumer)
+}
+
+// ListenToConfirmedBlocks listens to confirmed blocks.
+func (l *LoggingNodeBridge) ListenToConfirmedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToConfirmedBlocks", start, err) }(time.Now())
+
+	return l.NodeBridge.ListenToConfirmedBlocks(ctx, consumer)
+}
+
+// ListenToFinalizedBlocks listens to blocks whose slot was finalized.
+func (l *LoggingNodeBridge) ListenToFinalizedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToFinalizedBlocks", start, err) }(time.Now())
+
+	return l.NodeBridge.ListenToFinalizedBlocks(ctx, consumer)
+}
+
+// TransactionMetadata returns the transaction metadata for the given transaction ID.
+func (l *LoggingNodeBridge) TransactionMetadata(ctx context.Context, transactionID iotago.TransactionID) (metadata *api.TransactionMetadataResponse, err error) {
+	defer func(start time.Time) { l.logCall("TransactionMetadata", start, err, transactionID) }(time.Now())
+
+	return l.NodeBridge.TransactionMetadata(ctx, transactionID)
+}
+
+// TransactionMetadataBatch returns the transaction metadata for the given transaction IDs, looked up concurrently.
+func (l *LoggingNodeBridge) TransactionMetadataBatch(ctx context.Context, transactionIDs []iotago.TransactionID) (metadata map[iotago.TransactionID]*api.TransactionMetadataResponse, err error) {
+	defer func(start time.Time) { l.logCall("TransactionMetadataBatch", start, err, len(transactionIDs)) }(time.Now())
+
+	return l.NodeBridge.TransactionMetadataBatch(ctx, transactionIDs)
+}
+
+// TransactionOutputs returns the outputs created and consumed by the transaction with the given transaction ID.
+func (l *LoggingNodeBridge) TransactionOutputs(ctx context.Context, transactionID iotago.TransactionID) (outputs *TransactionOutputs, err error) {
+	defer func(start time.Time) { l.logCall("TransactionOutputs", start, err, transactionID) }(time.Now())
+
+	return l.NodeBridge.TransactionOutputs(ctx, transactionID)
+}
+
+// InclusionProof returns the inclusion proof of the transaction with the given transaction ID.
+func (l *LoggingNodeBridge) InclusionProof(ctx context.Context, transactionID iotago.TransactionID) (proof *InclusionProof, err error) {
+	defer func(start time.Time) { l.logCall("InclusionProof", start, err, transactionID) }(time.Now())
+
+	return l.NodeBridge.InclusionProof(ctx, transactionID)
+}
+
+// Output returns the output with metadata for the given output ID.
+func (l *LoggingNodeBridge) Output(ctx context.Context, outputID iotago.OutputID) (output *Output, err error) {
+	defer func(start time.Time) { l.logCall("Output", start, err, outputID) }(time.Now())
+
+	return l.NodeBridge.Output(ctx, outputID)
+}
+
+// Outputs returns the outputs with metadata for the given output IDs in the same order.
+func (l *LoggingNodeBridge) Outputs(ctx context.Context, outputIDs []iotago.OutputID) (outputs []*Output, err error) {
+	defer func(start time.Time) { l.logCall("Outputs", start, err, len(outputIDs)) }(time.Now())
+
+	return l.NodeBridge.Outputs(ctx, outputIDs)
+}
+
+// OutputAtSlot returns the state of the output for the given output ID as of the given slot.
+func (l *LoggingNodeBridge) OutputAtSlot(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (state *OutputSlotState, err error) {
+	defer func(start time.Time) { l.logCall("OutputAtSlot", start, err, outputID, slot) }(time.Now())
+
+	return l.NodeBridge.OutputAtSlot(ctx, outputID, slot)
+}
+
+// ForceCommitUntil forces the node to commit until the given slot.
+func (l *LoggingNodeBridge) ForceCommitUntil(ctx context.Context, slot iotago.SlotIndex) (err error) {
+	defer func(start time.Time) { l.logCall("ForceCommitUntil", start, err, slot) }(time.Now())
+
+	return l.NodeBridge.ForceCommitUntil(ctx, slot)
+}
+
+// Commitment returns the commitment for the given slot.
+func (l *LoggingNodeBridge) Commitment(ctx context.Context, slot iotago.SlotIndex) (commitment *Commitment, err error) {
+	defer func(start time.Time) { l.logCall("Commitment", start, err, slot) }(time.Now())
+
+	return l.NodeBridge.Commitment(ctx, slot)
+}
+
+// CommitmentByID returns the commitment for the given commitment ID.
+func (l *LoggingNodeBridge) CommitmentByID(ctx context.Context, id iotago.CommitmentID) (commitment *Commitment, err error) {
+	defer func(start time.Time) { l.logCall("CommitmentByID", start, err, id) }(time.Now())
+
+	return l.NodeBridge.CommitmentByID(ctx, id)
+}
+
+// CommitmentRaw returns the commitment for the given commitment ID and its raw serialized bytes.
+func (l *LoggingNodeBridge) CommitmentRaw(ctx context.Context, id iotago.CommitmentID) (commitment *Commitment, rawData []byte, err error) {
+	defer func(start time.Time) { l.logCall("CommitmentRaw", start, err, id) }(time.Now())
+
+	return l.NodeBridge.CommitmentRaw(ctx, id)
+}
+
+// CommitmentRoots returns the roots of the given commitment, verified against the commitment.
+func (l *LoggingNodeBridge) CommitmentRoots(ctx context.Context, id iotago.CommitmentID) (roots *CommitmentRoots, err error) {
+	defer func(start time.Time) { l.logCall("CommitmentRoots", start, err, id) }(time.Now())
+
+	return l.NodeBridge.CommitmentRoots(ctx, id)
+}
+
+// BlockInclusionProof returns the verified proof that the given block was accepted in its slot.
+func (l *LoggingNodeBridge) BlockInclusionProof(ctx context.Context, blockID iotago.BlockID) (proof *BlockInclusionProof, err error) {
+	defer func(start time.Time) { l.logCall("BlockInclusionProof", start, err, blockID) }(time.Now())
+
+	return l.NodeBridge.BlockInclusionProof(ctx, blockID)
+}
+
+// ReadSlotData returns the commitment, the accepted blocks and the ledger update of the given committed slot.
+func (l *LoggingNodeBridge) ReadSlotData(ctx context.Context, slot iotago.SlotIndex, opts ...options.Option[SlotDataOptions]) (slotData *SlotData, err error) {
+	defer func(start time.Time) { l.logCall("ReadSlotData", start, err, slot) }(time.Now())
+
+	return l.NodeBridge.ReadSlotData(ctx, slot, opts...)
+}
+
+// ListenToCommitments listens to commitments.
+func (l *LoggingNodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToCommitments", start, err, startSlot, endSlot) }(time.Now())
+
+	return l.NodeBridge.ListenToCommitments(ctx, startSlot, endSlot, consumer)
+}
+
+// ListenToCommitmentsChained listens to commitments and guarantees that every commitment references the previously delivered one.
+func (l *LoggingNodeBridge) ListenToCommitmentsChained(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToCommitmentsChained", start, err, startSlot, endSlot) }(time.Now())
+
+	return l.NodeBridge.ListenToCommitmentsChained(ctx, startSlot, endSlot, consumer)
+}
+
+// ListenToLedgerUpdates listens to ledger updates.
+func (l *LoggingNodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *LedgerUpdate) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToLedgerUpdates", start, err, startSlot, endSlot) }(time.Now())
+
+	return l.NodeBridge.ListenToLedgerUpdates(ctx, startSlot, endSlot, consumer)
+}
+
+// ListenToFilteredLedgerUpdates listens to ledger updates that only contain the outputs matching the given filter.
+func (l *LoggingNodeBridge) ListenToFilteredLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *OutputFilter, consumer func(update *LedgerUpdate) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToFilteredLedgerUpdates", start, err, startSlot, endSlot) }(time.Now())
+
+	return l.NodeBridge.ListenToFilteredLedgerUpdates(ctx, startSlot, endSlot, filter, consumer)
+}
+
+// SyncLedger streams the current unspent outputs to the handler and afterwards follows the ledger updates.
+func (l *LoggingNodeBridge) SyncLedger(ctx context.Context, handler LedgerSyncHandler) (err error) {
+	defer func(start time.Time) { l.logCall("SyncLedger", start, err) }(time.Now())
+
+	return l.NodeBridge.SyncLedger(ctx, handler)
+}
+
+// ListenToAccountChanges listens to the changes of accounts per committed slot.
+func (l *LoggingNodeBridge) ListenToAccountChanges(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(changes *AccountChanges) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToAccountChanges", start, err, startSlot, endSlot) }(time.Now())
+
+	return l.NodeBridge.ListenToAccountChanges(ctx, startSlot, endSlot, consumer)
+}
+
+// ListenToAddressActivity listens to the outputs that were created for or spent by the addresses in the given set per committed slot.
+func (l *LoggingNodeBridge) ListenToAddressActivity(ctx context.Context, startSlot, endSlot iotago.SlotIndex, addresses *AddressSet, consumer func(activities *AddressActivities) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToAddressActivity", start, err, startSlot, endSlot) }(time.Now())
+
+	return l.NodeBridge.ListenToAddressActivity(ctx, startSlot, endSlot, addresses, consumer)
+}
+
+// ListenToAcceptedTransactions listens to accepted transactions.
+func (l *LoggingNodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer func(tx *AcceptedTransaction) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToAcceptedTransactions", start, err) }(time.Now())
+
+	return l.NodeBridge.ListenToAcceptedTransactions(ctx, consumer)
+}
+
+// ListenToProtocolParameterUpdates passes protocol parameters that become active in a future epoch to the consumer.
+func (l *LoggingNodeBridge) ListenToProtocolParameterUpdates(ctx context.Context, pollInterval time.Duration, consumer func(update *ProtocolParametersUpdate) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToProtocolParameterUpdates", start, err, pollInterval) }(time.Now())
+
+	return l.NodeBridge.ListenToProtocolParameterUpdates(ctx, pollInterval, consumer)
+}
+
+// ListenToNodeStatus listens to node status updates.
+func (l *LoggingNodeBridge) ListenToNodeStatus(ctx context.Context, cooldown time.Duration, consumer func(status *inx.NodeStatus) error) (err error) {
+	defer func(start time.Time) { l.logCall("ListenToNodeStatus", start, err, cooldown) }(time.Now())
+
+	return l.NodeBridge.ListenToNodeStatus(ctx, cooldown, consumer)
+}
+
+// RequestTips requests tips.
+func (l *LoggingNodeBridge) RequestTips(ctx context.Context, count uint32) (strong iotago.BlockIDs, weak iotago.BlockIDs, shallowLike iotago.BlockIDs, err error) {
+	defer func(start time.Time) { l.logCall("RequestTips", start, err, count) }(time.Now())
+
+	return l.NodeBridge.RequestTips(ctx, count)
+}
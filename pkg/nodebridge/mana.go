@@ -0,0 +1,73 @@
+package nodebridge
+
+import (
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// ErrManaOverflow is returned if the sum of the mana of an output overflows.
+var ErrManaOverflow = ierrors.New("mana overflow")
+
+// ManaCalculator calculates the decay and generation of mana with the protocol parameters the node currently knows.
+// The parameters are looked up per target slot via the APIProvider of the NodeBridge,
+// so the calculations stay correct if the protocol parameters change.
+type ManaCalculator struct {
+	nodeBridge NodeBridge
+}
+
+// NewManaCalculator creates a new ManaCalculator.
+func NewManaCalculator(nodeBridge NodeBridge) *ManaCalculator {
+	return &ManaCalculator{
+		nodeBridge: nodeBridge,
+	}
+}
+
+// manaDecayProvider returns the ManaDecayProvider of the protocol parameters that are active in the given slot.
+func (m *ManaCalculator) manaDecayProvider(slot iotago.SlotIndex) *iotago.ManaDecayProvider {
+	return m.nodeBridge.APIProvider().APIForSlot(slot).ManaDecayProvider()
+}
+
+// DecayedMana returns the stored mana that was created in the from slot, decayed until the to slot.
+func (m *ManaCalculator) DecayedMana(stored iotago.Mana, from iotago.SlotIndex, to iotago.SlotIndex) (iotago.Mana, error) {
+	return m.manaDecayProvider(to).DecayManaBySlots(stored, from, to)
+}
+
+// GeneratedMana returns the mana generated by the given amount of base tokens between the from and the to slot, including its decay.
+func (m *ManaCalculator) GeneratedMana(amount iotago.BaseToken, from iotago.SlotIndex, to iotago.SlotIndex) (iotago.Mana, error) {
+	return m.manaDecayProvider(to).GenerateManaAndDecayBySlots(amount, from, to)
+}
+
+// StoredManaAt returns the stored mana of the output, decayed until the given slot.
+// The mana decays since the creation slot of the output.
+func (m *ManaCalculator) StoredManaAt(output *Output, slot iotago.SlotIndex) (iotago.Mana, error) {
+	return m.DecayedMana(output.Output.StoredMana(), output.OutputID.CreationSlot(), slot)
+}
+
+// PotentialManaAt returns the mana that was generated by the base tokens of the output since its creation slot until the given slot.
+// The base tokens that are needed to cover the minimum storage deposit of the output don't generate mana.
+func (m *ManaCalculator) PotentialManaAt(output *Output, slot iotago.SlotIndex) (iotago.Mana, error) {
+	api := m.nodeBridge.APIProvider().APIForSlot(slot)
+
+	return iotago.PotentialMana(api.ManaDecayProvider(), api.StorageScoreStructure(), output.Output, output.OutputID.CreationSlot(), slot)
+}
+
+// AvailableManaAt returns the total mana the output provides if it is consumed in the given slot,
+// which is the sum of the decayed stored mana and the potential mana.
+func (m *ManaCalculator) AvailableManaAt(output *Output, slot iotago.SlotIndex) (iotago.Mana, error) {
+	storedMana, err := m.StoredManaAt(output, slot)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "failed to calculate stored mana of output %s", output.OutputID.ToHex())
+	}
+
+	potentialMana, err := m.PotentialManaAt(output, slot)
+	if err != nil {
+		return 0, ierrors.Wrapf(err, "failed to calculate potential mana of output %s", output.OutputID.ToHex())
+	}
+
+	availableMana := storedMana + potentialMana
+	if availableMana < storedMana {
+		return 0, ierrors.Wrapf(ErrManaOverflow, "output %s", output.OutputID.ToHex())
+	}
+
+	return availableMana, nil
+}
@@ -0,0 +1,271 @@
+package nodebridge
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+const (
+	// DefaultManagementMaxRetries is the default amount of retries if a management call failed with a transient error.
+	DefaultManagementMaxRetries = 3
+	// DefaultManagementInitialBackoff is the default backoff before the first retry.
+	DefaultManagementInitialBackoff = 500 * time.Millisecond
+	// DefaultManagementMaxBackoff is the default maximum backoff between two retries.
+	DefaultManagementMaxBackoff = 5 * time.Second
+	// DefaultManagementTimeout is the default timeout of a management call, including all retries.
+	DefaultManagementTimeout = 30 * time.Second
+)
+
+// ErrManagementRequestsNotSupported is returned if the ManagementClient does not support custom requests,
+// which are needed for pruning the database and creating snapshots.
+var ErrManagementRequestsNotSupported = ierrors.New("management client does not support custom requests")
+
+// managementRequester is implemented by ManagementClients that support requests to routes
+// which are not covered by the ManagementClient interface.
+type managementRequester interface {
+	Do(ctx context.Context, method string, route string, reqObj interface{}, resObj interface{}) (*http.Response, error)
+}
+
+// NodeManagement provides typed access to the management plugin of the node.
+// The ManagementClient is acquired once and reused for all calls,
+// calls that failed with a transient error are retried with an exponential backoff.
+type NodeManagement struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridge NodeBridge
+
+	maxRetries     uint
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+
+	clientMutex sync.Mutex
+	client      nodeclient.ManagementClient
+}
+
+// WithManagementRetries sets the amount of retries and the backoff range of the management calls.
+// The backoff starts at initialBackoff and is doubled after every retry until it reaches maxBackoff.
+func WithManagementRetries(maxRetries uint, initialBackoff time.Duration, maxBackoff time.Duration) options.Option[NodeManagement] {
+	return func(m *NodeManagement) {
+		m.maxRetries = maxRetries
+		m.initialBackoff = initialBackoff
+		m.maxBackoff = maxBackoff
+	}
+}
+
+// WithManagementTimeout sets the timeout of a management call, including all retries.
+func WithManagementTimeout(timeout time.Duration) options.Option[NodeManagement] {
+	return func(m *NodeManagement) {
+		m.timeout = timeout
+	}
+}
+
+// NewNodeManagement creates a new NodeManagement.
+func NewNodeManagement(logger log.Logger, nodeBridge NodeBridge, opts ...options.Option[NodeManagement]) *NodeManagement {
+	return options.Apply(&NodeManagement{
+		Logger:         logger,
+		nodeBridge:     nodeBridge,
+		maxRetries:     DefaultManagementMaxRetries,
+		initialBackoff: DefaultManagementInitialBackoff,
+		maxBackoff:     DefaultManagementMaxBackoff,
+		timeout:        DefaultManagementTimeout,
+	}, opts)
+}
+
+// Peer returns the peer with the given ID.
+func (m *NodeManagement) Peer(ctx context.Context, peerID string) (*api.PeerInfo, error) {
+	var peer *api.PeerInfo
+
+	if err := m.call(ctx, "Peer", func(ctx context.Context, client nodeclient.ManagementClient) error {
+		var err error
+		peer, err = client.PeerByID(ctx, peerID)
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+// Peers returns all peers of the node.
+func (m *NodeManagement) Peers(ctx context.Context) ([]*api.PeerInfo, error) {
+	var peers []*api.PeerInfo
+
+	if err := m.call(ctx, "Peers", func(ctx context.Context, client nodeclient.ManagementClient) error {
+		response, err := client.Peers(ctx)
+		if err != nil {
+			return err
+		}
+		peers = response.Peers
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return peers, nil
+}
+
+// AddPeer adds the peer with the given libp2p multi address and optional alias to the node.
+func (m *NodeManagement) AddPeer(ctx context.Context, multiAddress string, alias string) (*api.PeerInfo, error) {
+	var peer *api.PeerInfo
+
+	if err := m.call(ctx, "AddPeer", func(ctx context.Context, client nodeclient.ManagementClient) error {
+		var err error
+		if alias == "" {
+			peer, err = client.AddPeer(ctx, multiAddress)
+		} else {
+			peer, err = client.AddPeer(ctx, multiAddress, alias)
+		}
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+// RemovePeer removes the peer with the given ID from the node.
+func (m *NodeManagement) RemovePeer(ctx context.Context, peerID string) error {
+	return m.call(ctx, "RemovePeer", func(ctx context.Context, client nodeclient.ManagementClient) error {
+		return client.RemovePeerByID(ctx, peerID)
+	})
+}
+
+// PruneDatabaseByEpoch prunes the database of the node until the given epoch.
+// It returns the oldest epoch that is still in the database.
+func (m *NodeManagement) PruneDatabaseByEpoch(ctx context.Context, epoch iotago.EpochIndex) (iotago.EpochIndex, error) {
+	return m.pruneDatabase(ctx, "PruneDatabaseByEpoch", &api.PruneDatabaseRequest{Epoch: epoch})
+}
+
+// PruneDatabaseByDepth prunes the database of the node, keeping the given amount of epochs.
+// It returns the oldest epoch that is still in the database.
+func (m *NodeManagement) PruneDatabaseByDepth(ctx context.Context, depth iotago.EpochIndex) (iotago.EpochIndex, error) {
+	return m.pruneDatabase(ctx, "PruneDatabaseByDepth", &api.PruneDatabaseRequest{Depth: depth})
+}
+
+// PruneDatabaseBySize prunes the database of the node until it is smaller than the given target size (e.g. "30GB").
+// It returns the oldest epoch that is still in the database.
+func (m *NodeManagement) PruneDatabaseBySize(ctx context.Context, targetDatabaseSize string) (iotago.EpochIndex, error) {
+	return m.pruneDatabase(ctx, "PruneDatabaseBySize", &api.PruneDatabaseRequest{TargetDatabaseSize: targetDatabaseSize})
+}
+
+func (m *NodeManagement) pruneDatabase(ctx context.Context, name string, request *api.PruneDatabaseRequest) (iotago.EpochIndex, error) {
+	response := &api.PruneDatabaseResponse{}
+
+	if err := m.call(ctx, name, func(ctx context.Context, client nodeclient.ManagementClient) error {
+		return doManagementRequest(ctx, client, http.MethodPost, api.ManagementRouteDatabasePrune, request, response)
+	}); err != nil {
+		return 0, err
+	}
+
+	return response.Epoch, nil
+}
+
+// CreateSnapshot creates a snapshot of the given slot on the node.
+func (m *NodeManagement) CreateSnapshot(ctx context.Context, slot iotago.SlotIndex) (*api.CreateSnapshotResponse, error) {
+	response := &api.CreateSnapshotResponse{}
+
+	if err := m.call(ctx, "CreateSnapshot", func(ctx context.Context, client nodeclient.ManagementClient) error {
+		return doManagementRequest(ctx, client, http.MethodPost, api.ManagementRouteSnapshotsCreate, &api.CreateSnapshotsRequest{Slot: slot}, response)
+	}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// call executes the given function with the ManagementClient and retries it on transient errors.
+func (m *NodeManagement) call(ctx context.Context, name string, callFunc func(ctx context.Context, client nodeclient.ManagementClient) error) error {
+	ctxCall, cancelCall := context.WithTimeout(ctx, m.timeout)
+	defer cancelCall()
+
+	backoff := m.initialBackoff
+	for attempt := uint(0); ; attempt++ {
+		client, err := m.managementClient(ctxCall)
+		if err != nil {
+			return err
+		}
+
+		err = callFunc(ctxCall, client)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= m.maxRetries || !isTransientManagementError(err) {
+			return err
+		}
+
+		// the plugin might have been restarted, so the client is acquired again
+		m.resetManagementClient()
+
+		m.LogDebugf("%s failed, retrying in %s: %s", name, backoff, err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctxCall.Done():
+			timer.Stop()
+			return ierrors.Join(err, ctxCall.Err())
+		case <-timer.C:
+		}
+
+		backoff = min(2*backoff, m.maxBackoff)
+	}
+}
+
+func (m *NodeManagement) managementClient(ctx context.Context) (nodeclient.ManagementClient, error) {
+	m.clientMutex.Lock()
+	defer m.clientMutex.Unlock()
+
+	if m.client != nil {
+		return m.client, nil
+	}
+
+	client, err := m.nodeBridge.Management(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.client = client
+
+	return client, nil
+}
+
+func (m *NodeManagement) resetManagementClient() {
+	m.clientMutex.Lock()
+	defer m.clientMutex.Unlock()
+
+	m.client = nil
+}
+
+func doManagementRequest(ctx context.Context, client nodeclient.ManagementClient, method string, route string, request any, response any) error {
+	requester, supported := client.(managementRequester)
+	if !supported {
+		return ErrManagementRequestsNotSupported
+	}
+
+	//nolint:bodyclose // the body is closed by the client
+	_, err := requester.Do(ctx, method, route, request, response)
+
+	return err
+}
+
+// isTransientManagementError returns true if the management call might succeed if it is retried.
+func isTransientManagementError(err error) bool {
+	if ierrors.Is(err, nodeclient.ErrHTTPServiceUnavailable) {
+		return true
+	}
+
+	return isTransientSubmitError(err)
+}
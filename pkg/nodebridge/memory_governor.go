@@ -0,0 +1,265 @@
+package nodebridge
+
+import (
+	"context"
+	"os"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// DefaultMemoryGovernorInterval is the default interval in which the MemoryGovernor samples the memory usage.
+	DefaultMemoryGovernorInterval = time.Second
+	// DefaultMemoryGovernorRecoveryRatio is the default ratio of the thresholds below which the shedding is stopped.
+	DefaultMemoryGovernorRecoveryRatio = 0.8
+
+	// heapObjectsMetric is the runtime metric of the memory occupied by live and not yet freed heap objects.
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+)
+
+// ErrLedgerUpdatesShed is returned by the consumers wrapped via LedgerUpdateConsumer while the memory usage is too high.
+// Ledger updates can't be skipped without corrupting the ledger state of the extension, so the stream is ended instead
+// and the ledger state needs to be resynced, e.g. via SyncLedger, after the SheddingStopped event.
+var ErrLedgerUpdatesShed = ierrors.New("ledger updates shed under memory pressure, the ledger state needs to be resynced")
+
+// SheddingMode defines how the block streams of the MemoryGovernor degrade while the memory usage is above the thresholds.
+type SheddingMode int
+
+const (
+	// SheddingModeSkip drops the items of the streams, the dropped items are reported via the StreamItemsDropped event.
+	SheddingModeSkip SheddingMode = iota
+	// SheddingModeMetadataOnly passes the blocks without their payload, i.e. they only contain the header
+	// and the signature and are passed without raw data.
+	SheddingModeMetadataOnly
+)
+
+// MemoryUsage is a sample of the memory usage of the process.
+type MemoryUsage struct {
+	// HeapBytes is the memory occupied by heap objects in bytes.
+	HeapBytes uint64
+	// RSSBytes is the resident set size of the process in bytes, it is 0 if it is not supported on the platform.
+	RSSBytes uint64
+}
+
+// MemoryGovernorEvents are the events of the MemoryGovernor.
+type MemoryGovernorEvents struct {
+	// SheddingStarted is triggered with the memory usage if the memory usage exceeded a threshold.
+	SheddingStarted *event.Event1[*MemoryUsage]
+	// SheddingStopped is triggered with the memory usage if the memory usage dropped below the recovery thresholds.
+	SheddingStopped *event.Event1[*MemoryUsage]
+}
+
+// MemoryGovernor monitors the memory usage of the process and switches the block streams that are wrapped
+// via BlockConsumer into shedding mode while the memory usage is above the thresholds,
+// so extensions degrade gracefully instead of running out of memory.
+// Ledger update streams that are wrapped via LedgerUpdateConsumer are ended instead, because they can't be shed.
+type MemoryGovernor struct {
+	log.Logger
+
+	Events *MemoryGovernorEvents
+
+	nodeBridge    NodeBridge
+	interval      time.Duration
+	heapThreshold uint64
+	rssThreshold  uint64
+	recoveryRatio float64
+	sheddingMode  SheddingMode
+
+	shedding atomic.Bool
+
+	droppedItemsMutex sync.Mutex
+	droppedItems      map[string]uint64
+}
+
+// WithMemoryGovernorHeapThreshold sets the heap size in bytes above which the streams are shed (0 to disable).
+func WithMemoryGovernorHeapThreshold(threshold uint64) options.Option[MemoryGovernor] {
+	return func(g *MemoryGovernor) {
+		g.heapThreshold = threshold
+	}
+}
+
+// WithMemoryGovernorRSSThreshold sets the resident set size in bytes above which the streams are shed (0 to disable).
+// The resident set size is only available on Linux.
+func WithMemoryGovernorRSSThreshold(threshold uint64) options.Option[MemoryGovernor] {
+	return func(g *MemoryGovernor) {
+		g.rssThreshold = threshold
+	}
+}
+
+// WithMemoryGovernorRecoveryRatio sets the ratio of the thresholds the memory usage needs to drop below
+// to stop the shedding, which prevents the streams from flapping between the modes.
+func WithMemoryGovernorRecoveryRatio(ratio float64) options.Option[MemoryGovernor] {
+	return func(g *MemoryGovernor) {
+		g.recoveryRatio = ratio
+	}
+}
+
+// WithMemoryGovernorInterval sets the interval in which the memory usage is sampled.
+func WithMemoryGovernorInterval(interval time.Duration) options.Option[MemoryGovernor] {
+	return func(g *MemoryGovernor) {
+		g.interval = interval
+	}
+}
+
+// WithSheddingMode sets how the block streams degrade while the memory usage is above the thresholds.
+func WithSheddingMode(mode SheddingMode) options.Option[MemoryGovernor] {
+	return func(g *MemoryGovernor) {
+		g.sheddingMode = mode
+	}
+}
+
+// NewMemoryGovernor creates a new MemoryGovernor.
+// The dropped items of the streams are reported via the StreamItemsDropped event of the NodeBridge.
+func NewMemoryGovernor(logger log.Logger, nodeBridge NodeBridge, opts ...options.Option[MemoryGovernor]) *MemoryGovernor {
+	return options.Apply(&MemoryGovernor{
+		Logger: logger,
+		Events: &MemoryGovernorEvents{
+			SheddingStarted: event.New1[*MemoryUsage](),
+			SheddingStopped: event.New1[*MemoryUsage](),
+		},
+		nodeBridge:    nodeBridge,
+		interval:      DefaultMemoryGovernorInterval,
+		recoveryRatio: DefaultMemoryGovernorRecoveryRatio,
+		sheddingMode:  SheddingModeSkip,
+		droppedItems:  make(map[string]uint64),
+	}, opts)
+}
+
+// Run samples the memory usage in the configured interval until the context is canceled.
+func (g *MemoryGovernor) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.update(readMemoryUsage())
+		}
+	}
+}
+
+// update switches the shedding on or off based on the given memory usage.
+func (g *MemoryGovernor) update(usage *MemoryUsage) {
+	if !g.shedding.Load() {
+		if !g.exceeds(usage, 1) {
+			return
+		}
+
+		g.shedding.Store(true)
+		g.LogWarnf("memory usage above threshold (heap: %d bytes, rss: %d bytes), shedding streams ...", usage.HeapBytes, usage.RSSBytes)
+		g.Events.SheddingStarted.Trigger(usage)
+
+		return
+	}
+
+	if g.exceeds(usage, g.recoveryRatio) {
+		return
+	}
+
+	g.shedding.Store(false)
+	g.LogInfof("memory usage recovered (heap: %d bytes, rss: %d bytes), stopped shedding streams", usage.HeapBytes, usage.RSSBytes)
+	g.Events.SheddingStopped.Trigger(usage)
+}
+
+// exceeds returns true if the memory usage exceeds one of the thresholds multiplied by the given ratio.
+func (g *MemoryGovernor) exceeds(usage *MemoryUsage, ratio float64) bool {
+	if g.heapThreshold != 0 && float64(usage.HeapBytes) > float64(g.heapThreshold)*ratio {
+		return true
+	}
+
+	return g.rssThreshold != 0 && usage.RSSBytes != 0 && float64(usage.RSSBytes) > float64(g.rssThreshold)*ratio
+}
+
+// IsShedding returns true if the memory usage is above the thresholds and the streams are shed.
+func (g *MemoryGovernor) IsShedding() bool {
+	return g.shedding.Load()
+}
+
+// DroppedItems returns the amount of items that were dropped by the stream with the given name.
+func (g *MemoryGovernor) DroppedItems(name string) uint64 {
+	g.droppedItemsMutex.Lock()
+	defer g.droppedItemsMutex.Unlock()
+
+	return g.droppedItems[name]
+}
+
+// drop counts a dropped item of the stream with the given name and triggers the StreamItemsDropped event.
+func (g *MemoryGovernor) drop(name string) {
+	g.droppedItemsMutex.Lock()
+	g.droppedItems[name]++
+	dropped := g.droppedItems[name]
+	g.droppedItemsMutex.Unlock()
+
+	g.nodeBridge.Events().StreamItemsDropped.Trigger(name, dropped)
+}
+
+// BlockConsumer wraps the consumer of a block stream, e.g. of ListenToBlocks, so it is shed while the memory usage is too high.
+// The name of the stream is used to report the dropped items.
+func (g *MemoryGovernor) BlockConsumer(name string, consumer func(block *iotago.Block, rawData []byte) error) func(block *iotago.Block, rawData []byte) error {
+	return func(block *iotago.Block, rawData []byte) error {
+		if !g.shedding.Load() {
+			return consumer(block, rawData)
+		}
+
+		if g.sheddingMode == SheddingModeMetadataOnly {
+			return consumer(&iotago.Block{
+				API:       block.API,
+				Header:    block.Header,
+				Signature: block.Signature,
+			}, nil)
+		}
+
+		g.drop(name)
+
+		return nil
+	}
+}
+
+// LedgerUpdateConsumer wraps the consumer of a ledger update stream, e.g. of ListenToLedgerUpdates,
+// so the stream is ended with ErrLedgerUpdatesShed while the memory usage is too high.
+// The name of the stream is used to report the dropped ledger update.
+func (g *MemoryGovernor) LedgerUpdateConsumer(name string, consumer func(update *LedgerUpdate) error) func(update *LedgerUpdate) error {
+	return func(update *LedgerUpdate) error {
+		if !g.shedding.Load() {
+			return consumer(update)
+		}
+
+		g.drop(name)
+
+		return ierrors.Wrapf(ErrLedgerUpdatesShed, "stream \"%s\" at slot %d", name, update.CommitmentID.Slot())
+	}
+}
+
+// readMemoryUsage samples the heap size via the runtime metrics and the resident set size via procfs.
+func readMemoryUsage() *MemoryUsage {
+	samples := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(samples)
+
+	usage := &MemoryUsage{}
+	if samples[0].Value.Kind() == metrics.KindUint64 {
+		usage.HeapBytes = samples[0].Value.Uint64()
+	}
+
+	// the second field of statm is the resident set size in pages
+	if statm, err := os.ReadFile("/proc/self/statm"); err == nil {
+		if fields := strings.Fields(string(statm)); len(fields) > 1 {
+			if residentPages, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+				usage.RSSBytes = residentPages * uint64(os.Getpagesize())
+			}
+		}
+	}
+
+	return usage
+}
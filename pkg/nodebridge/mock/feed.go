@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"context"
+	"sync"
+)
+
+// feed is an append-only list of items that can be followed by multiple listeners.
+// Listeners receive all items that were added before they subscribed, followed by all new items.
+type feed[T any] struct {
+	mutex  sync.RWMutex
+	items  []T
+	notify chan struct{}
+}
+
+func newFeed[T any]() *feed[T] {
+	return &feed[T]{
+		notify: make(chan struct{}),
+	}
+}
+
+// add appends the given item and wakes up all listeners.
+func (f *feed[T]) add(item T) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.items = append(f.items, item)
+
+	close(f.notify)
+	f.notify = make(chan struct{})
+}
+
+// since returns all items starting at the given index and a channel that is closed if new items are added.
+func (f *feed[T]) since(index int) ([]T, <-chan struct{}) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.items[index:], f.notify
+}
+
+// listen passes all items of the feed to the consumer until the context is canceled.
+// If the consumer returns done, listen stops without an error.
+func (f *feed[T]) listen(ctx context.Context, consumer func(item T) (done bool, err error)) error {
+	var index int
+	for {
+		items, notify := f.since(index)
+		for _, item := range items {
+			index++
+
+			done, err := consumer(item)
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-notify:
+		}
+	}
+}
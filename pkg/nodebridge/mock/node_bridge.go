@@ -0,0 +1,1354 @@
+// Package mock provides an in-memory implementation of the NodeBridge that can be used
+// to test INX extensions without a running node.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/merklehasher"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+// ValidatorsPageSize is the page size of ReadValidators.
+const ValidatorsPageSize = 50
+
+// ErrNotSupported is returned by methods that need a gRPC connection to a node.
+var ErrNotSupported = ierrors.New("not supported by the mock node bridge")
+
+var _ nodebridge.NodeBridge = &NodeBridge{}
+
+// NodeBridge is an in-memory implementation of the nodebridge.NodeBridge.
+// All data is configured via the setters, streams replay all previously added items and follow new ones.
+type NodeBridge struct {
+	mutex sync.RWMutex
+
+	events      *nodebridge.Events
+	apiProvider iotago.APIProvider
+	nodeConfig  *inx.NodeConfiguration
+
+	streamEvents             *nodebridge.StreamEvents
+	streamEventSubscriptions nodebridge.StreamEventSubscriptions
+	streamStats              []*nodebridge.StreamStats
+
+	nodeStatus                *inx.NodeStatus
+	latestCommitment          *nodebridge.Commitment
+	latestFinalizedCommitment *nodebridge.Commitment
+
+	blocks              map[iotago.BlockID]*iotago.Block
+	blockMetadata       map[iotago.BlockID]*api.BlockMetadataResponse
+	activeRootBlocks    map[iotago.BlockID]iotago.CommitmentID
+	transactionMetadata map[iotago.TransactionID]*api.TransactionMetadataResponse
+	outputs             map[iotago.OutputID]*nodebridge.Output
+	commitments         map[iotago.SlotIndex]*nodebridge.Commitment
+	commitmentRoots     map[iotago.CommitmentID]*iotago.Roots
+	tangleProofs        map[iotago.BlockID]*merklehasher.Proof[iotago.BlockID]
+	ledgerUpdates       map[iotago.SlotIndex]*nodebridge.LedgerUpdate
+	candidates          map[iotago.AccountID]bool
+	committeeMembers    map[iotago.AccountID]bool
+	validatorAccounts   map[iotago.AccountID]bool
+	committees          map[iotago.EpochIndex]*api.CommitteeResponse
+	validators          map[iotago.EpochIndex][]*api.ValidatorResponse
+	stakingInfos        map[iotago.AccountID]*api.ValidatorResponse
+	rewards             map[rewardsKey]*api.ManaRewardsResponse
+	congestion          map[iotago.AccountID]*api.CongestionResponse
+	apiRoutes           map[string]string
+	forcedCommitSlot    iotago.SlotIndex
+
+	strongTips      iotago.BlockIDs
+	weakTips        iotago.BlockIDs
+	shallowLikeTips iotago.BlockIDs
+
+	blockFeed               *feed[*iotago.Block]
+	acceptedBlockFeed       *feed[*api.BlockMetadataResponse]
+	confirmedBlockFeed      *feed[*api.BlockMetadataResponse]
+	finalizedBlockFeed      *feed[*api.BlockMetadataResponse]
+	commitmentFeed          *feed[*nodebridge.Commitment]
+	ledgerUpdateFeed        *feed[*nodebridge.LedgerUpdate]
+	acceptedTransactionFeed *feed[*nodebridge.AcceptedTransaction]
+	nodeStatusFeed          *feed[*inx.NodeStatus]
+	protocolParametersFeed  *feed[*nodebridge.ProtocolParametersUpdate]
+}
+
+// New creates a new mock NodeBridge that uses the given APIProvider.
+func New(apiProvider iotago.APIProvider) *NodeBridge {
+	return &NodeBridge{
+		events: &nodebridge.Events{
+			LatestCommitmentChanged:          event.New1[*nodebridge.Commitment](),
+			LatestFinalizedCommitmentChanged: event.New1[*nodebridge.Commitment](),
+			ConnectionStateChanged:           event.New1[connectivity.State](),
+			NodeHealthChanged:                event.New1[bool](),
+			NodeSyncedChanged:                event.New1[bool](),
+			PruningEpochChanged:              event.New1[iotago.EpochIndex](),
+			ProtocolParametersAnnounced:      event.New1[*nodebridge.ProtocolParametersUpdate](),
+			StreamStale:                      event.New2[string, time.Duration](),
+			StreamItemsDropped:               event.New2[string, uint64](),
+			APIRouteReregistered:             event.New2[string, error](),
+			CommitmentGapDetected:            event.New2[iotago.SlotIndex, iotago.SlotIndex](),
+			CommitmentChainBroken:            event.New1[*nodebridge.CommitmentChainError](),
+		},
+		streamEvents:            nodebridge.NewStreamEvents(),
+		apiProvider:             apiProvider,
+		nodeConfig:              &inx.NodeConfiguration{},
+		nodeStatus:              &inx.NodeStatus{},
+		blocks:                  make(map[iotago.BlockID]*iotago.Block),
+		blockMetadata:           make(map[iotago.BlockID]*api.BlockMetadataResponse),
+		activeRootBlocks:        make(map[iotago.BlockID]iotago.CommitmentID),
+		transactionMetadata:     make(map[iotago.TransactionID]*api.TransactionMetadataResponse),
+		outputs:                 make(map[iotago.OutputID]*nodebridge.Output),
+		commitments:             make(map[iotago.SlotIndex]*nodebridge.Commitment),
+		commitmentRoots:         make(map[iotago.CommitmentID]*iotago.Roots),
+		tangleProofs:            make(map[iotago.BlockID]*merklehasher.Proof[iotago.BlockID]),
+		ledgerUpdates:           make(map[iotago.SlotIndex]*nodebridge.LedgerUpdate),
+		candidates:              make(map[iotago.AccountID]bool),
+		committeeMembers:        make(map[iotago.AccountID]bool),
+		validatorAccounts:       make(map[iotago.AccountID]bool),
+		committees:              make(map[iotago.EpochIndex]*api.CommitteeResponse),
+		validators:              make(map[iotago.EpochIndex][]*api.ValidatorResponse),
+		stakingInfos:            make(map[iotago.AccountID]*api.ValidatorResponse),
+		rewards:                 make(map[rewardsKey]*api.ManaRewardsResponse),
+		congestion:              make(map[iotago.AccountID]*api.CongestionResponse),
+		apiRoutes:               make(map[string]string),
+		blockFeed:               newFeed[*iotago.Block](),
+		acceptedBlockFeed:       newFeed[*api.BlockMetadataResponse](),
+		confirmedBlockFeed:      newFeed[*api.BlockMetadataResponse](),
+		finalizedBlockFeed:      newFeed[*api.BlockMetadataResponse](),
+		commitmentFeed:          newFeed[*nodebridge.Commitment](),
+		ledgerUpdateFeed:        newFeed[*nodebridge.LedgerUpdate](),
+		acceptedTransactionFeed: newFeed[*nodebridge.AcceptedTransaction](),
+		nodeStatusFeed:          newFeed[*inx.NodeStatus](),
+		protocolParametersFeed:  newFeed[*nodebridge.ProtocolParametersUpdate](),
+	}
+}
+
+// Events returns the events.
+func (m *NodeBridge) Events() *nodebridge.Events {
+	return m.events
+}
+
+// StreamEvents returns the events that are triggered for the items of the subscribed streams.
+func (m *NodeBridge) StreamEvents() *nodebridge.StreamEvents {
+	return m.streamEvents
+}
+
+// SetStreamEventSubscriptions sets the streams whose items trigger the StreamEvents, it needs to be called before Run.
+func (m *NodeBridge) SetStreamEventSubscriptions(subscriptions nodebridge.StreamEventSubscriptions) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.streamEventSubscriptions = subscriptions
+}
+
+// Stats returns the stream stats that were set via SetStats, the mock does not track its streams.
+func (m *NodeBridge) Stats() []*nodebridge.StreamStats {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.streamStats
+}
+
+// SetStats sets the stream stats that are returned by Stats.
+func (m *NodeBridge) SetStats(stats []*nodebridge.StreamStats) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.streamStats = stats
+}
+
+// Connect does nothing, the mock is always connected.
+func (m *NodeBridge) Connect(_ context.Context, _ string, _ uint) error {
+	return nil
+}
+
+// Run blocks until the context is canceled.
+// The StreamEvents of the subscribed streams are triggered while the mock is running, see SetStreamEventSubscriptions.
+func (m *NodeBridge) Run(ctx context.Context) {
+	m.mutex.RLock()
+	subscriptions := m.streamEventSubscriptions
+	m.mutex.RUnlock()
+
+	if subscriptions != 0 {
+		_ = nodebridge.RunStreamEvents(ctx, m, m.streamEvents, subscriptions)
+	}
+
+	<-ctx.Done()
+}
+
+// Client returns nil, the mock has no gRPC connection.
+func (m *NodeBridge) Client() inx.INXClient {
+	return nil
+}
+
+// NodeConfig returns the NodeConfiguration.
+func (m *NodeBridge) NodeConfig() *inx.NodeConfiguration {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.nodeConfig
+}
+
+// SetNodeConfig sets the NodeConfiguration.
+func (m *NodeBridge) SetNodeConfig(nodeConfig *inx.NodeConfiguration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.nodeConfig = nodeConfig
+}
+
+// APIProvider returns the APIProvider.
+func (m *NodeBridge) APIProvider() iotago.APIProvider {
+	return m.apiProvider
+}
+
+// ProtocolParametersHistory returns all protocol parameters of the NodeConfiguration, ordered by their start epoch.
+func (m *NodeBridge) ProtocolParametersHistory() ([]*nodebridge.ProtocolParametersUpdate, error) {
+	return nodebridge.ProtocolParametersHistory(m.NodeConfig())
+}
+
+// ProtocolParametersForEpoch returns the protocol parameters of the APIProvider for the given epoch.
+func (m *NodeBridge) ProtocolParametersForEpoch(epoch iotago.EpochIndex) (iotago.ProtocolParameters, error) {
+	return m.apiProvider.APIForEpoch(epoch).ProtocolParameters(), nil
+}
+
+// ListenToProtocolParameterUpdates passes the protocol parameters announced via AnnounceProtocolParameters to the consumer.
+// The poll interval is ignored.
+func (m *NodeBridge) ListenToProtocolParameterUpdates(ctx context.Context, _ time.Duration, consumer func(update *nodebridge.ProtocolParametersUpdate) error) error {
+	return m.protocolParametersFeed.listen(ctx, func(update *nodebridge.ProtocolParametersUpdate) (bool, error) {
+		return false, consumer(update)
+	})
+}
+
+// AnnounceProtocolParameters adds the protocol parameters that become active at the given epoch to the NodeConfiguration
+// and to the APIProvider if it is an EpochBasedProvider, passes them to the ListenToProtocolParameterUpdates listeners
+// and triggers the ProtocolParametersAnnounced event.
+func (m *NodeBridge) AnnounceProtocolParameters(startEpoch iotago.EpochIndex, protocolParameters iotago.ProtocolParameters) error {
+	rawParams, err := inx.WrapProtocolParameters(startEpoch, protocolParameters)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	m.nodeConfig = &inx.NodeConfiguration{
+		BaseToken:          m.nodeConfig.GetBaseToken(),
+		ProtocolParameters: append(append([]*inx.RawProtocolParameters{}, m.nodeConfig.GetProtocolParameters()...), rawParams),
+	}
+	m.mutex.Unlock()
+
+	if provider, isEpochBased := m.apiProvider.(*iotago.EpochBasedProvider); isEpochBased {
+		provider.AddProtocolParametersAtEpoch(protocolParameters, startEpoch)
+	}
+
+	update := &nodebridge.ProtocolParametersUpdate{
+		StartEpoch:         startEpoch,
+		ProtocolParameters: protocolParameters,
+	}
+	m.protocolParametersFeed.add(update)
+	m.events.ProtocolParametersAnnounced.Trigger(update)
+
+	return nil
+}
+
+// INXNodeClient returns ErrNotSupported.
+func (m *NodeBridge) INXNodeClient() (*nodeclient.Client, error) {
+	return nil, ErrNotSupported
+}
+
+// Management returns ErrManagementPluginNotAvailable.
+func (m *NodeBridge) Management(_ context.Context) (nodeclient.ManagementClient, error) {
+	return nil, nodeclient.ErrManagementPluginNotAvailable
+}
+
+// Indexer returns ErrIndexerPluginNotAvailable.
+func (m *NodeBridge) Indexer(_ context.Context) (nodeclient.IndexerClient, error) {
+	return nil, nodeclient.ErrIndexerPluginNotAvailable
+}
+
+// EventAPI returns ErrMQTTPluginNotAvailable.
+func (m *NodeBridge) EventAPI(_ context.Context) (*nodeclient.EventAPIClient, error) {
+	return nil, nodeclient.ErrMQTTPluginNotAvailable
+}
+
+// BlockIssuer returns ErrBlockIssuerPluginNotAvailable.
+func (m *NodeBridge) BlockIssuer(_ context.Context) (nodeclient.BlockIssuerClient, error) {
+	return nil, nodeclient.ErrBlockIssuerPluginNotAvailable
+}
+
+// ReadIsCandidate returns true if the given account was set as a candidate.
+func (m *NodeBridge) ReadIsCandidate(_ context.Context, id iotago.AccountID, _ iotago.SlotIndex) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.candidates[id], nil
+}
+
+// SetCandidate sets whether the given account is a candidate.
+func (m *NodeBridge) SetCandidate(id iotago.AccountID, isCandidate bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.candidates[id] = isCandidate
+}
+
+// ReadIsCommitteeMember returns true if the given account was set as a committee member.
+func (m *NodeBridge) ReadIsCommitteeMember(_ context.Context, id iotago.AccountID, _ iotago.SlotIndex) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.committeeMembers[id], nil
+}
+
+// SetCommitteeMember sets whether the given account is a committee member.
+func (m *NodeBridge) SetCommitteeMember(id iotago.AccountID, isCommitteeMember bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.committeeMembers[id] = isCommitteeMember
+}
+
+// ReadIsValidatorAccount returns true if the given account was set as a validator account.
+func (m *NodeBridge) ReadIsValidatorAccount(_ context.Context, id iotago.AccountID, _ iotago.SlotIndex) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.validatorAccounts[id], nil
+}
+
+// SetValidatorAccount sets whether the given account is a validator account.
+func (m *NodeBridge) SetValidatorAccount(id iotago.AccountID, isValidatorAccount bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.validatorAccounts[id] = isValidatorAccount
+}
+
+// ReadCommittee returns the committee that was set for the given epoch.
+func (m *NodeBridge) ReadCommittee(_ context.Context, epoch iotago.EpochIndex) (*api.CommitteeResponse, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	committee, exists := m.committees[epoch]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "committee for epoch %d not found", epoch)
+	}
+
+	return committee, nil
+}
+
+// SetCommittee sets the committee of the given epoch.
+func (m *NodeBridge) SetCommittee(epoch iotago.EpochIndex, committee *api.CommitteeResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.committees[epoch] = committee
+}
+
+// ReadValidators returns a page of the validators that were set for the given epoch.
+// The pages contain at most ValidatorsPageSize validators.
+func (m *NodeBridge) ReadValidators(_ context.Context, epoch iotago.EpochIndex, cursor string) (*api.ValidatorsResponse, error) {
+	var index uint32
+	if cursor != "" {
+		var cursorEpoch iotago.EpochIndex
+		if _, err := fmt.Sscanf(cursor, "%d,%d", &cursorEpoch, &index); err != nil || cursorEpoch != epoch {
+			return nil, ierrors.Errorf("invalid cursor \"%s\"", cursor)
+		}
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	validators := m.validators[epoch]
+	start := min(int(index), len(validators))
+	end := min(start+ValidatorsPageSize, len(validators))
+
+	response := &api.ValidatorsResponse{
+		Validators: validators[start:end],
+		PageSize:   ValidatorsPageSize,
+	}
+	if end < len(validators) {
+		response.Cursor = nodebridge.ValidatorsCursor(epoch, uint32(end))
+	}
+
+	return response, nil
+}
+
+// ReadAllValidators returns all validators that were set for the given epoch.
+func (m *NodeBridge) ReadAllValidators(ctx context.Context, epoch iotago.EpochIndex) ([]*api.ValidatorResponse, error) {
+	return nodebridge.ReadAllValidatorPages(ctx, epoch, m.ReadValidators)
+}
+
+// SetValidators sets the validators of the given epoch.
+func (m *NodeBridge) SetValidators(epoch iotago.EpochIndex, validators []*api.ValidatorResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.validators[epoch] = validators
+}
+
+// StakingInfo returns the staking data that was set for the given account.
+func (m *NodeBridge) StakingInfo(_ context.Context, accountID iotago.AccountID) (*api.ValidatorResponse, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	stakingInfo, exists := m.stakingInfos[accountID]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "staking info of account %s not found", accountID)
+	}
+
+	return stakingInfo, nil
+}
+
+// SetStakingInfo sets the staking data of the given account.
+func (m *NodeBridge) SetStakingInfo(accountID iotago.AccountID, stakingInfo *api.ValidatorResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.stakingInfos[accountID] = stakingInfo
+}
+
+// rewardsKey is the key of the rewards of an output claimed in an epoch.
+type rewardsKey struct {
+	outputID   iotago.OutputID
+	claimEpoch iotago.EpochIndex
+}
+
+// RewardsForOutput returns the rewards that were set for the given output and claim epoch.
+func (m *NodeBridge) RewardsForOutput(_ context.Context, outputID iotago.OutputID, claimEpoch iotago.EpochIndex) (*api.ManaRewardsResponse, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	rewards, exists := m.rewards[rewardsKey{outputID: outputID, claimEpoch: claimEpoch}]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "rewards of output %s in epoch %d not found", outputID, claimEpoch)
+	}
+
+	return rewards, nil
+}
+
+// SetRewardsForOutput sets the rewards of the given output claimed in the given epoch.
+func (m *NodeBridge) SetRewardsForOutput(outputID iotago.OutputID, claimEpoch iotago.EpochIndex, rewards *api.ManaRewardsResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.rewards[rewardsKey{outputID: outputID, claimEpoch: claimEpoch}] = rewards
+}
+
+// Congestion returns the congestion that was set for the given account.
+func (m *NodeBridge) Congestion(_ context.Context, accountID iotago.AccountID) (*api.CongestionResponse, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	congestion, exists := m.congestion[accountID]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "congestion of account %s not found", accountID)
+	}
+
+	return congestion, nil
+}
+
+// ListenToCongestion passes the congestion of the given account to the consumer for every new commitment set via SetLatestCommitments.
+func (m *NodeBridge) ListenToCongestion(ctx context.Context, accountID iotago.AccountID, consumer func(congestion *api.CongestionResponse) error) error {
+	return nodebridge.ListenToCongestionPerCommitment(ctx, m, accountID, consumer)
+}
+
+// SetCongestion sets the congestion of the given account.
+func (m *NodeBridge) SetCongestion(accountID iotago.AccountID, congestion *api.CongestionResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.congestion[accountID] = congestion
+}
+
+// RegisterAPIRoute registers the given API route.
+func (m *NodeBridge) RegisterAPIRoute(_ context.Context, route string, address string, path string) error {
+	if _, err := nodebridge.ParseAPIRouteAddress(address); err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.apiRoutes[route] = address + path
+
+	return nil
+}
+
+// UnregisterAPIRoute unregisters the given API route.
+func (m *NodeBridge) UnregisterAPIRoute(_ context.Context, route string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.apiRoutes, route)
+
+	return nil
+}
+
+// APIRoutes returns the registered API routes and the address they are proxied to.
+func (m *NodeBridge) APIRoutes() map[string]string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	apiRoutes := make(map[string]string, len(m.apiRoutes))
+	for route, target := range m.apiRoutes {
+		apiRoutes[route] = target
+	}
+
+	return apiRoutes
+}
+
+// ActiveRootBlocks returns the active root blocks.
+func (m *NodeBridge) ActiveRootBlocks(_ context.Context) (map[iotago.BlockID]iotago.CommitmentID, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	activeRootBlocks := make(map[iotago.BlockID]iotago.CommitmentID, len(m.activeRootBlocks))
+	for blockID, commitmentID := range m.activeRootBlocks {
+		activeRootBlocks[blockID] = commitmentID
+	}
+
+	return activeRootBlocks, nil
+}
+
+// SetActiveRootBlocks sets the active root blocks.
+func (m *NodeBridge) SetActiveRootBlocks(activeRootBlocks map[iotago.BlockID]iotago.CommitmentID) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.activeRootBlocks = activeRootBlocks
+}
+
+// SubmitBlock adds the given block.
+func (m *NodeBridge) SubmitBlock(_ context.Context, block *iotago.Block) (iotago.BlockID, error) {
+	return m.AddBlock(block)
+}
+
+// AddBlock adds the given block and passes it to the ListenToBlocks listeners.
+func (m *NodeBridge) AddBlock(block *iotago.Block) (iotago.BlockID, error) {
+	blockID, err := block.ID()
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	m.mutex.Lock()
+	m.blocks[blockID] = block
+	m.mutex.Unlock()
+
+	m.blockFeed.add(block)
+
+	return blockID, nil
+}
+
+// Block returns the block for the given block ID.
+func (m *NodeBridge) Block(_ context.Context, blockID iotago.BlockID) (*iotago.Block, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	block, exists := m.blocks[blockID]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "block %s", blockID)
+	}
+
+	return block, nil
+}
+
+// BlockWithRaw returns the block for the given block ID and its serialized bytes.
+func (m *NodeBridge) BlockWithRaw(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, []byte, error) {
+	block, err := m.Block(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawData, err := m.apiProvider.APIForSlot(blockID.Slot()).Encode(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return block, rawData, nil
+}
+
+// BlockRaw returns the serialized bytes of the block for the given block ID.
+func (m *NodeBridge) BlockRaw(ctx context.Context, blockID iotago.BlockID) ([]byte, error) {
+	_, rawData, err := m.BlockWithRaw(ctx, blockID)
+
+	return rawData, err
+}
+
+// BlockMetadata returns the block metadata for the given block ID.
+func (m *NodeBridge) BlockMetadata(_ context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	blockMetadata, exists := m.blockMetadata[blockID]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "block metadata %s", blockID)
+	}
+
+	return blockMetadata, nil
+}
+
+// SetBlockMetadata sets the metadata of a block.
+func (m *NodeBridge) SetBlockMetadata(blockMetadata *api.BlockMetadataResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.blockMetadata[blockMetadata.BlockID] = blockMetadata
+}
+
+// AddAcceptedBlock sets the metadata of a block and passes it to the ListenToAcceptedBlocks listeners.
+func (m *NodeBridge) AddAcceptedBlock(blockMetadata *api.BlockMetadataResponse) {
+	m.SetBlockMetadata(blockMetadata)
+	m.acceptedBlockFeed.add(blockMetadata)
+}
+
+// AddConfirmedBlock sets the metadata of a block and passes it to the ListenToConfirmedBlocks listeners.
+func (m *NodeBridge) AddConfirmedBlock(blockMetadata *api.BlockMetadataResponse) {
+	m.SetBlockMetadata(blockMetadata)
+	m.confirmedBlockFeed.add(blockMetadata)
+}
+
+// AddFinalizedBlock sets the metadata of a block and passes it to the ListenToFinalizedBlocks listeners.
+func (m *NodeBridge) AddFinalizedBlock(blockMetadata *api.BlockMetadataResponse) {
+	m.SetBlockMetadata(blockMetadata)
+	m.finalizedBlockFeed.add(blockMetadata)
+}
+
+// ListenToBlocks listens to blocks.
+func (m *NodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return m.blockFeed.listen(ctx, func(block *iotago.Block) (bool, error) {
+		rawData, err := block.API.Encode(block)
+		if err != nil {
+			return false, err
+		}
+
+		return false, consumer(block, rawData)
+	})
+}
+
+// ListenToBlocksFiltered listens to the blocks that match the given filter.
+func (m *NodeBridge) ListenToBlocksFiltered(ctx context.Context, filter *nodebridge.BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return m.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+		if !filter.Matches(block) {
+			return nil
+		}
+
+		return consumer(block, rawData)
+	})
+}
+
+// ListenToBlocksBuffered listens to blocks and passes them to the consumer via a buffer of the given size.
+func (m *NodeBridge) ListenToBlocksBuffered(ctx context.Context, bufferSize int, dropPolicy nodebridge.BufferDropPolicy, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return nodebridge.ListenToBlocksBuffered(ctx, m, bufferSize, dropPolicy, consumer)
+}
+
+// ListenToAcceptedBlocks listens to accepted blocks.
+func (m *NodeBridge) ListenToAcceptedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	return m.acceptedBlockFeed.listen(ctx, func(blockMetadata *api.BlockMetadataResponse) (bool, error) {
+		return false, consumer(blockMetadata)
+	})
+}
+
+// ListenToConfirmedBlocks listens to confirmed blocks.
+func (m *NodeBridge) ListenToConfirmedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	return m.confirmedBlockFeed.listen(ctx, func(blockMetadata *api.BlockMetadataResponse) (bool, error) {
+		return false, consumer(blockMetadata)
+	})
+}
+
+// ListenToFinalizedBlocks listens to finalized blocks.
+func (m *NodeBridge) ListenToFinalizedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	return m.finalizedBlockFeed.listen(ctx, func(blockMetadata *api.BlockMetadataResponse) (bool, error) {
+		return false, consumer(blockMetadata)
+	})
+}
+
+// TransactionMetadata returns the transaction metadata for the given transaction ID.
+func (m *NodeBridge) TransactionMetadata(_ context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	transactionMetadata, exists := m.transactionMetadata[transactionID]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "transaction metadata %s", transactionID)
+	}
+
+	return transactionMetadata, nil
+}
+
+// TransactionMetadataBatch returns the transaction metadata for the given transaction IDs.
+// Unknown transactions are reported in the returned TransactionMetadataBatchError.
+func (m *NodeBridge) TransactionMetadataBatch(ctx context.Context, transactionIDs []iotago.TransactionID) (map[iotago.TransactionID]*api.TransactionMetadataResponse, error) {
+	return nodebridge.ReadTransactionMetadataBatch(ctx, transactionIDs, nodebridge.DefaultTransactionMetadataConcurrency, m.TransactionMetadata)
+}
+
+// SetTransactionMetadata sets the metadata of a transaction.
+func (m *NodeBridge) SetTransactionMetadata(transactionMetadata *api.TransactionMetadataResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.transactionMetadata[transactionMetadata.TransactionID] = transactionMetadata
+}
+
+// TransactionOutputs returns the known outputs created by the transaction with the given transaction ID
+// and the known outputs consumed by it, ordered by output ID.
+func (m *NodeBridge) TransactionOutputs(_ context.Context, transactionID iotago.TransactionID) (*nodebridge.TransactionOutputs, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var created, consumed []*nodebridge.Output
+	for outputID, output := range m.outputs {
+		if outputID.TransactionID() == transactionID {
+			created = append(created, output)
+		}
+
+		if spent := output.Metadata.Spent; spent != nil && spent.TransactionID == transactionID {
+			consumed = append(consumed, output)
+		}
+	}
+
+	if len(created) == 0 {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "transaction %s", transactionID)
+	}
+
+	sortOutputs(created)
+	sortOutputs(consumed)
+
+	return &nodebridge.TransactionOutputs{
+		TransactionID: transactionID,
+		Created:       created,
+		Consumed:      consumed,
+	}, nil
+}
+
+// InclusionProof returns the inclusion proof of the transaction with the given transaction ID,
+// built from the known outputs, blocks and commitments.
+func (m *NodeBridge) InclusionProof(ctx context.Context, transactionID iotago.TransactionID) (*nodebridge.InclusionProof, error) {
+	transactionOutputs, err := m.TransactionOutputs(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockID := transactionOutputs.Created[0].Metadata.BlockID
+	if latestCommitment := m.LatestCommitment(); latestCommitment == nil || latestCommitment.CommitmentID.Slot() < blockID.Slot() {
+		return nil, ierrors.Wrapf(nodebridge.ErrTransactionNotCommitted, "slot %d of block %s", blockID.Slot(), blockID)
+	}
+
+	block, err := m.Block(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := m.Commitment(ctx, blockID.Slot())
+	if err != nil {
+		return nil, err
+	}
+
+	return nodebridge.NewInclusionProof(transactionID, transactionOutputs.Created, block, commitment)
+}
+
+// Output returns the output with metadata for the given output ID.
+func (m *NodeBridge) Output(_ context.Context, outputID iotago.OutputID) (*nodebridge.Output, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	output, exists := m.outputs[outputID]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "output %s", outputID.ToHex())
+	}
+
+	return output, nil
+}
+
+// Outputs returns the outputs with metadata for the given output IDs in the same order.
+func (m *NodeBridge) Outputs(ctx context.Context, outputIDs []iotago.OutputID) ([]*nodebridge.Output, error) {
+	outputs := make([]*nodebridge.Output, 0, len(outputIDs))
+	for _, outputID := range outputIDs {
+		output, err := m.Output(ctx, outputID)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	return outputs, nil
+}
+
+// SetOutput adds or replaces the given output.
+func (m *NodeBridge) SetOutput(output *nodebridge.Output) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.outputs[output.OutputID] = output
+}
+
+// OutputAtSlot returns the state of the output for the given output ID as of the given slot.
+func (m *NodeBridge) OutputAtSlot(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (*nodebridge.OutputSlotState, error) {
+	output, err := m.Output(ctx, outputID)
+	if err != nil {
+		return nil, err
+	}
+
+	existed := output.Metadata.Included != nil && output.Metadata.Included.Slot <= slot
+	spent := output.Metadata.Spent != nil && output.Metadata.Spent.Slot <= slot
+
+	return &nodebridge.OutputSlotState{
+		Output:  output,
+		Slot:    slot,
+		Existed: existed,
+		Unspent: existed && !spent,
+	}, nil
+}
+
+// ForceCommitUntil records the given slot, see ForcedCommitSlot.
+func (m *NodeBridge) ForceCommitUntil(_ context.Context, slot iotago.SlotIndex) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.forcedCommitSlot = slot
+
+	return nil
+}
+
+// ForcedCommitSlot returns the slot of the last ForceCommitUntil call.
+func (m *NodeBridge) ForcedCommitSlot() iotago.SlotIndex {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.forcedCommitSlot
+}
+
+// Commitment returns the commitment for the given slot.
+func (m *NodeBridge) Commitment(_ context.Context, slot iotago.SlotIndex) (*nodebridge.Commitment, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	commitment, exists := m.commitments[slot]
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "commitment for slot %d", slot)
+	}
+
+	return commitment, nil
+}
+
+// CommitmentByID returns the commitment for the given commitment ID.
+func (m *NodeBridge) CommitmentByID(ctx context.Context, id iotago.CommitmentID) (*nodebridge.Commitment, error) {
+	commitment, err := m.Commitment(ctx, id.Slot())
+	if err != nil {
+		return nil, err
+	}
+
+	if commitment.CommitmentID != id {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "commitment %s", id)
+	}
+
+	return commitment, nil
+}
+
+// CommitmentRaw returns the commitment for the given commitment ID and its serialized bytes.
+func (m *NodeBridge) CommitmentRaw(ctx context.Context, id iotago.CommitmentID) (*nodebridge.Commitment, []byte, error) {
+	commitment, err := m.CommitmentByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawData, err := m.apiProvider.APIForSlot(id.Slot()).Encode(commitment.Commitment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commitment, rawData, nil
+}
+
+// CommitmentRoots returns the roots that were set for the given commitment, verified against the commitment.
+func (m *NodeBridge) CommitmentRoots(ctx context.Context, id iotago.CommitmentID) (*nodebridge.CommitmentRoots, error) {
+	commitment, err := m.CommitmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	roots, exists := m.commitmentRoots[id]
+	m.mutex.RUnlock()
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "roots of commitment %s not found", id)
+	}
+
+	commitmentRoots := &nodebridge.CommitmentRoots{
+		Commitment: commitment,
+		Roots:      roots,
+	}
+	if err := commitmentRoots.Verify(); err != nil {
+		return nil, err
+	}
+
+	return commitmentRoots, nil
+}
+
+// SetCommitmentRoots sets the roots of the given commitment.
+func (m *NodeBridge) SetCommitmentRoots(id iotago.CommitmentID, roots *iotago.Roots) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.commitmentRoots[id] = roots
+}
+
+// BlockInclusionProof returns the tangle proof that was set for the given block,
+// verified against the roots of the commitment of its slot.
+func (m *NodeBridge) BlockInclusionProof(ctx context.Context, blockID iotago.BlockID) (*nodebridge.BlockInclusionProof, error) {
+	m.mutex.RLock()
+	tangleProof, exists := m.tangleProofs[blockID]
+	m.mutex.RUnlock()
+	if !exists {
+		return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "inclusion proof of block %s not found", blockID)
+	}
+
+	commitment, err := m.Commitment(ctx, blockID.Slot())
+	if err != nil {
+		return nil, err
+	}
+
+	commitmentRoots, err := m.CommitmentRoots(ctx, commitment.CommitmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &nodebridge.BlockInclusionProof{
+		CommitmentRoots: commitmentRoots,
+		BlockID:         blockID,
+		TangleProof:     tangleProof,
+	}
+	if err := proof.Verify(); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// SetTangleProof sets the proof of the given block in the tangle root of the commitment of its slot.
+func (m *NodeBridge) SetTangleProof(blockID iotago.BlockID, proof *merklehasher.Proof[iotago.BlockID]) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.tangleProofs[blockID] = proof
+}
+
+// AddCommitment adds the given commitment and passes it to the ListenToCommitments listeners.
+func (m *NodeBridge) AddCommitment(commitment *nodebridge.Commitment) {
+	m.mutex.Lock()
+	m.commitments[commitment.CommitmentID.Slot()] = commitment
+	m.mutex.Unlock()
+
+	m.commitmentFeed.add(commitment)
+}
+
+// ReadSlotData returns the added commitment and ledger update of the given slot,
+// and the blocks in that slot whose metadata was set with at least the accepted state.
+func (m *NodeBridge) ReadSlotData(ctx context.Context, slot iotago.SlotIndex, opts ...options.Option[nodebridge.SlotDataOptions]) (*nodebridge.SlotData, error) {
+	slotDataOptions := nodebridge.NewSlotDataOptions(opts...)
+
+	commitment, err := m.Commitment(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	blocks := make([]*nodebridge.SlotBlock, 0)
+	for blockID, blockMetadata := range m.blockMetadata {
+		if blockID.Slot() != slot {
+			continue
+		}
+
+		switch blockMetadata.BlockState {
+		case api.BlockStateAccepted, api.BlockStateConfirmed, api.BlockStateFinalized:
+		default:
+			continue
+		}
+
+		slotBlock := &nodebridge.SlotBlock{Metadata: blockMetadata}
+		if slotDataOptions.IncludeBlocks() {
+			block, exists := m.blocks[blockID]
+			if !exists {
+				return nil, ierrors.Wrapf(nodebridge.ErrNotFound, "block %s", blockID)
+			}
+
+			rawData, err := block.API.Encode(block)
+			if err != nil {
+				return nil, err
+			}
+
+			slotBlock.Block = block
+			slotBlock.RawData = rawData
+		}
+
+		blocks = append(blocks, slotBlock)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Metadata.BlockID.Compare(blocks[j].Metadata.BlockID) < 0
+	})
+
+	ledgerUpdate, exists := m.ledgerUpdates[slot]
+	if !exists {
+		ledgerUpdate = &nodebridge.LedgerUpdate{
+			API:          m.apiProvider.APIForSlot(slot),
+			CommitmentID: commitment.CommitmentID,
+		}
+	}
+
+	return &nodebridge.SlotData{
+		Commitment:   commitment,
+		Blocks:       blocks,
+		LedgerUpdate: ledgerUpdate,
+	}, nil
+}
+
+// ListenToCommitments listens to commitments.
+func (m *NodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *nodebridge.Commitment, rawData []byte) error) error {
+	return m.commitmentFeed.listen(ctx, func(commitment *nodebridge.Commitment) (bool, error) {
+		slot := commitment.CommitmentID.Slot()
+		if slot < startSlot {
+			return false, nil
+		}
+		if endSlot != 0 && slot > endSlot {
+			return true, nil
+		}
+
+		rawData, err := m.apiProvider.APIForSlot(slot).Encode(commitment.Commitment)
+		if err != nil {
+			return false, err
+		}
+
+		if err := consumer(commitment, rawData); err != nil {
+			return false, err
+		}
+
+		return slot == endSlot, nil
+	})
+}
+
+// ListenToCommitmentsChained listens to commitments and guarantees that every commitment references the previously delivered one.
+func (m *NodeBridge) ListenToCommitmentsChained(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *nodebridge.Commitment, rawData []byte) error) error {
+	return nodebridge.ListenToCommitmentsChained(ctx, m, startSlot, endSlot, consumer)
+}
+
+// AddLedgerUpdate applies the given ledger update to the outputs and passes it to the ListenToLedgerUpdates listeners.
+func (m *NodeBridge) AddLedgerUpdate(update *nodebridge.LedgerUpdate) {
+	m.mutex.Lock()
+	for _, output := range update.Consumed {
+		m.outputs[output.OutputID] = output
+	}
+	for _, output := range update.Created {
+		m.outputs[output.OutputID] = output
+	}
+	m.ledgerUpdates[update.CommitmentID.Slot()] = update
+	m.mutex.Unlock()
+
+	m.ledgerUpdateFeed.add(update)
+}
+
+// ListenToLedgerUpdates listens to ledger updates.
+func (m *NodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *nodebridge.LedgerUpdate) error) error {
+	return m.ledgerUpdateFeed.listen(ctx, func(update *nodebridge.LedgerUpdate) (bool, error) {
+		slot := update.CommitmentID.Slot()
+		if slot < startSlot {
+			return false, nil
+		}
+		if endSlot != 0 && slot > endSlot {
+			return true, nil
+		}
+
+		if err := consumer(update); err != nil {
+			return false, err
+		}
+
+		return slot == endSlot, nil
+	})
+}
+
+// ListenToFilteredLedgerUpdates listens to the added ledger updates, which only contain the outputs matching the given filter.
+func (m *NodeBridge) ListenToFilteredLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *nodebridge.OutputFilter, consumer func(update *nodebridge.LedgerUpdate) error) error {
+	filterOutputs := func(outputs []*nodebridge.Output) []*nodebridge.Output {
+		filtered := make([]*nodebridge.Output, 0, len(outputs))
+		for _, output := range outputs {
+			if filter.Matches(output.Output) {
+				filtered = append(filtered, output)
+			}
+		}
+
+		return filtered
+	}
+
+	return m.ListenToLedgerUpdates(ctx, startSlot, endSlot, func(update *nodebridge.LedgerUpdate) error {
+		return consumer(&nodebridge.LedgerUpdate{
+			API:          update.API,
+			CommitmentID: update.CommitmentID,
+			Consumed:     filterOutputs(update.Consumed),
+			Created:      filterOutputs(update.Created),
+		})
+	})
+}
+
+// ListenToAccountChanges listens to the account changes derived from the added ledger updates.
+func (m *NodeBridge) ListenToAccountChanges(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(changes *nodebridge.AccountChanges) error) error {
+	return m.ListenToLedgerUpdates(ctx, startSlot, endSlot, func(update *nodebridge.LedgerUpdate) error {
+		changes := nodebridge.AccountChangesFromLedgerUpdate(update)
+		if len(changes.Changes) == 0 {
+			return nil
+		}
+
+		return consumer(changes)
+	})
+}
+
+// ListenToAddressActivity listens to the address activities derived from the added ledger updates.
+func (m *NodeBridge) ListenToAddressActivity(ctx context.Context, startSlot, endSlot iotago.SlotIndex, addresses *nodebridge.AddressSet, consumer func(activities *nodebridge.AddressActivities) error) error {
+	return m.ListenToLedgerUpdates(ctx, startSlot, endSlot, func(update *nodebridge.LedgerUpdate) error {
+		activities := nodebridge.AddressActivitiesFromLedgerUpdate(update, addresses)
+		if len(activities.Activities) == 0 {
+			return nil
+		}
+
+		return consumer(activities)
+	})
+}
+
+// SyncLedger passes the current unspent outputs to the handler and afterwards follows the ledger updates.
+// The bootstrap ledger state belongs to the last added ledger update, or the latest commitment if there is none.
+func (m *NodeBridge) SyncLedger(ctx context.Context, handler nodebridge.LedgerSyncHandler) error {
+	m.mutex.RLock()
+	bootstrapCommitmentID := iotago.EmptyCommitmentID
+	if m.latestCommitment != nil {
+		bootstrapCommitmentID = m.latestCommitment.CommitmentID
+	}
+	if updates, _ := m.ledgerUpdateFeed.since(0); len(updates) > 0 {
+		bootstrapCommitmentID = updates[len(updates)-1].CommitmentID
+	}
+
+	unspentOutputs := make([]*nodebridge.Output, 0, len(m.outputs))
+	for _, output := range m.outputs {
+		if output.Metadata.Spent == nil {
+			unspentOutputs = append(unspentOutputs, output)
+		}
+	}
+	m.mutex.RUnlock()
+
+	sortOutputs(unspentOutputs)
+	for _, output := range unspentOutputs {
+		if err := handler.BootstrapOutput(output); err != nil {
+			return err
+		}
+	}
+
+	if err := handler.BootstrapDone(bootstrapCommitmentID); err != nil {
+		return err
+	}
+
+	return m.ListenToLedgerUpdates(ctx, bootstrapCommitmentID.Slot()+1, 0, handler.LedgerUpdate)
+}
+
+// AddAcceptedTransaction passes the given transaction to the ListenToAcceptedTransactions listeners.
+func (m *NodeBridge) AddAcceptedTransaction(tx *nodebridge.AcceptedTransaction) {
+	m.acceptedTransactionFeed.add(tx)
+}
+
+// ListenToAcceptedTransactions listens to accepted transactions.
+func (m *NodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer func(tx *nodebridge.AcceptedTransaction) error) error {
+	return m.acceptedTransactionFeed.listen(ctx, func(tx *nodebridge.AcceptedTransaction) (bool, error) {
+		return false, consumer(tx)
+	})
+}
+
+// ListenToNodeStatus listens to node status updates.
+// The cooldown is ignored, every status set via SetNodeStatus is delivered.
+func (m *NodeBridge) ListenToNodeStatus(ctx context.Context, _ time.Duration, consumer func(status *inx.NodeStatus) error) error {
+	return m.nodeStatusFeed.listen(ctx, func(status *inx.NodeStatus) (bool, error) {
+		return false, consumer(status)
+	})
+}
+
+// SetNodeStatus sets the node status and passes it to the ListenToNodeStatus listeners.
+// The latest and the latest finalized commitment are updated from the status and the events are triggered if they changed.
+func (m *NodeBridge) SetNodeStatus(status *inx.NodeStatus) error {
+	latestCommitment, err := m.unwrapCommitment(status.GetLatestCommitment())
+	if err != nil {
+		return err
+	}
+
+	latestFinalizedCommitment, err := m.unwrapCommitment(status.GetLatestFinalizedCommitment())
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	latestCommitmentChanged := latestCommitment != nil && (m.latestCommitment == nil || m.latestCommitment.CommitmentID != latestCommitment.CommitmentID)
+	latestFinalizedCommitmentChanged := latestFinalizedCommitment != nil && (m.latestFinalizedCommitment == nil || m.latestFinalizedCommitment.CommitmentID != latestFinalizedCommitment.CommitmentID)
+	healthChanged, syncedChanged, pruningEpochChanged := nodebridge.NodeStatusChanges(m.nodeStatus, status)
+	m.nodeStatus = status
+	m.latestCommitment = latestCommitment
+	m.latestFinalizedCommitment = latestFinalizedCommitment
+	m.mutex.Unlock()
+
+	m.nodeStatusFeed.add(status)
+
+	if latestCommitmentChanged {
+		m.events.LatestCommitmentChanged.Trigger(latestCommitment)
+	}
+	if latestFinalizedCommitmentChanged {
+		m.events.LatestFinalizedCommitmentChanged.Trigger(latestFinalizedCommitment)
+	}
+	if healthChanged {
+		m.events.NodeHealthChanged.Trigger(status.GetIsHealthy())
+	}
+	if syncedChanged {
+		m.events.NodeSyncedChanged.Trigger(status.GetIsBootstrapped())
+	}
+	if pruningEpochChanged {
+		m.events.PruningEpochChanged.Trigger(iotago.EpochIndex(status.GetPruningEpoch()))
+	}
+
+	return nil
+}
+
+// SetLatestCommitments sets the latest and the latest finalized commitment of the node status.
+func (m *NodeBridge) SetLatestCommitments(latestCommitment *nodebridge.Commitment, latestFinalizedCommitment *nodebridge.Commitment) error {
+	inxLatestCommitment, err := m.wrapCommitment(latestCommitment)
+	if err != nil {
+		return err
+	}
+
+	inxLatestFinalizedCommitment, err := m.wrapCommitment(latestFinalizedCommitment)
+	if err != nil {
+		return err
+	}
+
+	status := m.copyNodeStatus()
+	status.LatestCommitment = inxLatestCommitment
+	status.LatestFinalizedCommitment = inxLatestFinalizedCommitment
+
+	return m.SetNodeStatus(status)
+}
+
+// SetHealthy sets whether the node is healthy.
+func (m *NodeBridge) SetHealthy(isHealthy bool) error {
+	status := m.copyNodeStatus()
+	status.IsHealthy = isHealthy
+
+	return m.SetNodeStatus(status)
+}
+
+// NodeStatus returns the current node status.
+func (m *NodeBridge) NodeStatus() *inx.NodeStatus {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.nodeStatus
+}
+
+// IsNodeHealthy returns true if the node is healthy.
+func (m *NodeBridge) IsNodeHealthy() bool {
+	return m.NodeStatus().GetIsHealthy()
+}
+
+// LatestCommitment returns the latest commitment.
+func (m *NodeBridge) LatestCommitment() *nodebridge.Commitment {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.latestCommitment
+}
+
+// LatestFinalizedCommitment returns the latest finalized commitment.
+func (m *NodeBridge) LatestFinalizedCommitment() *nodebridge.Commitment {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.latestFinalizedCommitment
+}
+
+// PruningEpoch returns the pruning epoch.
+func (m *NodeBridge) PruningEpoch() iotago.EpochIndex {
+	return iotago.EpochIndex(m.NodeStatus().GetPruningEpoch())
+}
+
+// RequestTips returns the configured tips.
+func (m *NodeBridge) RequestTips(_ context.Context, _ uint32) (strong iotago.BlockIDs, weak iotago.BlockIDs, shallowLike iotago.BlockIDs, err error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.strongTips, m.weakTips, m.shallowLikeTips, nil
+}
+
+// SetTips sets the tips returned by RequestTips.
+func (m *NodeBridge) SetTips(strong iotago.BlockIDs, weak iotago.BlockIDs, shallowLike iotago.BlockIDs) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.strongTips = strong
+	m.weakTips = weak
+	m.shallowLikeTips = shallowLike
+}
+
+func (m *NodeBridge) copyNodeStatus() *inx.NodeStatus {
+	status := m.NodeStatus()
+
+	return &inx.NodeStatus{
+		IsHealthy:                 status.GetIsHealthy(),
+		IsBootstrapped:            status.GetIsBootstrapped(),
+		LastAcceptedBlockSlot:     status.GetLastAcceptedBlockSlot(),
+		LastConfirmedBlockSlot:    status.GetLastConfirmedBlockSlot(),
+		LatestCommitment:          status.GetLatestCommitment(),
+		LatestFinalizedCommitment: status.GetLatestFinalizedCommitment(),
+		PruningEpoch:              status.GetPruningEpoch(),
+		HasPruned:                 status.GetHasPruned(),
+	}
+}
+
+func (m *NodeBridge) wrapCommitment(commitment *nodebridge.Commitment) (*inx.Commitment, error) {
+	if commitment == nil {
+		//nolint:nilnil // nil, nil is ok in this context, even if it is not go idiomatic
+		return nil, nil
+	}
+
+	rawData, err := m.apiProvider.APIForSlot(commitment.CommitmentID.Slot()).Encode(commitment.Commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	return inx.NewCommitmentWithBytes(commitment.CommitmentID, rawData), nil
+}
+
+func (m *NodeBridge) unwrapCommitment(inxCommitment *inx.Commitment) (*nodebridge.Commitment, error) {
+	if inxCommitment == nil || inxCommitment.GetCommitment() == nil {
+		//nolint:nilnil // nil, nil is ok in this context, even if it is not go idiomatic
+		return nil, nil
+	}
+
+	commitmentID := inxCommitment.GetCommitmentId().Unwrap()
+
+	commitment, err := inxCommitment.UnwrapCommitment(m.apiProvider.APIForSlot(commitmentID.Slot()))
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to unwrap commitment %s", commitmentID)
+	}
+
+	return &nodebridge.Commitment{
+		CommitmentID: commitmentID,
+		Commitment:   commitment,
+	}, nil
+}
+
+func sortOutputs(outputs []*nodebridge.Output) {
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].OutputID.Compare(outputs[j].OutputID) < 0
+	})
+}
@@ -0,0 +1,1369 @@
+package nodebridge
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/nodeclient"
+)
+
+const (
+	// DefaultMultiNodeHealthCheckInterval is the default interval in which disconnected nodes are reconnected
+	// and nodes that failed a call are considered again.
+	DefaultMultiNodeHealthCheckInterval = 5 * time.Second
+	// DefaultMultiNodeFailoverBackoff is the default backoff before a failed stream is re-subscribed at the same node.
+	DefaultMultiNodeFailoverBackoff = 1 * time.Second
+
+	// MultiNodeAddressSeparator separates the addresses of the nodes passed to MultiNodeBridge.Connect.
+	MultiNodeAddressSeparator = ","
+)
+
+// ErrNoHealthyNode is returned if none of the nodes of the MultiNodeBridge is connected.
+var ErrNoHealthyNode = ierrors.Wrap(ErrUnavailable, "no connected node available")
+
+// MultiNodeBridgeEvents are the events of the MultiNodeBridge that are not part of the NodeBridge events.
+type MultiNodeBridgeEvents struct {
+	// PrimaryChanged is triggered with the previous and the new address if the primary node changed.
+	// The address is empty if there was or is no primary node.
+	PrimaryChanged *event.Event2[string, string]
+}
+
+// multiNodeMember is one of the nodes of the MultiNodeBridge.
+type multiNodeMember struct {
+	address string
+	// nodeBridge is the connection to the node, it is nil if the node was never connected.
+	nodeBridge NodeBridge
+	// running is true while the nodeBridge is connected and running.
+	running bool
+	// connecting is true while the nodeBridge is being (re-)connected.
+	connecting bool
+	// unavailableUntil is the time until the node is not used as primary, because a call to it failed.
+	unavailableUntil time.Time
+}
+
+// MultiNodeBridge is a NodeBridge that is connected to multiple nodes of the same network.
+// All calls are routed to the primary node, which is the first healthy node in the order of the addresses.
+//
+// If a call fails because the primary node is unavailable, the call is retried once at the next primary node.
+// Streams that were interrupted are re-subscribed at the next primary node; streams of a slot range
+// resume after the last slot that was passed to the consumer, so no slot is delivered twice or skipped.
+// Disconnected nodes are reconnected in the health check interval.
+//
+// The nodes must belong to the same network, use WithNodeBridgeOptions(WithTargetNetworkName(...)) to enforce it.
+type MultiNodeBridge struct {
+	// the logger used to log events.
+	log.Logger
+
+	nodeBridgeFactory   func(logger log.Logger) NodeBridge
+	healthCheckInterval time.Duration
+	failoverBackoff     time.Duration
+
+	events      *Events
+	multiEvents *MultiNodeBridgeEvents
+
+	streamEvents             *StreamEvents
+	streamEventSubscriptions StreamEventSubscriptions
+
+	mutex   sync.RWMutex
+	members []*multiNodeMember
+	primary *multiNodeMember
+	// primaryChanged is closed and replaced if the primary node changed.
+	primaryChanged chan struct{}
+	// primaryHealthy is the health of the primary node that was reported via the NodeHealthChanged event.
+	primaryHealthy bool
+}
+
+var _ NodeBridge = &MultiNodeBridge{}
+
+// WithNodeBridgeOptions sets the options of the NodeBridges that are created for every node.
+func WithNodeBridgeOptions(opts ...options.Option[nodeBridge]) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.nodeBridgeFactory = func(logger log.Logger) NodeBridge {
+			return New(logger, opts...)
+		}
+	}
+}
+
+// WithNodeBridgeFactory sets the function that creates the NodeBridge for every node,
+// which allows to inject a decorated or fake NodeBridge.
+func WithNodeBridgeFactory(nodeBridgeFactory func(logger log.Logger) NodeBridge) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.nodeBridgeFactory = nodeBridgeFactory
+	}
+}
+
+// WithHealthCheckInterval sets the interval in which disconnected nodes are reconnected
+// and nodes that failed a call are considered again.
+func WithHealthCheckInterval(interval time.Duration) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.healthCheckInterval = interval
+	}
+}
+
+// WithFailoverBackoff sets the backoff before a failed stream is re-subscribed at the same node,
+// which is the case if there is no other node available.
+func WithFailoverBackoff(backoff time.Duration) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.failoverBackoff = backoff
+	}
+}
+
+// WithMultiNodeStreamEvents subscribes to the given streams, their items trigger the StreamEvents while the MultiNodeBridge is running.
+// The streams fail over like the other streams of the MultiNodeBridge,
+// so WithStreamEvents should not be passed to the NodeBridges of the nodes.
+func WithMultiNodeStreamEvents(subscriptions StreamEventSubscriptions) options.Option[MultiNodeBridge] {
+	return func(m *MultiNodeBridge) {
+		m.streamEventSubscriptions = subscriptions
+	}
+}
+
+// NewMultiNodeBridge creates a new MultiNodeBridge.
+func NewMultiNodeBridge(logger log.Logger, opts ...options.Option[MultiNodeBridge]) *MultiNodeBridge {
+	return options.Apply(&MultiNodeBridge{
+		Logger: logger,
+		nodeBridgeFactory: func(logger log.Logger) NodeBridge {
+			return New(logger)
+		},
+		healthCheckInterval: DefaultMultiNodeHealthCheckInterval,
+		failoverBackoff:     DefaultMultiNodeFailoverBackoff,
+		events: &Events{
+			LatestCommitmentChanged:          event.New1[*Commitment](),
+			LatestFinalizedCommitmentChanged: event.New1[*Commitment](),
+			ConnectionStateChanged:           event.New1[connectivity.State](),
+			NodeHealthChanged:                event.New1[bool](),
+			NodeSyncedChanged:                event.New1[bool](),
+			PruningEpochChanged:              event.New1[iotago.EpochIndex](),
+			ProtocolParametersAnnounced:      event.New1[*ProtocolParametersUpdate](),
+			StreamStale:                      event.New2[string, time.Duration](),
+			StreamItemsDropped:               event.New2[string, uint64](),
+			APIRouteReregistered:             event.New2[string, error](),
+			CommitmentGapDetected:            event.New2[iotago.SlotIndex, iotago.SlotIndex](),
+			CommitmentChainBroken:            event.New1[*CommitmentChainError](),
+		},
+		multiEvents: &MultiNodeBridgeEvents{
+			PrimaryChanged: event.New2[string, string](),
+		},
+		streamEvents:   NewStreamEvents(),
+		primaryChanged: make(chan struct{}),
+	}, opts)
+}
+
+// Events returns the events of the primary node.
+func (m *MultiNodeBridge) Events() *Events {
+	return m.events
+}
+
+// StreamEvents returns the events that are triggered for the items of the subscribed INX streams, see WithMultiNodeStreamEvents.
+func (m *MultiNodeBridge) StreamEvents() *StreamEvents {
+	return m.streamEvents
+}
+
+// Stats returns the progress of the consumers of the active streams of the primary node,
+// the streams of the MultiNodeBridge are always opened at the primary node.
+func (m *MultiNodeBridge) Stats() []*StreamStats {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.Stats()
+	}
+
+	return nil
+}
+
+// MultiNodeEvents returns the events of the MultiNodeBridge that are not part of the NodeBridge events.
+func (m *MultiNodeBridge) MultiNodeEvents() *MultiNodeBridgeEvents {
+	return m.multiEvents
+}
+
+// PrimaryAddress returns the address of the primary node, or an empty string if there is none.
+func (m *MultiNodeBridge) PrimaryAddress() string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.primary == nil {
+		return ""
+	}
+
+	return m.primary.address
+}
+
+// Connect connects to the nodes with the given addresses, separated by MultiNodeAddressSeparator.
+// It succeeds if at least one of the nodes is connected, the others are reconnected while the MultiNodeBridge is running.
+func (m *MultiNodeBridge) Connect(ctx context.Context, address string, maxConnectionAttempts uint) error {
+	addresses := make([]string, 0)
+	for _, nodeAddress := range strings.Split(address, MultiNodeAddressSeparator) {
+		if nodeAddress = strings.TrimSpace(nodeAddress); nodeAddress != "" {
+			addresses = append(addresses, nodeAddress)
+		}
+	}
+
+	if len(addresses) == 0 {
+		return ierrors.Wrap(ErrInvalidAddress, "no node address given")
+	}
+
+	members := make([]*multiNodeMember, 0, len(addresses))
+	for _, nodeAddress := range addresses {
+		if _, err := ParseAddress(nodeAddress); err != nil {
+			return err
+		}
+
+		members = append(members, &multiNodeMember{address: nodeAddress})
+	}
+
+	m.mutex.Lock()
+	m.members = members
+	m.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(members))
+	for i, member := range members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = m.connectMember(ctx, member, maxConnectionAttempts)
+		}()
+	}
+	wg.Wait()
+
+	var connected bool
+	for i, err := range errs {
+		if err != nil {
+			m.LogWarnf("Connecting to node %s failed: %s", members[i].address, err)
+			continue
+		}
+		connected = true
+	}
+
+	if !connected {
+		return ierrors.Join(ErrNoHealthyNode, ierrors.Join(errs...))
+	}
+
+	m.selectPrimary()
+
+	return nil
+}
+
+// connectMember creates a new NodeBridge for the member and connects it.
+func (m *MultiNodeBridge) connectMember(ctx context.Context, member *multiNodeMember, maxConnectionAttempts uint) error {
+	m.mutex.Lock()
+	member.connecting = true
+	m.mutex.Unlock()
+
+	nodeBridge := m.nodeBridgeFactory(m.NewChildLogger(member.address))
+	err := nodeBridge.Connect(ctx, member.address, maxConnectionAttempts)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	member.connecting = false
+	if err != nil {
+		return err
+	}
+	member.nodeBridge = nodeBridge
+
+	return nil
+}
+
+// Run runs the NodeBridges of all connected nodes and reconnects disconnected nodes until the context is canceled.
+func (m *MultiNodeBridge) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	runMember := func(member *multiNodeMember) {
+		m.mutex.Lock()
+		nodeBridge := member.nodeBridge
+		if nodeBridge == nil || member.running {
+			m.mutex.Unlock()
+			return
+		}
+		member.running = true
+		m.mutex.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.runMember(ctx, member, nodeBridge)
+		}()
+	}
+
+	m.mutex.RLock()
+	members := m.members
+	m.mutex.RUnlock()
+
+	for _, member := range members {
+		runMember(member)
+	}
+	m.selectPrimary()
+
+	if m.streamEventSubscriptions != 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := RunStreamEvents(ctx, m, m.streamEvents, m.streamEventSubscriptions); err != nil {
+				m.LogErrorf("Error listening to stream events: %s", err)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(m.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+
+		case <-ticker.C:
+			for _, member := range members {
+				m.mutex.RLock()
+				reconnect := !member.running && !member.connecting
+				m.mutex.RUnlock()
+
+				if !reconnect {
+					continue
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					if err := m.connectMember(ctx, member, 1); err != nil {
+						m.LogDebugf("Reconnecting to node %s failed: %s", member.address, err)
+						return
+					}
+
+					m.LogInfof("Reconnected to node %s", member.address)
+					runMember(member)
+					m.selectPrimary()
+				}()
+			}
+
+			// nodes that failed a call might be available again
+			m.selectPrimary()
+		}
+	}
+}
+
+// runMember runs the NodeBridge of the member until it stops and forwards its events while it is the primary node.
+func (m *MultiNodeBridge) runMember(ctx context.Context, member *multiNodeMember, nodeBridge NodeBridge) {
+	unhook := m.forwardEvents(member, nodeBridge)
+	defer unhook()
+
+	nodeBridge.Run(ctx)
+
+	m.mutex.Lock()
+	member.running = false
+	m.mutex.Unlock()
+
+	if ctx.Err() == nil {
+		m.LogWarnf("Connection to node %s lost", member.address)
+		m.selectPrimary()
+	}
+}
+
+// forwardEvents forwards the events of the member if it is the primary node.
+func (m *MultiNodeBridge) forwardEvents(member *multiNodeMember, nodeBridge NodeBridge) func() {
+	isPrimary := func() bool {
+		m.mutex.RLock()
+		defer m.mutex.RUnlock()
+
+		return m.primary == member
+	}
+
+	memberEvents := nodeBridge.Events()
+	hooks := []interface{ Unhook() }{
+		memberEvents.LatestCommitmentChanged.Hook(func(commitment *Commitment) {
+			if isPrimary() {
+				m.events.LatestCommitmentChanged.Trigger(commitment)
+			}
+		}),
+		memberEvents.LatestFinalizedCommitmentChanged.Hook(func(commitment *Commitment) {
+			if isPrimary() {
+				m.events.LatestFinalizedCommitmentChanged.Trigger(commitment)
+			}
+		}),
+		memberEvents.ConnectionStateChanged.Hook(func(state connectivity.State) {
+			if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+				m.markUnavailable(member)
+			}
+
+			if isPrimary() {
+				m.events.ConnectionStateChanged.Trigger(state)
+			}
+		}),
+		memberEvents.NodeHealthChanged.Hook(func(_ bool) {
+			// the health of the primary node is reported by selectPrimary
+			m.selectPrimary()
+		}),
+		memberEvents.NodeSyncedChanged.Hook(func(synced bool) {
+			if isPrimary() {
+				m.events.NodeSyncedChanged.Trigger(synced)
+			}
+		}),
+		memberEvents.PruningEpochChanged.Hook(func(epoch iotago.EpochIndex) {
+			if isPrimary() {
+				m.events.PruningEpochChanged.Trigger(epoch)
+			}
+		}),
+		memberEvents.ProtocolParametersAnnounced.Hook(func(update *ProtocolParametersUpdate) {
+			if isPrimary() {
+				m.events.ProtocolParametersAnnounced.Trigger(update)
+			}
+		}),
+		memberEvents.StreamStale.Hook(func(name string, since time.Duration) {
+			if isPrimary() {
+				m.events.StreamStale.Trigger(name, since)
+			}
+		}),
+		memberEvents.APIRouteReregistered.Hook(func(route string, err error) {
+			if isPrimary() {
+				m.events.APIRouteReregistered.Trigger(route, err)
+			}
+		}),
+		memberEvents.CommitmentGapDetected.Hook(func(firstSlot iotago.SlotIndex, lastSlot iotago.SlotIndex) {
+			if isPrimary() {
+				m.events.CommitmentGapDetected.Trigger(firstSlot, lastSlot)
+			}
+		}),
+		memberEvents.CommitmentChainBroken.Hook(func(chainErr *CommitmentChainError) {
+			if isPrimary() {
+				m.events.CommitmentChainBroken.Trigger(chainErr)
+			}
+		}),
+	}
+
+	return func() {
+		for _, hook := range hooks {
+			hook.Unhook()
+		}
+	}
+}
+
+// markUnavailable excludes the member from the primary selection until the next health check.
+func (m *MultiNodeBridge) markUnavailable(member *multiNodeMember) {
+	m.mutex.Lock()
+	member.unavailableUntil = time.Now().Add(m.healthCheckInterval)
+	m.mutex.Unlock()
+
+	m.selectPrimary()
+}
+
+// selectPrimary selects the first healthy running node as primary.
+// If there is no healthy node, the first running node that did not fail a call is selected,
+// so calls are still possible while the nodes are syncing.
+func (m *MultiNodeBridge) selectPrimary() {
+	m.mutex.Lock()
+
+	now := time.Now()
+	var primary, fallback, lastResort *multiNodeMember
+	for _, member := range m.members {
+		if !member.running {
+			continue
+		}
+
+		if lastResort == nil {
+			lastResort = member
+		}
+
+		if now.Before(member.unavailableUntil) {
+			continue
+		}
+
+		if fallback == nil {
+			fallback = member
+		}
+
+		if member.nodeBridge.IsNodeHealthy() {
+			primary = member
+			break
+		}
+	}
+
+	switch {
+	case primary != nil:
+	case fallback != nil:
+		primary = fallback
+	default:
+		primary = lastResort
+	}
+
+	previous := m.primary
+	m.primary = primary
+	if previous != primary {
+		close(m.primaryChanged)
+		m.primaryChanged = make(chan struct{})
+	}
+
+	healthy := primary != nil && primary.nodeBridge.IsNodeHealthy()
+	healthChanged := healthy != m.primaryHealthy
+	m.primaryHealthy = healthy
+
+	m.mutex.Unlock()
+
+	if previous != primary {
+		previousAddress, primaryAddress := "", ""
+		if previous != nil {
+			previousAddress = previous.address
+		}
+		if primary != nil {
+			primaryAddress = primary.address
+		}
+
+		if primary == nil {
+			m.LogWarnf("No connected node available, previous primary node: %s", previousAddress)
+		} else {
+			m.LogInfof("Primary node changed from \"%s\" to \"%s\"", previousAddress, primaryAddress)
+		}
+
+		m.multiEvents.PrimaryChanged.Trigger(previousAddress, primaryAddress)
+	}
+
+	if healthChanged {
+		m.events.NodeHealthChanged.Trigger(healthy)
+	}
+}
+
+// primaryNodeBridge returns the primary member and its NodeBridge.
+func (m *MultiNodeBridge) primaryNodeBridge() (*multiNodeMember, NodeBridge, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.primary == nil {
+		return nil, nil, ErrNoHealthyNode
+	}
+
+	return m.primary, m.primary.nodeBridge, nil
+}
+
+// awaitPrimaryNodeBridge waits until there is a primary node and returns it and its NodeBridge.
+func (m *MultiNodeBridge) awaitPrimaryNodeBridge(ctx context.Context) (*multiNodeMember, NodeBridge, error) {
+	for {
+		m.mutex.RLock()
+		primary := m.primary
+		primaryChanged := m.primaryChanged
+		m.mutex.RUnlock()
+
+		if primary != nil {
+			return primary, primary.nodeBridge, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-primaryChanged:
+		}
+	}
+}
+
+// anyNodeBridge returns the NodeBridge of the primary node,
+// or the NodeBridge of the first node that was connected if there is no primary node.
+func (m *MultiNodeBridge) anyNodeBridge() NodeBridge {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.primary != nil {
+		return m.primary.nodeBridge
+	}
+
+	for _, member := range m.members {
+		if member.nodeBridge != nil {
+			return member.nodeBridge
+		}
+	}
+
+	return nil
+}
+
+// multiNodeCall executes the call at the primary node and retries it once at the next primary node
+// if the primary node is unavailable.
+func multiNodeCall[T any](ctx context.Context, m *MultiNodeBridge, name string, call func(nodeBridge NodeBridge) (T, error)) (T, error) {
+	member, nodeBridge, err := m.primaryNodeBridge()
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	result, err := call(nodeBridge)
+	if err == nil || ctx.Err() != nil || !isReconnectableStreamError(err) {
+		return result, err
+	}
+
+	m.markUnavailable(member)
+
+	nextMember, nextNodeBridge, nextErr := m.primaryNodeBridge()
+	if nextErr != nil || nextMember == member {
+		return result, err
+	}
+
+	m.LogDebugf("%s failed at node %s, retrying at node %s: %s", name, member.address, nextMember.address, err)
+
+	return call(nextNodeBridge)
+}
+
+// multiNodeConsumerError marks errors of consumers, which end a stream instead of causing a failover.
+type multiNodeConsumerError struct {
+	err error
+}
+
+func (e *multiNodeConsumerError) Error() string {
+	return e.err.Error()
+}
+
+func (e *multiNodeConsumerError) Unwrap() error {
+	return e.err
+}
+
+func markConsumerError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &multiNodeConsumerError{err: err}
+}
+
+// listenWithFailover runs the given stream listener at the primary node and re-subscribes it
+// at the next primary node if the stream was interrupted. The listener returns true if the stream is complete.
+// Errors of the consumer have to be marked with markConsumerError, they end the stream.
+func (m *MultiNodeBridge) listenWithFailover(ctx context.Context, name string, listenFunc func(ctx context.Context, nodeBridge NodeBridge) (bool, error)) error {
+	var previous *multiNodeMember
+	for {
+		member, nodeBridge, err := m.awaitPrimaryNodeBridge(ctx)
+		if err != nil {
+			// the context was canceled
+			return nil
+		}
+
+		if member == previous {
+			// there is no other node available, so the stream is re-subscribed at the same node after a backoff
+			timer := time.NewTimer(m.failoverBackoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+		}
+
+		complete, err := listenFunc(ctx, nodeBridge)
+
+		var consumerErr *multiNodeConsumerError
+		if ierrors.As(err, &consumerErr) {
+			return consumerErr.err
+		}
+
+		if complete || ctx.Err() != nil {
+			return err
+		}
+
+		if err != nil && !isReconnectableStreamError(err) {
+			return err
+		}
+
+		m.LogWarnf("%s: stream at node %s was interrupted, failing over ...", name, member.address)
+		m.markUnavailable(member)
+		previous = member
+	}
+}
+
+// listenToSlotRangeWithFailover runs the given stream listener of a slot range with failover.
+// The listener reports every slot that was passed to the consumer via delivered,
+// so the stream resumes after the last delivered slot at the next primary node.
+func (m *MultiNodeBridge) listenToSlotRangeWithFailover(ctx context.Context, name string, startSlot, endSlot iotago.SlotIndex, listenFunc func(ctx context.Context, nodeBridge NodeBridge, startSlot iotago.SlotIndex, delivered func(slot iotago.SlotIndex)) error) error {
+	delivered := func(slot iotago.SlotIndex) {
+		startSlot = slot + 1
+	}
+
+	return m.listenWithFailover(ctx, name, func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		err := listenFunc(ctx, nodeBridge, startSlot, delivered)
+
+		complete := endSlot != 0 && startSlot > endSlot
+		if complete && isReconnectableStreamError(err) {
+			// the stream was interrupted after the end slot was delivered, so nothing is missing
+			return true, nil
+		}
+
+		return complete, err
+	})
+}
+
+// Client returns the INXClient of the primary node.
+func (m *MultiNodeBridge) Client() inx.INXClient {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.Client()
+	}
+
+	return nil
+}
+
+// NodeConfig returns the NodeConfiguration of the primary node.
+func (m *MultiNodeBridge) NodeConfig() *inx.NodeConfiguration {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.NodeConfig()
+	}
+
+	return nil
+}
+
+// APIProvider returns the APIProvider of the primary node.
+func (m *MultiNodeBridge) APIProvider() iotago.APIProvider {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.APIProvider()
+	}
+
+	return nil
+}
+
+// ProtocolParametersHistory returns all protocol parameters known to the primary node, ordered by their start epoch.
+func (m *MultiNodeBridge) ProtocolParametersHistory() ([]*ProtocolParametersUpdate, error) {
+	return multiNodeCall(context.Background(), m, "ProtocolParametersHistory", func(nodeBridge NodeBridge) ([]*ProtocolParametersUpdate, error) {
+		return nodeBridge.ProtocolParametersHistory()
+	})
+}
+
+// ProtocolParametersForEpoch returns the protocol parameters that are valid in the given epoch.
+func (m *MultiNodeBridge) ProtocolParametersForEpoch(epoch iotago.EpochIndex) (iotago.ProtocolParameters, error) {
+	return multiNodeCall(context.Background(), m, "ProtocolParametersForEpoch", func(nodeBridge NodeBridge) (iotago.ProtocolParameters, error) {
+		return nodeBridge.ProtocolParametersForEpoch(epoch)
+	})
+}
+
+// ListenToProtocolParameterUpdates passes protocol parameters that become active in a future epoch to the consumer.
+// Protocol parameters that were already passed to the consumer are not passed again after a failover.
+func (m *MultiNodeBridge) ListenToProtocolParameterUpdates(ctx context.Context, pollInterval time.Duration, consumer func(update *ProtocolParametersUpdate) error) error {
+	type announcement struct {
+		version    iotago.Version
+		startEpoch iotago.EpochIndex
+	}
+	announced := make(map[announcement]struct{})
+
+	return m.listenWithFailover(ctx, "ListenToProtocolParameterUpdates", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToProtocolParameterUpdates(ctx, pollInterval, func(update *ProtocolParametersUpdate) error {
+			key := announcement{version: update.ProtocolParameters.Version(), startEpoch: update.StartEpoch}
+			if _, exists := announced[key]; exists {
+				return nil
+			}
+			announced[key] = struct{}{}
+
+			return markConsumerError(consumer(update))
+		})
+	})
+}
+
+// INXNodeClient returns the NodeClient of the primary node.
+func (m *MultiNodeBridge) INXNodeClient() (*nodeclient.Client, error) {
+	return multiNodeCall(context.Background(), m, "INXNodeClient", func(nodeBridge NodeBridge) (*nodeclient.Client, error) {
+		return nodeBridge.INXNodeClient()
+	})
+}
+
+// Management returns the ManagementClient of the primary node.
+func (m *MultiNodeBridge) Management(ctx context.Context) (nodeclient.ManagementClient, error) {
+	return multiNodeCall(ctx, m, "Management", func(nodeBridge NodeBridge) (nodeclient.ManagementClient, error) {
+		return nodeBridge.Management(ctx)
+	})
+}
+
+// Indexer returns the IndexerClient of the primary node.
+func (m *MultiNodeBridge) Indexer(ctx context.Context) (nodeclient.IndexerClient, error) {
+	return multiNodeCall(ctx, m, "Indexer", func(nodeBridge NodeBridge) (nodeclient.IndexerClient, error) {
+		return nodeBridge.Indexer(ctx)
+	})
+}
+
+// EventAPI returns the EventAPIClient of the primary node.
+func (m *MultiNodeBridge) EventAPI(ctx context.Context) (*nodeclient.EventAPIClient, error) {
+	return multiNodeCall(ctx, m, "EventAPI", func(nodeBridge NodeBridge) (*nodeclient.EventAPIClient, error) {
+		return nodeBridge.EventAPI(ctx)
+	})
+}
+
+// BlockIssuer returns the BlockIssuerClient of the primary node.
+func (m *MultiNodeBridge) BlockIssuer(ctx context.Context) (nodeclient.BlockIssuerClient, error) {
+	return multiNodeCall(ctx, m, "BlockIssuer", func(nodeBridge NodeBridge) (nodeclient.BlockIssuerClient, error) {
+		return nodeBridge.BlockIssuer(ctx)
+	})
+}
+
+// ReadIsCandidate returns true if the given account is a candidate.
+func (m *MultiNodeBridge) ReadIsCandidate(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error) {
+	return multiNodeCall(ctx, m, "ReadIsCandidate", func(nodeBridge NodeBridge) (bool, error) {
+		return nodeBridge.ReadIsCandidate(ctx, id, slot)
+	})
+}
+
+// ReadIsCommitteeMember returns true if the given account is a committee member.
+func (m *MultiNodeBridge) ReadIsCommitteeMember(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error) {
+	return multiNodeCall(ctx, m, "ReadIsCommitteeMember", func(nodeBridge NodeBridge) (bool, error) {
+		return nodeBridge.ReadIsCommitteeMember(ctx, id, slot)
+	})
+}
+
+// ReadIsValidatorAccount returns true if the given account is a validator account.
+func (m *MultiNodeBridge) ReadIsValidatorAccount(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error) {
+	return multiNodeCall(ctx, m, "ReadIsValidatorAccount", func(nodeBridge NodeBridge) (bool, error) {
+		return nodeBridge.ReadIsValidatorAccount(ctx, id, slot)
+	})
+}
+
+// ReadCommittee returns the committee of the given epoch.
+func (m *MultiNodeBridge) ReadCommittee(ctx context.Context, epoch iotago.EpochIndex) (*api.CommitteeResponse, error) {
+	return multiNodeCall(ctx, m, "ReadCommittee", func(nodeBridge NodeBridge) (*api.CommitteeResponse, error) {
+		return nodeBridge.ReadCommittee(ctx, epoch)
+	})
+}
+
+// ReadValidators returns a page of the validators of the given epoch, starting at the cursor (empty for the first page).
+func (m *MultiNodeBridge) ReadValidators(ctx context.Context, epoch iotago.EpochIndex, cursor string) (*api.ValidatorsResponse, error) {
+	return multiNodeCall(ctx, m, "ReadValidators", func(nodeBridge NodeBridge) (*api.ValidatorsResponse, error) {
+		return nodeBridge.ReadValidators(ctx, epoch, cursor)
+	})
+}
+
+// ReadAllValidators returns all validators of the given epoch.
+func (m *MultiNodeBridge) ReadAllValidators(ctx context.Context, epoch iotago.EpochIndex) ([]*api.ValidatorResponse, error) {
+	return multiNodeCall(ctx, m, "ReadAllValidators", func(nodeBridge NodeBridge) ([]*api.ValidatorResponse, error) {
+		return nodeBridge.ReadAllValidators(ctx, epoch)
+	})
+}
+
+// StakingInfo returns the staking data of the given validator account.
+func (m *MultiNodeBridge) StakingInfo(ctx context.Context, accountID iotago.AccountID) (*api.ValidatorResponse, error) {
+	return multiNodeCall(ctx, m, "StakingInfo", func(nodeBridge NodeBridge) (*api.ValidatorResponse, error) {
+		return nodeBridge.StakingInfo(ctx, accountID)
+	})
+}
+
+// RewardsForOutput returns the mana rewards the given staking account or delegation output can claim in the given epoch (0 for the latest).
+func (m *MultiNodeBridge) RewardsForOutput(ctx context.Context, outputID iotago.OutputID, claimEpoch iotago.EpochIndex) (*api.ManaRewardsResponse, error) {
+	return multiNodeCall(ctx, m, "RewardsForOutput", func(nodeBridge NodeBridge) (*api.ManaRewardsResponse, error) {
+		return nodeBridge.RewardsForOutput(ctx, outputID, claimEpoch)
+	})
+}
+
+// Congestion returns the congestion of the given account.
+func (m *MultiNodeBridge) Congestion(ctx context.Context, accountID iotago.AccountID) (*api.CongestionResponse, error) {
+	return multiNodeCall(ctx, m, "Congestion", func(nodeBridge NodeBridge) (*api.CongestionResponse, error) {
+		return nodeBridge.Congestion(ctx, accountID)
+	})
+}
+
+// ListenToCongestion passes the congestion of the given account to the consumer for every new commitment of the primary node.
+func (m *MultiNodeBridge) ListenToCongestion(ctx context.Context, accountID iotago.AccountID, consumer func(congestion *api.CongestionResponse) error) error {
+	return ListenToCongestionPerCommitment(ctx, m, accountID, consumer)
+}
+
+// RegisterAPIRoute registers the given API route at all connected nodes,
+// so the route stays reachable if the primary node changes.
+func (m *MultiNodeBridge) RegisterAPIRoute(ctx context.Context, route string, address string, path string) error {
+	return m.forEachRunningNode(func(nodeBridge NodeBridge) error {
+		return nodeBridge.RegisterAPIRoute(ctx, route, address, path)
+	})
+}
+
+// UnregisterAPIRoute unregisters the given API route at all connected nodes.
+func (m *MultiNodeBridge) UnregisterAPIRoute(ctx context.Context, route string) error {
+	return m.forEachRunningNode(func(nodeBridge NodeBridge) error {
+		return nodeBridge.UnregisterAPIRoute(ctx, route)
+	})
+}
+
+// forEachRunningNode executes the given function for the NodeBridges of all connected nodes.
+// It only fails if the function failed for all nodes.
+func (m *MultiNodeBridge) forEachRunningNode(f func(nodeBridge NodeBridge) error) error {
+	m.mutex.RLock()
+	nodeBridges := make([]NodeBridge, 0, len(m.members))
+	for _, member := range m.members {
+		if member.running || (member.nodeBridge != nil && member == m.primary) {
+			nodeBridges = append(nodeBridges, member.nodeBridge)
+		}
+	}
+	m.mutex.RUnlock()
+
+	if len(nodeBridges) == 0 {
+		return ErrNoHealthyNode
+	}
+
+	var errs []error
+	for _, nodeBridge := range nodeBridges {
+		if err := f(nodeBridge); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == len(nodeBridges) {
+		return ierrors.Join(errs...)
+	}
+
+	return nil
+}
+
+// ActiveRootBlocks returns the active root blocks.
+func (m *MultiNodeBridge) ActiveRootBlocks(ctx context.Context) (map[iotago.BlockID]iotago.CommitmentID, error) {
+	return multiNodeCall(ctx, m, "ActiveRootBlocks", func(nodeBridge NodeBridge) (map[iotago.BlockID]iotago.CommitmentID, error) {
+		return nodeBridge.ActiveRootBlocks(ctx)
+	})
+}
+
+// SubmitBlock submits the given block.
+func (m *MultiNodeBridge) SubmitBlock(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+	return multiNodeCall(ctx, m, "SubmitBlock", func(nodeBridge NodeBridge) (iotago.BlockID, error) {
+		return nodeBridge.SubmitBlock(ctx, block)
+	})
+}
+
+// Block returns the block for the given block ID.
+func (m *MultiNodeBridge) Block(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, error) {
+	return multiNodeCall(ctx, m, "Block", func(nodeBridge NodeBridge) (*iotago.Block, error) {
+		return nodeBridge.Block(ctx, blockID)
+	})
+}
+
+// BlockWithRaw returns the block for the given block ID and its raw serialized bytes as sent by the node.
+func (m *MultiNodeBridge) BlockWithRaw(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, []byte, error) {
+	type blockWithRaw struct {
+		block   *iotago.Block
+		rawData []byte
+	}
+
+	result, err := multiNodeCall(ctx, m, "BlockWithRaw", func(nodeBridge NodeBridge) (*blockWithRaw, error) {
+		block, rawData, err := nodeBridge.BlockWithRaw(ctx, blockID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &blockWithRaw{block: block, rawData: rawData}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.block, result.rawData, nil
+}
+
+// BlockRaw returns the raw serialized bytes of the block for the given block ID as sent by the node.
+func (m *MultiNodeBridge) BlockRaw(ctx context.Context, blockID iotago.BlockID) ([]byte, error) {
+	return multiNodeCall(ctx, m, "BlockRaw", func(nodeBridge NodeBridge) ([]byte, error) {
+		return nodeBridge.BlockRaw(ctx, blockID)
+	})
+}
+
+// BlockMetadata returns the block metadata for the given block ID.
+func (m *MultiNodeBridge) BlockMetadata(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error) {
+	return multiNodeCall(ctx, m, "BlockMetadata", func(nodeBridge NodeBridge) (*api.BlockMetadataResponse, error) {
+		return nodeBridge.BlockMetadata(ctx, blockID)
+	})
+}
+
+// ListenToBlocks listens to blocks.
+// Blocks that were received by the node during a failover are not passed to the consumer.
+func (m *MultiNodeBridge) ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return m.listenWithFailover(ctx, "ListenToBlocks", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+			return markConsumerError(consumer(block, rawData))
+		})
+	})
+}
+
+// ListenToBlocksFiltered listens to the blocks that match the given filter.
+// Blocks that were received by the node during a failover are not passed to the consumer.
+func (m *MultiNodeBridge) ListenToBlocksFiltered(ctx context.Context, filter *BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return m.listenWithFailover(ctx, "ListenToBlocksFiltered", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToBlocksFiltered(ctx, filter, func(block *iotago.Block, rawData []byte) error {
+			return markConsumerError(consumer(block, rawData))
+		})
+	})
+}
+
+// ListenToBlocksBuffered listens to blocks of the primary node via a buffer of the given size,
+// the stream fails over to another node like ListenToBlocks.
+func (m *MultiNodeBridge) ListenToBlocksBuffered(ctx context.Context, bufferSize int, dropPolicy BufferDropPolicy, consumer func(block *iotago.Block, rawData []byte) error) error {
+	return ListenToBlocksBuffered(ctx, m, bufferSize, dropPolicy, consumer)
+}
+
+// ListenToAcceptedBlocks listens to accepted blocks.
+// Blocks that were accepted during a failover are not passed to the consumer.
+func (m *MultiNodeBridge) ListenToAcceptedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	return m.listenWithFailover(ctx, "ListenToAcceptedBlocks", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToAcceptedBlocks(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			return markConsumerError(consumer(blockMetadata))
+		})
+	})
+}
+
+// ListenToConfirmedBlocks listens to confirmed blocks.
+// Blocks that were confirmed during a failover are not passed to the consumer.
+func (m *MultiNodeBridge) ListenToConfirmedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	return m.listenWithFailover(ctx, "ListenToConfirmedBlocks", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToConfirmedBlocks(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			return markConsumerError(consumer(blockMetadata))
+		})
+	})
+}
+
+// ListenToFinalizedBlocks listens to blocks whose slot was finalized.
+// Blocks whose slot was finalized during a failover are not passed to the consumer.
+func (m *MultiNodeBridge) ListenToFinalizedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error {
+	return m.listenWithFailover(ctx, "ListenToFinalizedBlocks", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToFinalizedBlocks(ctx, func(blockMetadata *api.BlockMetadataResponse) error {
+			return markConsumerError(consumer(blockMetadata))
+		})
+	})
+}
+
+// TransactionMetadata returns the transaction metadata for the given transaction ID.
+func (m *MultiNodeBridge) TransactionMetadata(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error) {
+	return multiNodeCall(ctx, m, "TransactionMetadata", func(nodeBridge NodeBridge) (*api.TransactionMetadataResponse, error) {
+		return nodeBridge.TransactionMetadata(ctx, transactionID)
+	})
+}
+
+// TransactionMetadataBatch returns the transaction metadata for the given transaction IDs, looked up concurrently.
+// Every lookup fails over to the next primary node on its own.
+func (m *MultiNodeBridge) TransactionMetadataBatch(ctx context.Context, transactionIDs []iotago.TransactionID) (map[iotago.TransactionID]*api.TransactionMetadataResponse, error) {
+	return ReadTransactionMetadataBatch(ctx, transactionIDs, DefaultTransactionMetadataConcurrency, m.TransactionMetadata)
+}
+
+// TransactionOutputs returns the outputs created and consumed by the transaction with the given transaction ID.
+func (m *MultiNodeBridge) TransactionOutputs(ctx context.Context, transactionID iotago.TransactionID) (*TransactionOutputs, error) {
+	return multiNodeCall(ctx, m, "TransactionOutputs", func(nodeBridge NodeBridge) (*TransactionOutputs, error) {
+		return nodeBridge.TransactionOutputs(ctx, transactionID)
+	})
+}
+
+// InclusionProof returns the inclusion proof of the transaction with the given transaction ID.
+func (m *MultiNodeBridge) InclusionProof(ctx context.Context, transactionID iotago.TransactionID) (*InclusionProof, error) {
+	return multiNodeCall(ctx, m, "InclusionProof", func(nodeBridge NodeBridge) (*InclusionProof, error) {
+		return nodeBridge.InclusionProof(ctx, transactionID)
+	})
+}
+
+// Output returns the output with metadata for the given output ID.
+func (m *MultiNodeBridge) Output(ctx context.Context, outputID iotago.OutputID) (*Output, error) {
+	return multiNodeCall(ctx, m, "Output", func(nodeBridge NodeBridge) (*Output, error) {
+		return nodeBridge.Output(ctx, outputID)
+	})
+}
+
+// Outputs returns the outputs with metadata for the given output IDs in the same order.
+func (m *MultiNodeBridge) Outputs(ctx context.Context, outputIDs []iotago.OutputID) ([]*Output, error) {
+	return multiNodeCall(ctx, m, "Outputs", func(nodeBridge NodeBridge) ([]*Output, error) {
+		return nodeBridge.Outputs(ctx, outputIDs)
+	})
+}
+
+// OutputAtSlot returns the state of the output for the given output ID as of the given slot.
+func (m *MultiNodeBridge) OutputAtSlot(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (*OutputSlotState, error) {
+	return multiNodeCall(ctx, m, "OutputAtSlot", func(nodeBridge NodeBridge) (*OutputSlotState, error) {
+		return nodeBridge.OutputAtSlot(ctx, outputID, slot)
+	})
+}
+
+// ForceCommitUntil forces the primary node to commit until the given slot.
+func (m *MultiNodeBridge) ForceCommitUntil(ctx context.Context, slot iotago.SlotIndex) error {
+	_, err := multiNodeCall(ctx, m, "ForceCommitUntil", func(nodeBridge NodeBridge) (struct{}, error) {
+		return struct{}{}, nodeBridge.ForceCommitUntil(ctx, slot)
+	})
+
+	return err
+}
+
+// Commitment returns the commitment for the given slot.
+func (m *MultiNodeBridge) Commitment(ctx context.Context, slot iotago.SlotIndex) (*Commitment, error) {
+	return multiNodeCall(ctx, m, "Commitment", func(nodeBridge NodeBridge) (*Commitment, error) {
+		return nodeBridge.Commitment(ctx, slot)
+	})
+}
+
+// CommitmentByID returns the commitment for the given commitment ID.
+func (m *MultiNodeBridge) CommitmentByID(ctx context.Context, id iotago.CommitmentID) (*Commitment, error) {
+	return multiNodeCall(ctx, m, "CommitmentByID", func(nodeBridge NodeBridge) (*Commitment, error) {
+		return nodeBridge.CommitmentByID(ctx, id)
+	})
+}
+
+// CommitmentRaw returns the commitment for the given commitment ID and its raw serialized bytes as sent by the node.
+func (m *MultiNodeBridge) CommitmentRaw(ctx context.Context, id iotago.CommitmentID) (*Commitment, []byte, error) {
+	type commitmentRaw struct {
+		commitment *Commitment
+		rawData    []byte
+	}
+
+	result, err := multiNodeCall(ctx, m, "CommitmentRaw", func(nodeBridge NodeBridge) (*commitmentRaw, error) {
+		commitment, rawData, err := nodeBridge.CommitmentRaw(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		return &commitmentRaw{commitment: commitment, rawData: rawData}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.commitment, result.rawData, nil
+}
+
+// CommitmentRoots returns the roots of the given commitment, verified against the commitment.
+func (m *MultiNodeBridge) CommitmentRoots(ctx context.Context, id iotago.CommitmentID) (*CommitmentRoots, error) {
+	return multiNodeCall(ctx, m, "CommitmentRoots", func(nodeBridge NodeBridge) (*CommitmentRoots, error) {
+		return nodeBridge.CommitmentRoots(ctx, id)
+	})
+}
+
+// BlockInclusionProof returns the verified proof that the given block was accepted in its slot.
+func (m *MultiNodeBridge) BlockInclusionProof(ctx context.Context, blockID iotago.BlockID) (*BlockInclusionProof, error) {
+	return multiNodeCall(ctx, m, "BlockInclusionProof", func(nodeBridge NodeBridge) (*BlockInclusionProof, error) {
+		return nodeBridge.BlockInclusionProof(ctx, blockID)
+	})
+}
+
+// ReadSlotData returns the commitment, the accepted blocks and the ledger update of the given committed slot.
+func (m *MultiNodeBridge) ReadSlotData(ctx context.Context, slot iotago.SlotIndex, opts ...options.Option[SlotDataOptions]) (*SlotData, error) {
+	return multiNodeCall(ctx, m, "ReadSlotData", func(nodeBridge NodeBridge) (*SlotData, error) {
+		return nodeBridge.ReadSlotData(ctx, slot, opts...)
+	})
+}
+
+// ListenToCommitments listens to commitments.
+// After a failover the stream resumes after the last commitment that was passed to the consumer.
+func (m *MultiNodeBridge) ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
+	return m.listenToSlotRangeWithFailover(ctx, "ListenToCommitments", startSlot, endSlot, func(ctx context.Context, nodeBridge NodeBridge, startSlot iotago.SlotIndex, delivered func(slot iotago.SlotIndex)) error {
+		return nodeBridge.ListenToCommitments(ctx, startSlot, endSlot, func(commitment *Commitment, rawData []byte) error {
+			if err := consumer(commitment, rawData); err != nil {
+				return markConsumerError(err)
+			}
+			delivered(commitment.CommitmentID.Slot())
+
+			return nil
+		})
+	})
+}
+
+// ListenToCommitmentsChained listens to commitments and guarantees that every commitment references the previously delivered one.
+// The commitments are chained across failovers, so a primary node on another chain stops the listener with a CommitmentChainError.
+func (m *MultiNodeBridge) ListenToCommitmentsChained(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error {
+	return ListenToCommitmentsChained(ctx, m, startSlot, endSlot, consumer)
+}
+
+// ListenToLedgerUpdates listens to ledger updates.
+// After a failover the stream resumes after the last ledger update that was passed to the consumer.
+func (m *MultiNodeBridge) ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *LedgerUpdate) error) error {
+	return m.listenToSlotRangeWithFailover(ctx, "ListenToLedgerUpdates", startSlot, endSlot, func(ctx context.Context, nodeBridge NodeBridge, startSlot iotago.SlotIndex, delivered func(slot iotago.SlotIndex)) error {
+		return nodeBridge.ListenToLedgerUpdates(ctx, startSlot, endSlot, func(update *LedgerUpdate) error {
+			if err := consumer(update); err != nil {
+				return markConsumerError(err)
+			}
+			delivered(update.CommitmentID.Slot())
+
+			return nil
+		})
+	})
+}
+
+// ListenToFilteredLedgerUpdates listens to ledger updates that only contain the outputs matching the given filter.
+// After a failover the stream resumes after the last ledger update that was passed to the consumer.
+func (m *MultiNodeBridge) ListenToFilteredLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *OutputFilter, consumer func(update *LedgerUpdate) error) error {
+	return m.listenToSlotRangeWithFailover(ctx, "ListenToFilteredLedgerUpdates", startSlot, endSlot, func(ctx context.Context, nodeBridge NodeBridge, startSlot iotago.SlotIndex, delivered func(slot iotago.SlotIndex)) error {
+		return nodeBridge.ListenToFilteredLedgerUpdates(ctx, startSlot, endSlot, filter, func(update *LedgerUpdate) error {
+			if err := consumer(update); err != nil {
+				return markConsumerError(err)
+			}
+			delivered(update.CommitmentID.Slot())
+
+			return nil
+		})
+	})
+}
+
+// multiNodeLedgerSyncHandler tracks the progress of SyncLedger, so the ledger updates can be resumed after a failover.
+type multiNodeLedgerSyncHandler struct {
+	handler      LedgerSyncHandler
+	bootstrapped bool
+	lastSlot     iotago.SlotIndex
+}
+
+func (h *multiNodeLedgerSyncHandler) BootstrapOutput(output *Output) error {
+	return markConsumerError(h.handler.BootstrapOutput(output))
+}
+
+func (h *multiNodeLedgerSyncHandler) BootstrapDone(commitmentID iotago.CommitmentID) error {
+	if err := h.handler.BootstrapDone(commitmentID); err != nil {
+		return markConsumerError(err)
+	}
+	h.bootstrapped = true
+	h.lastSlot = commitmentID.Slot()
+
+	return nil
+}
+
+func (h *multiNodeLedgerSyncHandler) LedgerUpdate(update *LedgerUpdate) error {
+	slot := update.CommitmentID.Slot()
+	if slot <= h.lastSlot {
+		// the update is already part of the ledger state
+		return nil
+	}
+
+	if slot != h.lastSlot+1 {
+		return ierrors.Wrapf(ErrLedgerSyncGap, "expected slot %d, got %d", h.lastSlot+1, slot)
+	}
+
+	if err := h.handler.LedgerUpdate(update); err != nil {
+		return markConsumerError(err)
+	}
+	h.lastSlot = slot
+
+	return nil
+}
+
+// SyncLedger streams the current unspent outputs to the handler and afterwards
+// follows the ledger updates starting right after the commitment of the bootstrap ledger state.
+// If the primary node fails after the bootstrap, the ledger updates are resumed at the next primary node
+// without gaps. If it fails during the bootstrap, the error is returned, because the handler already
+// received a part of the unspent outputs.
+func (m *MultiNodeBridge) SyncLedger(ctx context.Context, handler LedgerSyncHandler) error {
+	syncHandler := &multiNodeLedgerSyncHandler{handler: handler}
+
+	return m.listenWithFailover(ctx, "SyncLedger", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		if !syncHandler.bootstrapped {
+			err := nodeBridge.SyncLedger(ctx, syncHandler)
+			if !syncHandler.bootstrapped {
+				// the bootstrap can't be resumed at another node
+				return true, err
+			}
+
+			return false, err
+		}
+
+		return false, nodeBridge.ListenToLedgerUpdates(ctx, syncHandler.lastSlot+1, 0, syncHandler.LedgerUpdate)
+	})
+}
+
+// ListenToAccountChanges listens to the changes of accounts per committed slot.
+// After a failover the stream resumes after the last slot that was passed to the consumer.
+func (m *MultiNodeBridge) ListenToAccountChanges(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(changes *AccountChanges) error) error {
+	// the changes are derived from the ledger updates, so every slot is tracked even if it has no changes
+	return m.ListenToFilteredLedgerUpdates(ctx, startSlot, endSlot, NewOutputFilter(WithOutputTypes(iotago.OutputAccount)), func(update *LedgerUpdate) error {
+		changes := AccountChangesFromLedgerUpdate(update)
+		if len(changes.Changes) == 0 {
+			return nil
+		}
+
+		return consumer(changes)
+	})
+}
+
+// ListenToAddressActivity listens to the outputs that were created for or spent by the addresses in the given set per committed slot.
+// After a failover the stream resumes after the last slot that was passed to the consumer.
+func (m *MultiNodeBridge) ListenToAddressActivity(ctx context.Context, startSlot, endSlot iotago.SlotIndex, addresses *AddressSet, consumer func(activities *AddressActivities) error) error {
+	filter := NewOutputFilter(WithOutputPredicate(func(output iotago.Output) bool {
+		return len(addresses.matchingAddresses(output)) > 0
+	}))
+
+	// the activities are derived from the ledger updates, so every slot is tracked even if it has no activities
+	return m.ListenToFilteredLedgerUpdates(ctx, startSlot, endSlot, filter, func(update *LedgerUpdate) error {
+		activities := AddressActivitiesFromLedgerUpdate(update, addresses)
+		if len(activities.Activities) == 0 {
+			return nil
+		}
+
+		return consumer(activities)
+	})
+}
+
+// ListenToAcceptedTransactions listens to accepted transactions.
+// Transactions that were accepted during a failover are not passed to the consumer.
+func (m *MultiNodeBridge) ListenToAcceptedTransactions(ctx context.Context, consumer func(tx *AcceptedTransaction) error) error {
+	return m.listenWithFailover(ctx, "ListenToAcceptedTransactions", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToAcceptedTransactions(ctx, func(tx *AcceptedTransaction) error {
+			return markConsumerError(consumer(tx))
+		})
+	})
+}
+
+// ListenToNodeStatus listens to the node status updates of the primary node.
+func (m *MultiNodeBridge) ListenToNodeStatus(ctx context.Context, cooldown time.Duration, consumer func(status *inx.NodeStatus) error) error {
+	return m.listenWithFailover(ctx, "ListenToNodeStatus", func(ctx context.Context, nodeBridge NodeBridge) (bool, error) {
+		return false, nodeBridge.ListenToNodeStatus(ctx, cooldown, func(status *inx.NodeStatus) error {
+			return markConsumerError(consumer(status))
+		})
+	})
+}
+
+// NodeStatus returns the current node status of the primary node.
+func (m *MultiNodeBridge) NodeStatus() *inx.NodeStatus {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.NodeStatus()
+	}
+
+	return nil
+}
+
+// IsNodeHealthy returns true if the primary node is healthy.
+func (m *MultiNodeBridge) IsNodeHealthy() bool {
+	_, nodeBridge, err := m.primaryNodeBridge()
+	if err != nil {
+		return false
+	}
+
+	return nodeBridge.IsNodeHealthy()
+}
+
+// LatestCommitment returns the latest commitment of the primary node.
+func (m *MultiNodeBridge) LatestCommitment() *Commitment {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.LatestCommitment()
+	}
+
+	return nil
+}
+
+// LatestFinalizedCommitment returns the latest finalized commitment of the primary node.
+func (m *MultiNodeBridge) LatestFinalizedCommitment() *Commitment {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.LatestFinalizedCommitment()
+	}
+
+	return nil
+}
+
+// PruningEpoch returns the pruning epoch of the primary node.
+func (m *MultiNodeBridge) PruningEpoch() iotago.EpochIndex {
+	if nodeBridge := m.anyNodeBridge(); nodeBridge != nil {
+		return nodeBridge.PruningEpoch()
+	}
+
+	return 0
+}
+
+// RequestTips requests tips from the primary node.
+func (m *MultiNodeBridge) RequestTips(ctx context.Context, count uint32) (iotago.BlockIDs, iotago.BlockIDs, iotago.BlockIDs, error) {
+	type tips struct {
+		strong      iotago.BlockIDs
+		weak        iotago.BlockIDs
+		shallowLike iotago.BlockIDs
+	}
+
+	result, err := multiNodeCall(ctx, m, "RequestTips", func(nodeBridge NodeBridge) (*tips, error) {
+		strong, weak, shallowLike, err := nodeBridge.RequestTips(ctx, count)
+		if err != nil {
+			return nil, err
+		}
+
+		return &tips{strong: strong, weak: weak, shallowLike: shallowLike}, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return result.strong, result.weak, result.shallowLike, nil
+}
@@ -0,0 +1,422 @@
+package nodebridge_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/nodebridge/mock"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+const (
+	testNodeA = "node-a:9029"
+	testNodeB = "node-b:9029"
+	testNodeC = "node-c:9029"
+)
+
+var errTestConsumer = ierrors.New("consumer failed")
+
+// testNode is a mock node whose connection and calls can be made to fail.
+type testNode struct {
+	*mock.NodeBridge
+
+	// connectErr is returned by Connect.
+	connectErr error
+	// callErr is returned by Congestion instead of the congestion of the mock.
+	callErr error
+	// ledgerUpdatesFailAfterSlot interrupts ListenToLedgerUpdates with ErrUnavailable after the given slot was delivered.
+	ledgerUpdatesFailAfterSlot iotago.SlotIndex
+
+	// disconnected is closed to let Run return, which simulates a lost connection.
+	disconnected     chan struct{}
+	disconnectedOnce sync.Once
+}
+
+func newTestNode(t *testing.T, healthy bool) *testNode {
+	t.Helper()
+
+	node := &testNode{
+		NodeBridge:   mock.New(iotago.SingleVersionProvider(tpkg.ZeroCostTestAPI)),
+		disconnected: make(chan struct{}),
+	}
+	require.NoError(t, node.SetHealthy(healthy))
+
+	return node
+}
+
+func (n *testNode) disconnect() {
+	n.disconnectedOnce.Do(func() { close(n.disconnected) })
+}
+
+func (n *testNode) Connect(_ context.Context, _ string, _ uint) error {
+	return n.connectErr
+}
+
+func (n *testNode) Run(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-n.disconnected:
+	}
+}
+
+func (n *testNode) Congestion(ctx context.Context, accountID iotago.AccountID) (*api.CongestionResponse, error) {
+	if n.callErr != nil {
+		return nil, n.callErr
+	}
+
+	return n.NodeBridge.Congestion(ctx, accountID)
+}
+
+func (n *testNode) ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *nodebridge.LedgerUpdate) error) error {
+	return n.NodeBridge.ListenToLedgerUpdates(ctx, startSlot, endSlot, func(update *nodebridge.LedgerUpdate) error {
+		if err := consumer(update); err != nil {
+			return err
+		}
+
+		if n.ledgerUpdatesFailAfterSlot != 0 && update.CommitmentID.Slot() >= n.ledgerUpdatesFailAfterSlot {
+			return ierrors.Wrap(nodebridge.ErrUnavailable, "connection lost")
+		}
+
+		return nil
+	})
+}
+
+func (n *testNode) addLedgerUpdates(slots ...iotago.SlotIndex) {
+	for _, slot := range slots {
+		n.AddLedgerUpdate(&nodebridge.LedgerUpdate{
+			API:          tpkg.ZeroCostTestAPI,
+			CommitmentID: iotago.NewCommitmentID(slot, iotago.Identifier{}),
+		})
+	}
+}
+
+// testNodeConnection is created by the NodeBridge factory of the MultiNodeBridge
+// and becomes the testNode of the address it is connected to.
+type testNodeConnection struct {
+	*testNode
+
+	nodes map[string]*testNode
+}
+
+func (c *testNodeConnection) Connect(ctx context.Context, address string, maxConnectionAttempts uint) error {
+	c.testNode = c.nodes[address]
+
+	return c.testNode.Connect(ctx, address, maxConnectionAttempts)
+}
+
+// runTestMultiNodeBridge connects a MultiNodeBridge to the given nodes and runs it until the test ends.
+// The health check interval is long enough that failed nodes are not considered again during the test.
+func runTestMultiNodeBridge(t *testing.T, nodes map[string]*testNode, addresses string) (*nodebridge.MultiNodeBridge, error) {
+	t.Helper()
+
+	multiNodeBridge := nodebridge.NewMultiNodeBridge(log.NewLogger(),
+		nodebridge.WithNodeBridgeFactory(func(_ log.Logger) nodebridge.NodeBridge {
+			return &testNodeConnection{nodes: nodes}
+		}),
+		nodebridge.WithHealthCheckInterval(time.Hour),
+		nodebridge.WithFailoverBackoff(time.Millisecond),
+	)
+
+	if err := multiNodeBridge.Connect(context.Background(), addresses, 1); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		multiNodeBridge.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	require.Eventually(t, func() bool {
+		return multiNodeBridge.PrimaryAddress() != ""
+	}, time.Second, time.Millisecond)
+
+	return multiNodeBridge, nil
+}
+
+func TestMultiNodeBridgeConnect(t *testing.T) {
+	tests := []struct {
+		name        string
+		addresses   string
+		unhealthy   []string
+		unreachable []string
+		wantPrimary string
+		wantErr     error
+	}{
+		{
+			name:        "first node is primary",
+			addresses:   testNodeA + "," + testNodeB,
+			wantPrimary: testNodeA,
+		},
+		{
+			name:        "addresses are trimmed",
+			addresses:   " " + testNodeB + " , " + testNodeA + ",",
+			wantPrimary: testNodeB,
+		},
+		{
+			name:        "unreachable node is skipped",
+			addresses:   testNodeA + "," + testNodeB,
+			unreachable: []string{testNodeA},
+			wantPrimary: testNodeB,
+		},
+		{
+			name:        "unhealthy node is skipped",
+			addresses:   testNodeA + "," + testNodeB + "," + testNodeC,
+			unhealthy:   []string{testNodeA},
+			wantPrimary: testNodeB,
+		},
+		{
+			name:        "first running node is primary if no node is healthy",
+			addresses:   testNodeA + "," + testNodeB,
+			unhealthy:   []string{testNodeA, testNodeB},
+			wantPrimary: testNodeA,
+		},
+		{
+			name:        "all nodes unreachable",
+			addresses:   testNodeA + "," + testNodeB,
+			unreachable: []string{testNodeA, testNodeB},
+			wantErr:     nodebridge.ErrNoHealthyNode,
+		},
+		{
+			name:      "invalid address",
+			addresses: testNodeA + ",node-b",
+			wantErr:   nodebridge.ErrInvalidAddress,
+		},
+		{
+			name:      "no address",
+			addresses: " , ",
+			wantErr:   nodebridge.ErrInvalidAddress,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := map[string]*testNode{
+				testNodeA: newTestNode(t, true),
+				testNodeB: newTestNode(t, true),
+				testNodeC: newTestNode(t, true),
+			}
+			for _, address := range test.unhealthy {
+				require.NoError(t, nodes[address].SetHealthy(false))
+			}
+			for _, address := range test.unreachable {
+				nodes[address].connectErr = ierrors.Wrap(nodebridge.ErrUnavailable, "connection refused")
+			}
+
+			multiNodeBridge, err := runTestMultiNodeBridge(t, nodes, test.addresses)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.wantPrimary, multiNodeBridge.PrimaryAddress())
+		})
+	}
+}
+
+func TestMultiNodeBridgeFailover(t *testing.T) {
+	nodes := map[string]*testNode{
+		testNodeA: newTestNode(t, true),
+		testNodeB: newTestNode(t, true),
+	}
+
+	multiNodeBridge, err := runTestMultiNodeBridge(t, nodes, testNodeA+","+testNodeB)
+	require.NoError(t, err)
+	require.Equal(t, testNodeA, multiNodeBridge.PrimaryAddress())
+
+	primaryChanges := make(chan [2]string, 10)
+	hook := multiNodeBridge.MultiNodeEvents().PrimaryChanged.Hook(func(previous string, primary string) {
+		primaryChanges <- [2]string{previous, primary}
+	})
+	defer hook.Unhook()
+
+	// the primary node becomes unhealthy
+	require.NoError(t, nodes[testNodeA].SetHealthy(false))
+	require.Equal(t, testNodeB, multiNodeBridge.PrimaryAddress())
+	require.Equal(t, [2]string{testNodeA, testNodeB}, <-primaryChanges)
+
+	// the primary node is healthy again
+	require.NoError(t, nodes[testNodeA].SetHealthy(true))
+	require.Equal(t, testNodeA, multiNodeBridge.PrimaryAddress())
+	require.Equal(t, [2]string{testNodeB, testNodeA}, <-primaryChanges)
+
+	// the connection to the primary node is lost
+	nodes[testNodeA].disconnect()
+	require.Eventually(t, func() bool {
+		return multiNodeBridge.PrimaryAddress() == testNodeB
+	}, time.Second, time.Millisecond)
+	require.Equal(t, [2]string{testNodeA, testNodeB}, <-primaryChanges)
+
+	// the connection to the last node is lost
+	nodes[testNodeB].disconnect()
+	require.Eventually(t, func() bool {
+		return multiNodeBridge.PrimaryAddress() == ""
+	}, time.Second, time.Millisecond)
+	require.False(t, multiNodeBridge.IsNodeHealthy())
+
+	_, err = multiNodeBridge.Congestion(context.Background(), tpkg.RandAccountID())
+	require.ErrorIs(t, err, nodebridge.ErrNoHealthyNode)
+}
+
+func TestMultiNodeBridgeCallFailover(t *testing.T) {
+	accountID := tpkg.RandAccountID()
+	congestionA := &api.CongestionResponse{Slot: 1}
+	congestionB := &api.CongestionResponse{Slot: 2}
+
+	tests := []struct {
+		name           string
+		errA           error
+		errB           error
+		missingA       bool
+		wantCongestion *api.CongestionResponse
+		wantPrimary    string
+		wantErr        error
+	}{
+		{
+			name:           "primary node answers",
+			wantCongestion: congestionA,
+			wantPrimary:    testNodeA,
+		},
+		{
+			name:           "unavailable primary node is retried at the next node",
+			errA:           ierrors.Wrap(nodebridge.ErrUnavailable, "connection lost"),
+			wantCongestion: congestionB,
+			wantPrimary:    testNodeB,
+		},
+		{
+			name:        "other errors are not retried",
+			missingA:    true,
+			wantPrimary: testNodeA,
+			wantErr:     nodebridge.ErrNotFound,
+		},
+		{
+			name:        "too many requests are not retried",
+			errA:        ierrors.Wrap(nodebridge.ErrTooManyRequests, "congested"),
+			wantPrimary: testNodeA,
+			wantErr:     nodebridge.ErrTooManyRequests,
+		},
+		{
+			name:        "call is retried only once",
+			errA:        ierrors.Wrap(nodebridge.ErrUnavailable, "connection lost"),
+			errB:        ierrors.Wrap(nodebridge.ErrUnavailable, "connection lost"),
+			wantPrimary: testNodeB,
+			wantErr:     nodebridge.ErrUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := map[string]*testNode{
+				testNodeA: newTestNode(t, true),
+				testNodeB: newTestNode(t, true),
+			}
+			nodes[testNodeA].callErr = test.errA
+			nodes[testNodeB].callErr = test.errB
+			if !test.missingA {
+				nodes[testNodeA].SetCongestion(accountID, congestionA)
+			}
+			nodes[testNodeB].SetCongestion(accountID, congestionB)
+
+			multiNodeBridge, err := runTestMultiNodeBridge(t, nodes, testNodeA+","+testNodeB)
+			require.NoError(t, err)
+
+			congestion, err := multiNodeBridge.Congestion(context.Background(), accountID)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.wantCongestion, congestion)
+			}
+			require.Equal(t, test.wantPrimary, multiNodeBridge.PrimaryAddress())
+		})
+	}
+}
+
+func TestMultiNodeBridgeStreamFailover(t *testing.T) {
+	tests := []struct {
+		name            string
+		failAfterSlotA  iotago.SlotIndex
+		consumerErrSlot iotago.SlotIndex
+		wantSlots       []iotago.SlotIndex
+		wantPrimary     string
+		wantErr         error
+	}{
+		{
+			name:        "stream without interruption",
+			wantSlots:   []iotago.SlotIndex{1, 2, 3, 4},
+			wantPrimary: testNodeA,
+		},
+		{
+			name:           "interrupted stream resumes at the next node",
+			failAfterSlotA: 2,
+			wantSlots:      []iotago.SlotIndex{1, 2, 3, 4},
+			wantPrimary:    testNodeB,
+		},
+		{
+			name:           "stream interrupted after the last slot is complete",
+			failAfterSlotA: 4,
+			wantSlots:      []iotago.SlotIndex{1, 2, 3, 4},
+			wantPrimary:    testNodeA,
+		},
+		{
+			name:            "consumer error ends the stream without failover",
+			consumerErrSlot: 2,
+			wantSlots:       []iotago.SlotIndex{1},
+			wantPrimary:     testNodeA,
+			wantErr:         errTestConsumer,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := map[string]*testNode{
+				testNodeA: newTestNode(t, true),
+				testNodeB: newTestNode(t, true),
+			}
+			nodes[testNodeA].ledgerUpdatesFailAfterSlot = test.failAfterSlotA
+			nodes[testNodeA].addLedgerUpdates(1, 2, 3, 4)
+			nodes[testNodeB].addLedgerUpdates(1, 2, 3, 4)
+
+			multiNodeBridge, err := runTestMultiNodeBridge(t, nodes, testNodeA+","+testNodeB)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			var slots []iotago.SlotIndex
+			err = multiNodeBridge.ListenToLedgerUpdates(ctx, 1, 4, func(update *nodebridge.LedgerUpdate) error {
+				if update.CommitmentID.Slot() == test.consumerErrSlot {
+					return errTestConsumer
+				}
+				slots = append(slots, update.CommitmentID.Slot())
+
+				return nil
+			})
+			require.NoError(t, ctx.Err(), "stream did not end")
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.wantSlots, slots)
+			require.Equal(t, test.wantPrimary, multiNodeBridge.PrimaryAddress())
+		})
+	}
+}
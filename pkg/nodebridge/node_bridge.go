@@ -8,9 +8,12 @@ import (
 
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 
 	"github.com/iotaledger/hive.go/ierrors"
@@ -23,9 +26,15 @@ import (
 	"github.com/iotaledger/iota.go/v4/nodeclient"
 )
 
+// NodeBridge is the connection of an INX extension to the node.
+// gRPC errors returned by the node are wrapped with ErrNotFound, ErrUnavailable, ErrAlreadyExists, ErrTooManyRequests or ErrMessageTooLarge.
 type NodeBridge interface {
 	// Events returns the events.
 	Events() *Events
+	// StreamEvents returns the events that are triggered for the items of the subscribed INX streams, see WithStreamEvents.
+	StreamEvents() *StreamEvents
+	// Stats returns the progress of the consumers of the active streams, ordered by the name of the stream.
+	Stats() []*StreamStats
 	// Connect connects to the given address and reads the node configuration.
 	Connect(ctx context.Context, address string, maxConnectionAttempts uint) error
 	// Run starts the node bridge.
@@ -36,6 +45,12 @@ type NodeBridge interface {
 	NodeConfig() *inx.NodeConfiguration
 	// APIProvider returns the APIProvider.
 	APIProvider() iotago.APIProvider
+	// ProtocolParametersHistory returns all protocol parameters known to the node, ordered by their start epoch.
+	ProtocolParametersHistory() ([]*ProtocolParametersUpdate, error)
+	// ProtocolParametersForEpoch returns the protocol parameters that are valid in the given epoch.
+	ProtocolParametersForEpoch(epoch iotago.EpochIndex) (iotago.ProtocolParameters, error)
+	// ListenToProtocolParameterUpdates passes protocol parameters that become active in a future epoch to the consumer.
+	ListenToProtocolParameterUpdates(ctx context.Context, pollInterval time.Duration, consumer func(update *ProtocolParametersUpdate) error) error
 
 	// INXNodeClient returns the NodeClient.
 	INXNodeClient() (*nodeclient.Client, error)
@@ -58,9 +73,25 @@ type NodeBridge interface {
 	ReadIsCommitteeMember(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error)
 	// ReadIsValidatorAccount returns true if the given account is a validator account.
 	ReadIsValidatorAccount(ctx context.Context, id iotago.AccountID, slot iotago.SlotIndex) (bool, error)
+	// ReadCommittee returns the committee of the given epoch.
+	ReadCommittee(ctx context.Context, epoch iotago.EpochIndex) (*api.CommitteeResponse, error)
+	// ReadValidators returns a page of the validators of the given epoch, starting at the cursor (empty for the first page).
+	ReadValidators(ctx context.Context, epoch iotago.EpochIndex, cursor string) (*api.ValidatorsResponse, error)
+	// ReadAllValidators returns all validators of the given epoch.
+	ReadAllValidators(ctx context.Context, epoch iotago.EpochIndex) ([]*api.ValidatorResponse, error)
+	// StakingInfo returns the staking data of the given validator account.
+	StakingInfo(ctx context.Context, accountID iotago.AccountID) (*api.ValidatorResponse, error)
+	// RewardsForOutput returns the mana rewards the given staking account or delegation output can claim in the given epoch (0 for the latest).
+	RewardsForOutput(ctx context.Context, outputID iotago.OutputID, claimEpoch iotago.EpochIndex) (*api.ManaRewardsResponse, error)
+	// Congestion returns the congestion of the given account, which contains the reference mana cost,
+	// the block issuance credits of the account and the slot of the estimate.
+	Congestion(ctx context.Context, accountID iotago.AccountID) (*api.CongestionResponse, error)
+	// ListenToCongestion passes the congestion of the given account to the consumer for every new commitment.
+	ListenToCongestion(ctx context.Context, accountID iotago.AccountID, consumer func(congestion *api.CongestionResponse) error) error
 
 	// RegisterAPIRoute registers the given API route.
-	RegisterAPIRoute(ctx context.Context, route string, bindAddress string, path string) error
+	// The address is the address on which the node reaches the extension, see ParseAPIRouteAddress.
+	RegisterAPIRoute(ctx context.Context, route string, address string, path string) error
 	// UnregisterAPIRoute unregisters the given API route.
 	UnregisterAPIRoute(ctx context.Context, route string) error
 
@@ -70,20 +101,43 @@ type NodeBridge interface {
 	SubmitBlock(ctx context.Context, block *iotago.Block) (iotago.BlockID, error)
 	// Block returns the block for the given block ID.
 	Block(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, error)
+	// BlockWithRaw returns the block for the given block ID and its raw serialized bytes as sent by the node.
+	BlockWithRaw(ctx context.Context, blockID iotago.BlockID) (*iotago.Block, []byte, error)
+	// BlockRaw returns the raw serialized bytes of the block for the given block ID as sent by the node.
+	BlockRaw(ctx context.Context, blockID iotago.BlockID) ([]byte, error)
 	// BlockMetadata returns the block metadata for the given block ID.
 	BlockMetadata(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error)
 	// ListenToBlocks listens to blocks.
 	ListenToBlocks(ctx context.Context, consumer func(block *iotago.Block, rawData []byte) error) error
+	// ListenToBlocksFiltered listens to the blocks that match the given filter.
+	ListenToBlocksFiltered(ctx context.Context, filter *BlockFilter, consumer func(block *iotago.Block, rawData []byte) error) error
+	// ListenToBlocksBuffered listens to blocks and passes them to the consumer via a buffer of the given size.
+	ListenToBlocksBuffered(ctx context.Context, bufferSize int, dropPolicy BufferDropPolicy, consumer func(block *iotago.Block, rawData []byte) error) error
 	// ListenToAcceptedBlocks listens to accepted blocks.
 	ListenToAcceptedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error
 	// ListenToConfirmedBlocks listens to confirmed blocks.
 	ListenToConfirmedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error
+	// ListenToFinalizedBlocks listens to blocks whose slot was finalized.
+	ListenToFinalizedBlocks(ctx context.Context, consumer func(blockMetadata *api.BlockMetadataResponse) error) error
 
 	// TransactionMetadata returns the transaction metadata for the given transaction ID.
 	TransactionMetadata(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error)
+	// TransactionMetadataBatch returns the transaction metadata for the given transaction IDs, looked up concurrently.
+	// If some of the lookups failed, the successful ones are returned together with a TransactionMetadataBatchError.
+	TransactionMetadataBatch(ctx context.Context, transactionIDs []iotago.TransactionID) (map[iotago.TransactionID]*api.TransactionMetadataResponse, error)
+	// TransactionOutputs returns the outputs created by the transaction with the given transaction ID,
+	// and the outputs consumed by it if they are still known to the node.
+	TransactionOutputs(ctx context.Context, transactionID iotago.TransactionID) (*TransactionOutputs, error)
+	// InclusionProof returns the block that included the transaction with the given transaction ID,
+	// the output ID proofs of all outputs created by the transaction and the commitment of the slot of the block.
+	InclusionProof(ctx context.Context, transactionID iotago.TransactionID) (*InclusionProof, error)
 
 	// Output returns the output with metadata for the given output ID.
 	Output(ctx context.Context, outputID iotago.OutputID) (*Output, error)
+	// Outputs returns the outputs with metadata for the given output IDs in the same order.
+	Outputs(ctx context.Context, outputIDs []iotago.OutputID) ([]*Output, error)
+	// OutputAtSlot returns the state of the output for the given output ID as of the given slot.
+	OutputAtSlot(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (*OutputSlotState, error)
 
 	// ForceCommitUntil forces the node to commit until the given slot.
 	ForceCommitUntil(ctx context.Context, slot iotago.SlotIndex) error
@@ -91,14 +145,35 @@ type NodeBridge interface {
 	Commitment(ctx context.Context, slot iotago.SlotIndex) (*Commitment, error)
 	// CommitmentByID returns the commitment for the given commitment ID.
 	CommitmentByID(ctx context.Context, id iotago.CommitmentID) (*Commitment, error)
+	// CommitmentRaw returns the commitment for the given commitment ID and its raw serialized bytes as sent by the node.
+	CommitmentRaw(ctx context.Context, id iotago.CommitmentID) (*Commitment, []byte, error)
+	// CommitmentRoots returns the roots of the given commitment, verified against the commitment.
+	CommitmentRoots(ctx context.Context, id iotago.CommitmentID) (*CommitmentRoots, error)
+	// BlockInclusionProof returns the verified proof that the given block was accepted in its slot.
+	BlockInclusionProof(ctx context.Context, blockID iotago.BlockID) (*BlockInclusionProof, error)
+	// ReadSlotData returns the commitment, the accepted blocks and the ledger update of the given committed slot.
+	ReadSlotData(ctx context.Context, slot iotago.SlotIndex, opts ...options.Option[SlotDataOptions]) (*SlotData, error)
 	// ListenToCommitments listens to commitments.
 	ListenToCommitments(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error
+	// ListenToCommitmentsChained listens to commitments and guarantees that every commitment references the previously delivered one.
+	ListenToCommitmentsChained(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(commitment *Commitment, rawData []byte) error) error
 
 	// ListenToLedgerUpdates listens to ledger updates.
 	ListenToLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(update *LedgerUpdate) error) error
+	// ListenToFilteredLedgerUpdates listens to ledger updates that only contain the outputs matching the given filter.
+	ListenToFilteredLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *OutputFilter, consumer func(update *LedgerUpdate) error) error
+	// SyncLedger streams the current unspent outputs to the handler and afterwards
+	// follows the ledger updates starting right after the commitment of the bootstrap ledger state.
+	SyncLedger(ctx context.Context, handler LedgerSyncHandler) error
+	// ListenToAccountChanges listens to the changes of accounts per committed slot.
+	ListenToAccountChanges(ctx context.Context, startSlot, endSlot iotago.SlotIndex, consumer func(changes *AccountChanges) error) error
+	// ListenToAddressActivity listens to the outputs that were created for or spent by the addresses in the given set per committed slot.
+	ListenToAddressActivity(ctx context.Context, startSlot, endSlot iotago.SlotIndex, addresses *AddressSet, consumer func(activities *AddressActivities) error) error
 	// ListenToAcceptedTransactions listens to accepted transactions.
 	ListenToAcceptedTransactions(ctx context.Context, consumer func(tx *AcceptedTransaction) error) error
 
+	// ListenToNodeStatus listens to node status updates.
+	ListenToNodeStatus(ctx context.Context, cooldown time.Duration, consumer func(status *inx.NodeStatus) error) error
 	// NodeStatus returns the current node status.
 	NodeStatus() *inx.NodeStatus
 	// IsNodeHealthy returns true if the node is healthy.
@@ -120,13 +195,59 @@ type nodeBridge struct {
 	// the logger used to log events.
 	log.Logger
 
-	targetNetworkName string
-	events            *Events
-
-	conn        *grpc.ClientConn
-	client      inx.INXClient
-	nodeConfig  *inx.NodeConfiguration
-	apiProvider *iotago.EpochBasedProvider
+	targetNetworkName  string
+	ledgerMirror       LedgerMirror
+	outputCache        *OutputCache
+	outputsConcurrency int
+	retryPolicies      map[string]*RetryPolicy
+	defaultRetryPolicy *RetryPolicy
+	events             *Events
+
+	streamEvents             *StreamEvents
+	streamEventSubscriptions StreamEventSubscriptions
+	streamStats              *streamStatsTracker
+
+	skipOutputProofVerification   bool
+	ledgerUpdateUnwrapConcurrency int
+
+	// apiRoutes are the registered API routes, which are re-registered if the connection to the node was re-established.
+	apiRoutesMutex sync.Mutex
+	apiRoutes      map[string]*inx.APIRouteRequest
+
+	// requiredCapabilities are checked at Connect.
+	requiredCapabilities []Capability
+
+	streamReconnectInterval    time.Duration
+	streamReconnectMaxInterval time.Duration
+	streamReconnectMaxAttempts uint
+	streamWatchdogInterval     time.Duration
+	streamWatchdogRestart      bool
+
+	keepaliveParams *keepalive.ClientParameters
+	callTimeout     time.Duration
+	dialer          DialerFunc
+	maxRecvMsgSize  int
+	maxSendMsgSize  int
+
+	// readTimeout, writeTimeout and streamEstablishTimeout are the default timeouts per call class.
+	readTimeout            time.Duration
+	writeTimeout           time.Duration
+	streamEstablishTimeout time.Duration
+
+	protocolParametersPollInterval time.Duration
+	transactionMetadataConcurrency int
+
+	// tracerProvider is nil if the tracing is disabled.
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer
+
+	conn            *grpc.ClientConn
+	client          inx.INXClient
+	nodeConfigMutex sync.RWMutex
+	nodeConfig      *inx.NodeConfiguration
+	apiProvider     iotago.APIProvider
+	// customAPIProvider is true if the APIProvider was set via WithAPIProvider.
+	customAPIProvider bool
 
 	nodeStatusMutex           sync.RWMutex
 	nodeStatus                *inx.NodeStatus
@@ -137,6 +258,31 @@ type nodeBridge struct {
 type Events struct {
 	LatestCommitmentChanged          *event.Event1[*Commitment]
 	LatestFinalizedCommitmentChanged *event.Event1[*Commitment]
+	// ConnectionStateChanged is triggered with the new state if the state of the gRPC connection to the node changed.
+	ConnectionStateChanged *event.Event1[connectivity.State]
+	// NodeHealthChanged is triggered with the new health if the health of the node changed.
+	NodeHealthChanged *event.Event1[bool]
+	// NodeSyncedChanged is triggered with the new sync state if the node became synced (bootstrapped) or lost its sync.
+	NodeSyncedChanged *event.Event1[bool]
+	// PruningEpochChanged is triggered with the new pruning epoch if the node pruned an epoch.
+	PruningEpochChanged *event.Event1[iotago.EpochIndex]
+	// ProtocolParametersAnnounced is triggered if the node announced protocol parameters that become active in a future epoch.
+	ProtocolParametersAnnounced *event.Event1[*ProtocolParametersUpdate]
+	// StreamStale is triggered with the name of the stream and the time since its last item
+	// if the stream watchdog detected a stale stream.
+	StreamStale *event.Event2[string, time.Duration]
+	// StreamItemsDropped is triggered with the name of the stream and the total amount of dropped items
+	// if a buffered stream dropped an item because its consumer could not keep up.
+	StreamItemsDropped *event.Event2[string, uint64]
+	// APIRouteReregistered is triggered with the route and the error (nil on success)
+	// if an API route was re-registered after the connection to the node was re-established, e.g. after a node restart.
+	APIRouteReregistered *event.Event2[string, error]
+	// CommitmentGapDetected is triggered with the first and the last missing slot
+	// if ListenToCommitmentsChained detected missing commitments that are backfilled.
+	CommitmentGapDetected *event.Event2[iotago.SlotIndex, iotago.SlotIndex]
+	// CommitmentChainBroken is triggered if ListenToCommitmentsChained received a commitment
+	// that does not reference the previously delivered commitment.
+	CommitmentChainBroken *event.Event1[*CommitmentChainError]
 }
 
 // WithTargetNetworkName checks if the network name of the node is equal to the given targetNetworkName.
@@ -147,15 +293,56 @@ func WithTargetNetworkName(targetNetworkName string) options.Option[nodeBridge]
 	}
 }
 
+// WithAPIProvider sets a custom APIProvider that is used instead of the one derived from the node configuration.
+func WithAPIProvider(apiProvider iotago.APIProvider) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.apiProvider = apiProvider
+		n.customAPIProvider = true
+	}
+}
+
+// WithStaticAPI sets an APIProvider that always returns the given API.
+func WithStaticAPI(api iotago.API) options.Option[nodeBridge] {
+	return WithAPIProvider(iotago.SingleVersionProvider(api))
+}
+
+// WithLedgerMirror sets the LedgerMirror that is used to resolve outputs which are no longer known to the node.
+func WithLedgerMirror(ledgerMirror LedgerMirror) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.ledgerMirror = ledgerMirror
+	}
+}
+
 func New(log log.Logger, opts ...options.Option[nodeBridge]) NodeBridge {
 	return options.Apply(&nodeBridge{
-		Logger:            log,
-		targetNetworkName: "",
+		Logger:                         log,
+		targetNetworkName:              "",
+		retryPolicies:                  make(map[string]*RetryPolicy),
+		outputsConcurrency:             DefaultOutputsConcurrency,
+		ledgerUpdateUnwrapConcurrency:  DefaultLedgerUpdateUnwrapConcurrency,
+		transactionMetadataConcurrency: DefaultTransactionMetadataConcurrency,
+		protocolParametersPollInterval: DefaultProtocolParametersPollInterval,
+		maxRecvMsgSize:                 DefaultMaxRecvMsgSize,
+		maxSendMsgSize:                 DefaultMaxSendMsgSize,
 		events: &Events{
 			LatestCommitmentChanged:          event.New1[*Commitment](),
 			LatestFinalizedCommitmentChanged: event.New1[*Commitment](),
+			ConnectionStateChanged:           event.New1[connectivity.State](),
+			NodeHealthChanged:                event.New1[bool](),
+			NodeSyncedChanged:                event.New1[bool](),
+			PruningEpochChanged:              event.New1[iotago.EpochIndex](),
+			ProtocolParametersAnnounced:      event.New1[*ProtocolParametersUpdate](),
+			StreamStale:                      event.New2[string, time.Duration](),
+			StreamItemsDropped:               event.New2[string, uint64](),
+			APIRouteReregistered:             event.New2[string, error](),
+			CommitmentGapDetected:            event.New2[iotago.SlotIndex, iotago.SlotIndex](),
+			CommitmentChainBroken:            event.New1[*CommitmentChainError](),
 		},
-		apiProvider: iotago.NewEpochBasedProvider(),
+		streamEvents: NewStreamEvents(),
+		streamStats:  newStreamStatsTracker(),
+		apiRoutes:    make(map[string]*inx.APIRouteRequest),
+		apiProvider:  iotago.NewEpochBasedProvider(),
+		tracer:       noopTracer,
 	}, opts)
 }
 
@@ -165,12 +352,18 @@ func (n *nodeBridge) Events() *Events {
 }
 
 // Connect connects to the given address and reads the node configuration.
+// The address is either "host:port", "tcp://host:port" or "unix:///path/to/socket", see ParseAddress.
 func (n *nodeBridge) Connect(ctx context.Context, address string, maxConnectionAttempts uint) error {
-	conn, err := grpc.Dial(address,
-		grpc.WithChainUnaryInterceptor(grpcretry.UnaryClientInterceptor(), grpcprometheus.UnaryClientInterceptor),
-		grpc.WithStreamInterceptor(grpcprometheus.StreamClientInterceptor),
+	target, err := n.dialTarget(address)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(target, append([]grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(requestIDUnaryClientInterceptor, n.callTimeoutUnaryClientInterceptor, n.retryPolicyUnaryClientInterceptor, grpcretry.UnaryClientInterceptor(), grpcprometheus.UnaryClientInterceptor, errorWrappingUnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(requestIDStreamClientInterceptor, n.streamEstablishTimeoutStreamClientInterceptor, grpcprometheus.StreamClientInterceptor, errorWrappingStreamClientInterceptor),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	}, n.dialOptions()...)...)
 	if err != nil {
 		return err
 	}
@@ -187,9 +380,13 @@ func (n *nodeBridge) Connect(ctx context.Context, address string, maxConnectionA
 	if err != nil {
 		return err
 	}
+	n.nodeConfigMutex.Lock()
 	n.nodeConfig = nodeConfig
+	n.nodeConfigMutex.Unlock()
 
-	n.apiProvider = nodeConfig.APIProvider()
+	if !n.customAPIProvider {
+		n.apiProvider = nodeConfig.APIProvider()
+	}
 
 	if n.targetNetworkName != "" {
 		// we need to check for the correct target network name
@@ -198,6 +395,13 @@ func (n *nodeBridge) Connect(ctx context.Context, address string, maxConnectionA
 		}
 	}
 
+	if len(n.requiredCapabilities) > 0 {
+		n.LogInfo("Checking required node capabilities ...")
+		if err := n.checkRequiredCapabilities(ctx); err != nil {
+			return err
+		}
+	}
+
 	n.LogInfo("Reading node status ...")
 	nodeStatus, err := n.client.ReadNodeStatus(ctx, &inx.NoParams{})
 	if err != nil {
@@ -209,16 +413,30 @@ func (n *nodeBridge) Connect(ctx context.Context, address string, maxConnectionA
 
 // Run starts the node bridge.
 func (n *nodeBridge) Run(ctx context.Context) {
-	c, cancel := context.WithCancel(ctx)
+	streamGroup := NewStreamGroup(ctx, DefaultStreamGroupDrainTimeout)
+	streamGroup.Go("node status", n.listenToNodeStatus)
+	streamGroup.Go("connection state", n.watchConnectionState)
+	if n.outputCache != nil {
+		streamGroup.Go("output cache", n.listenToOutputCacheUpdates)
+	}
+	if n.protocolParametersPollInterval > 0 {
+		streamGroup.Go("protocol parameters", n.listenToProtocolParameterUpdates)
+	}
+	if n.streamEventSubscriptions != 0 {
+		streamGroup.Go("stream events", func(ctx context.Context) error {
+			return RunStreamEvents(ctx, n, n.streamEvents, n.streamEventSubscriptions)
+		})
+	}
 
-	go func() {
-		if err := n.listenToNodeStatus(c); err != nil {
-			n.LogErrorf("Error listening to node status: %s", err)
+	if err := streamGroup.Wait(); err != nil {
+		var streamErr *StreamGroupError
+		if ierrors.As(err, &streamErr) {
+			n.LogErrorf("Error listening to %s: %s", streamErr.Name, streamErr.Err)
+		} else {
+			n.LogErrorf("Error listening to node streams: %s", err)
 		}
-		cancel()
-	}()
+	}
 
-	<-c.Done()
 	_ = n.conn.Close()
 }
 
@@ -229,6 +447,9 @@ func (n *nodeBridge) Client() inx.INXClient {
 
 // NodeConfig returns the NodeConfiguration.
 func (n *nodeBridge) NodeConfig() *inx.NodeConfiguration {
+	n.nodeConfigMutex.RLock()
+	defer n.nodeConfigMutex.RUnlock()
+
 	return n.nodeConfig
 }
 
@@ -2,6 +2,7 @@ package nodebridge
 
 import (
 	"context"
+	"time"
 
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
@@ -45,20 +46,40 @@ func (n *nodeBridge) PruningEpoch() iotago.EpochIndex {
 	return iotago.EpochIndex(n.NodeStatus().GetPruningEpoch())
 }
 
-func (n *nodeBridge) listenToNodeStatus(ctx context.Context) error {
-	stream, err := n.client.ListenToNodeStatus(ctx, &inx.NodeStatusRequest{CooldownInMilliseconds: ListenToNodeStatusCooldownInMilliseconds})
+// ListenToNodeStatus listens to node status updates.
+// The node sends at most one update per cooldown.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost.
+func (n *nodeBridge) ListenToNodeStatus(ctx context.Context, cooldown time.Duration, consumer func(status *inx.NodeStatus) error) error {
+	return n.listenWithReconnect(ctx, "ListenToNodeStatus", func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error {
+		return n.listenToNodeStatusStream(ctx, cooldown, func(status *inx.NodeStatus) error {
+			if err := consumer(status); err != nil {
+				return err
+			}
+			delivered(status.GetLatestCommitment().GetCommitmentId().Unwrap().Slot())
+
+			return nil
+		})
+	})
+}
+
+func (n *nodeBridge) listenToNodeStatusStream(ctx context.Context, cooldown time.Duration, consumer func(status *inx.NodeStatus) error) error {
+	stream, err := n.client.ListenToNodeStatus(ctx, &inx.NodeStatusRequest{CooldownInMilliseconds: uint32(cooldown.Milliseconds())})
 	if err != nil {
 		return err
 	}
 
-	if err := ListenToStream(ctx, stream.Recv, n.processNodeStatus); err != nil {
-		n.LogErrorf("listenToNodeStatus failed: %s", err.Error())
+	if err := ListenToStream(ctx, stream.Recv, consumer); err != nil {
+		n.LogErrorf("ListenToNodeStatus failed: %s", err.Error())
 		return err
 	}
 
 	return nil
 }
 
+func (n *nodeBridge) listenToNodeStatus(ctx context.Context) error {
+	return n.ListenToNodeStatus(ctx, ListenToNodeStatusCooldownInMilliseconds*time.Millisecond, n.processNodeStatus)
+}
+
 func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 	var latestCommitment *Commitment
 	var latestCommitmentChanged bool
@@ -66,6 +87,8 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 	var latestFinalizedCommitment *Commitment
 	var latestFinalizedCommitmentChanged bool
 
+	var healthChanged, syncedChanged, pruningEpochChanged bool
+
 	updateStatus := func() error {
 		n.nodeStatusMutex.Lock()
 		defer n.nodeStatusMutex.Unlock()
@@ -83,6 +106,7 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 				latestFinalizedCommitmentChanged = true
 			}
 		}
+		healthChanged, syncedChanged, pruningEpochChanged = NodeStatusChanges(n.nodeStatus, nodeStatus)
 		n.nodeStatus = nodeStatus
 
 		return nil
@@ -94,7 +118,9 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 
 	if latestCommitmentChanged {
 		slot := latestCommitment.CommitmentID.Slot()
-		n.apiProvider.SetCommittedSlot(slot)
+		if committedSlotSetter, ok := n.apiProvider.(interface{ SetCommittedSlot(slot iotago.SlotIndex) }); ok {
+			committedSlotSetter.SetCommittedSlot(slot)
+		}
 
 		n.events.LatestCommitmentChanged.Trigger(latestCommitment)
 	}
@@ -103,5 +129,30 @@ func (n *nodeBridge) processNodeStatus(nodeStatus *inx.NodeStatus) error {
 		n.events.LatestFinalizedCommitmentChanged.Trigger(latestFinalizedCommitment)
 	}
 
+	if healthChanged {
+		n.events.NodeHealthChanged.Trigger(nodeStatus.GetIsHealthy())
+	}
+
+	if syncedChanged {
+		n.events.NodeSyncedChanged.Trigger(nodeStatus.GetIsBootstrapped())
+	}
+
+	if pruningEpochChanged {
+		n.events.PruningEpochChanged.Trigger(iotago.EpochIndex(nodeStatus.GetPruningEpoch()))
+	}
+
 	return nil
 }
+
+// NodeStatusChanges compares two node status updates and returns whether the health,
+// the sync state (the node is synced if it is bootstrapped) or the pruning epoch changed.
+// Nothing is reported as changed if there is no previous status.
+func NodeStatusChanges(previous *inx.NodeStatus, current *inx.NodeStatus) (healthChanged bool, syncedChanged bool, pruningEpochChanged bool) {
+	if previous == nil || current == nil {
+		return false, false, false
+	}
+
+	return previous.GetIsHealthy() != current.GetIsHealthy(),
+		previous.GetIsBootstrapped() != current.GetIsBootstrapped(),
+		previous.GetPruningEpoch() != current.GetPruningEpoch()
+}
@@ -0,0 +1,173 @@
+package nodebridge
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// OutputCacheMetrics contains the metrics of an OutputCache.
+type OutputCacheMetrics struct {
+	// Hits is the amount of output lookups that were served by the cache.
+	Hits uint64
+	// Misses is the amount of output lookups that had to be requested from the node.
+	Misses uint64
+	// Size is the current amount of cached outputs.
+	Size int
+}
+
+// OutputCache is an in-memory LRU cache of outputs that is kept consistent via the ledger updates of the node.
+// The cache is only used while the node bridge is running and listening to ledger updates.
+// The LatestCommitmentID in the metadata of cached outputs is not updated.
+type OutputCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[iotago.OutputID]*list.Element
+	lru     *list.List
+	// appliedSlot is the slot of the last ledger update that was applied to the cache.
+	appliedSlot iotago.SlotIndex
+
+	// tracking is true once the cache received the first ledger update of the node and until the stream ends.
+	tracking atomic.Bool
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+}
+
+// NewOutputCache creates a new OutputCache that holds at most size outputs.
+func NewOutputCache(size int) *OutputCache {
+	return &OutputCache{
+		size:    size,
+		entries: make(map[iotago.OutputID]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// WithOutputCache sets the OutputCache that is used to serve repeated Output lookups locally.
+func WithOutputCache(outputCache *OutputCache) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.outputCache = outputCache
+	}
+}
+
+// Get returns the cached output for the given output ID.
+func (c *OutputCache) Get(outputID iotago.OutputID) (*Output, bool) {
+	if !c.tracking.Load() {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[outputID]
+	if !exists {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	c.lru.MoveToFront(element)
+
+	//nolint:forcetypeassert // we only store *Output in the list
+	return element.Value.(*Output), true
+}
+
+// Put adds the given output to the cache.
+// Outputs that were read before the last applied ledger update are not added,
+// because the update might have consumed them after they were read.
+func (c *OutputCache) Put(output *Output) {
+	if !c.tracking.Load() {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if output.Metadata == nil || output.Metadata.LatestCommitmentID.Slot() < c.appliedSlot {
+		return
+	}
+
+	c.put(output)
+}
+
+func (c *OutputCache) put(output *Output) {
+	if element, exists := c.entries[output.OutputID]; exists {
+		element.Value = output
+		c.lru.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[output.OutputID] = c.lru.PushFront(output)
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+
+		//nolint:forcetypeassert // we only store *Output in the list
+		delete(c.entries, oldest.Value.(*Output).OutputID)
+	}
+}
+
+// ApplyLedgerUpdate updates the cached outputs with the given ledger update.
+// Created outputs are added to the cache, consumed outputs are only updated if they are already cached.
+func (c *OutputCache) ApplyLedgerUpdate(update *LedgerUpdate) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.appliedSlot = update.CommitmentID.Slot()
+
+	for _, output := range update.Consumed {
+		if _, exists := c.entries[output.OutputID]; exists {
+			c.put(output)
+		}
+	}
+
+	for _, output := range update.Created {
+		c.put(output)
+	}
+}
+
+// Reset removes all outputs from the cache.
+func (c *OutputCache) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[iotago.OutputID]*list.Element)
+	c.lru.Init()
+	c.appliedSlot = 0
+}
+
+// Metrics returns the current metrics of the cache.
+func (c *OutputCache) Metrics() OutputCacheMetrics {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return OutputCacheMetrics{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   c.lru.Len(),
+	}
+}
+
+// listenToOutputCacheUpdates keeps the output cache consistent with the ledger of the node.
+// The cache is only used after the first ledger update was received, because outputs that are cached
+// before the stream is subscribed could miss updates. The cache is reset if the ledger updates stream ends,
+// because updates might be missed afterwards.
+func (n *nodeBridge) listenToOutputCacheUpdates(ctx context.Context) error {
+	defer func() {
+		n.outputCache.tracking.Store(false)
+		n.outputCache.Reset()
+	}()
+
+	return n.ListenToLedgerUpdates(ctx, 0, 0, func(update *LedgerUpdate) error {
+		n.outputCache.ApplyLedgerUpdate(update)
+		n.outputCache.tracking.Store(true)
+
+		return nil
+	})
+}
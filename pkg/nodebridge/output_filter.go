@@ -0,0 +1,159 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// OutputFilter decides which outputs of the ledger updates are passed to the consumer.
+// All configured conditions have to match. A filter without conditions matches all outputs.
+//
+// The output types are checked on the raw output data before the output is decoded,
+// all other conditions are checked before the output ID proof and the metadata are unwrapped.
+type OutputFilter struct {
+	outputTypes map[iotago.OutputType]struct{}
+	predicates  []func(output iotago.Output) bool
+}
+
+// NewOutputFilter creates a new OutputFilter.
+func NewOutputFilter(opts ...options.Option[OutputFilter]) *OutputFilter {
+	return options.Apply(&OutputFilter{}, opts)
+}
+
+// WithOutputTypes only matches outputs of the given types.
+func WithOutputTypes(outputTypes ...iotago.OutputType) options.Option[OutputFilter] {
+	return func(f *OutputFilter) {
+		if f.outputTypes == nil {
+			f.outputTypes = make(map[iotago.OutputType]struct{}, len(outputTypes))
+		}
+
+		for _, outputType := range outputTypes {
+			f.outputTypes[outputType] = struct{}{}
+		}
+	}
+}
+
+// WithOutputAddress only matches outputs that contain the given address in one of their unlock conditions.
+func WithOutputAddress(address iotago.Address) options.Option[OutputFilter] {
+	return WithOutputPredicate(func(output iotago.Output) bool {
+		for _, unlockConditionAddress := range unlockConditionAddresses(output.UnlockConditionSet()) {
+			if unlockConditionAddress.Equal(address) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// WithOutputNativeToken only matches outputs that hold the native token with the given ID.
+func WithOutputNativeToken(nativeTokenID iotago.NativeTokenID) options.Option[OutputFilter] {
+	return WithOutputPredicate(func(output iotago.Output) bool {
+		nativeToken := output.FeatureSet().NativeToken()
+
+		return nativeToken != nil && nativeToken.ID == nativeTokenID
+	})
+}
+
+// WithOutputMinStoredMana only matches outputs that hold at least the given amount of stored mana.
+func WithOutputMinStoredMana(mana iotago.Mana) options.Option[OutputFilter] {
+	return WithOutputPredicate(func(output iotago.Output) bool {
+		return output.StoredMana() >= mana
+	})
+}
+
+// WithOutputPredicate only matches outputs for which the given predicate returns true.
+func WithOutputPredicate(predicate func(output iotago.Output) bool) options.Option[OutputFilter] {
+	return func(f *OutputFilter) {
+		f.predicates = append(f.predicates, predicate)
+	}
+}
+
+// Matches returns true if the given output matches all conditions of the filter.
+func (f *OutputFilter) Matches(output iotago.Output) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.outputTypes != nil {
+		if _, exists := f.outputTypes[output.Type()]; !exists {
+			return false
+		}
+	}
+
+	return f.matchesPredicates(output)
+}
+
+// matchesRaw checks the filter against the raw output data.
+// The output is only decoded if there are conditions besides the output types.
+func (f *OutputFilter) matchesRaw(api iotago.API, rawOutputData []byte) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if f.outputTypes != nil {
+		// the first byte of a serialized output is its type
+		if len(rawOutputData) == 0 {
+			return false, nil
+		}
+
+		if _, exists := f.outputTypes[iotago.OutputType(rawOutputData[0])]; !exists {
+			return false, nil
+		}
+	}
+
+	if len(f.predicates) == 0 {
+		return true, nil
+	}
+
+	var output iotago.TxEssenceOutput
+	if _, err := api.Decode(rawOutputData, &output); err != nil {
+		return false, err
+	}
+
+	return f.matchesPredicates(output), nil
+}
+
+func (f *OutputFilter) matchesPredicates(output iotago.Output) bool {
+	for _, predicate := range f.predicates {
+		if !predicate(output) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// unlockConditionAddresses returns all addresses that are contained in the given unlock conditions.
+func unlockConditionAddresses(unlockConditions iotago.UnlockConditionSet) []iotago.Address {
+	addresses := make([]iotago.Address, 0, len(unlockConditions))
+
+	for _, unlockCondition := range unlockConditions {
+		switch condition := unlockCondition.(type) {
+		case *iotago.AddressUnlockCondition:
+			addresses = append(addresses, condition.Address)
+		case *iotago.StateControllerAddressUnlockCondition:
+			addresses = append(addresses, condition.Address)
+		case *iotago.GovernorAddressUnlockCondition:
+			addresses = append(addresses, condition.Address)
+		case *iotago.ImmutableAccountUnlockCondition:
+			addresses = append(addresses, condition.Address)
+		case *iotago.ExpirationUnlockCondition:
+			addresses = append(addresses, condition.ReturnAddress)
+		case *iotago.StorageDepositReturnUnlockCondition:
+			addresses = append(addresses, condition.ReturnAddress)
+		}
+	}
+
+	return addresses
+}
+
+// ListenToFilteredLedgerUpdates listens to ledger updates that only contain the outputs matching the given filter.
+// The updates of all slots are passed to the consumer, even if none of their outputs matched.
+// If stream reconnect is enabled, the stream is re-subscribed if the connection to the node is lost
+// and resumes after the slot of the last received ledger update.
+func (n *nodeBridge) ListenToFilteredLedgerUpdates(ctx context.Context, startSlot, endSlot iotago.SlotIndex, filter *OutputFilter, consumer func(update *LedgerUpdate) error) error {
+	return n.listenToLedgerUpdates(ctx, startSlot, endSlot, filter, consumer)
+}
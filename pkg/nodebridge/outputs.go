@@ -2,13 +2,42 @@ package nodebridge
 
 import (
 	"context"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
 
 	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
 	iotaapi "github.com/iotaledger/iota.go/v4/api"
 )
 
+// DefaultOutputsConcurrency is the default maximum amount of concurrent output requests of Outputs.
+const DefaultOutputsConcurrency = 16
+
+// DefaultLedgerUpdateUnwrapConcurrency is the default amount of workers that unwrap the outputs of a ledger update.
+var DefaultLedgerUpdateUnwrapConcurrency = runtime.GOMAXPROCS(0)
+
+// LedgerMirror is a local copy of the ledger that is used to resolve outputs
+// which are no longer known to the node (e.g. because they were pruned).
+type LedgerMirror interface {
+	// Output returns the output with metadata for the given output ID.
+	Output(ctx context.Context, outputID iotago.OutputID) (*Output, error)
+}
+
+// OutputSlotState is the state of an output as of a given slot.
+type OutputSlotState struct {
+	// Output is the output with the latest known metadata.
+	Output *Output
+	// Slot is the slot the state was resolved for.
+	Slot iotago.SlotIndex
+	// Existed is true if the output was included in the ledger at or before the slot.
+	Existed bool
+	// Unspent is true if the output existed and was not spent at or before the slot.
+	Unspent bool
+}
+
 func (n *nodeBridge) unwrapOutput(inxOutput *inx.LedgerOutput, inxSpent *inx.LedgerSpent, latestCommitmentID iotago.CommitmentID) (*Output, error) {
 	outputID := inxOutput.UnwrapOutputID()
 
@@ -52,13 +81,10 @@ func (n *nodeBridge) unwrapOutput(inxOutput *inx.LedgerOutput, inxSpent *inx.Led
 		return nil, err
 	}
 
-	derivedOutputID, err := outputIDProof.OutputID(output)
-	if err != nil {
-		return nil, err
-	}
-
-	if derivedOutputID != outputID {
-		return nil, ierrors.Errorf("output ID mismatch. Expected %s, got %s", outputID.ToHex(), derivedOutputID.ToHex())
+	if !n.skipOutputProofVerification {
+		if err := verifyOutputIDProof(outputID, output, outputIDProof); err != nil {
+			return nil, err
+		}
 	}
 
 	return &Output{
@@ -70,8 +96,93 @@ func (n *nodeBridge) unwrapOutput(inxOutput *inx.LedgerOutput, inxSpent *inx.Led
 	}, nil
 }
 
+// verifyOutputIDProof checks that the output ID derived from the proof and the output matches the given output ID.
+func verifyOutputIDProof(outputID iotago.OutputID, output iotago.Output, outputIDProof *iotago.OutputIDProof) error {
+	derivedOutputID, err := outputIDProof.OutputID(output)
+	if err != nil {
+		return err
+	}
+
+	if derivedOutputID != outputID {
+		return ierrors.Errorf("output ID mismatch. Expected %s, got %s", outputID.ToHex(), derivedOutputID.ToHex())
+	}
+
+	return nil
+}
+
 // Output returns the output with metadata for the given output ID.
+// If an OutputCache is configured, repeated lookups are served from the cache.
 func (n *nodeBridge) Output(ctx context.Context, outputID iotago.OutputID) (*Output, error) {
+	if n.outputCache == nil {
+		return n.readOutput(ctx, outputID)
+	}
+
+	if output, exists := n.outputCache.Get(outputID); exists {
+		return output, nil
+	}
+
+	output, err := n.readOutput(ctx, outputID)
+	if err != nil {
+		return nil, err
+	}
+	n.outputCache.Put(output)
+
+	return output, nil
+}
+
+// WithOutputsConcurrency sets the maximum amount of concurrent output requests of Outputs.
+func WithOutputsConcurrency(concurrency int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.outputsConcurrency = concurrency
+	}
+}
+
+// WithSkipOutputProofVerification disables the verification of the output ID proofs of unwrapped outputs,
+// which saves a considerable amount of CPU time while processing ledger updates of a trusted local node.
+// The proofs can still be verified on demand via Output.VerifiedOutputIDProof.
+func WithSkipOutputProofVerification(skip bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.skipOutputProofVerification = skip
+	}
+}
+
+// WithLedgerUpdateUnwrapConcurrency sets the amount of workers that unwrap the outputs of a ledger update
+// or an accepted transaction in parallel. The outputs are unwrapped sequentially if the concurrency is 1.
+func WithLedgerUpdateUnwrapConcurrency(concurrency int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.ledgerUpdateUnwrapConcurrency = concurrency
+	}
+}
+
+// Outputs returns the outputs with metadata for the given output IDs in the same order.
+// The outputs are requested concurrently over the INX connection, bounded by the outputs concurrency.
+// If one of the outputs can't be read, the remaining requests are canceled and the error is returned.
+func (n *nodeBridge) Outputs(ctx context.Context, outputIDs []iotago.OutputID) ([]*Output, error) {
+	outputs := make([]*Output, len(outputIDs))
+
+	group, ctxGroup := errgroup.WithContext(ctx)
+	group.SetLimit(max(n.outputsConcurrency, 1))
+
+	for i, outputID := range outputIDs {
+		group.Go(func() error {
+			output, err := n.Output(ctxGroup, outputID)
+			if err != nil {
+				return ierrors.Wrapf(err, "failed to read output %s", outputID.ToHex())
+			}
+			outputs[i] = output
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+func (n *nodeBridge) readOutput(ctx context.Context, outputID iotago.OutputID) (*Output, error) {
 	inxOutputReponse, err := n.client.ReadOutput(ctx, inx.NewOutputId(outputID))
 	if err != nil {
 		return nil, err
@@ -86,3 +197,30 @@ func (n *nodeBridge) Output(ctx context.Context, outputID iotago.OutputID) (*Out
 
 	return n.unwrapOutput(inxOutput, inxSpent, inxOutputReponse.GetLatestCommitmentId().Unwrap())
 }
+
+// OutputAtSlot returns the state of the output for the given output ID as of the given slot.
+// If the node does not know the output anymore, the LedgerMirror is used as a fallback (if configured).
+// The state is derived from the inclusion and spent metadata, so it is only final for committed slots.
+func (n *nodeBridge) OutputAtSlot(ctx context.Context, outputID iotago.OutputID, slot iotago.SlotIndex) (*OutputSlotState, error) {
+	output, err := n.Output(ctx, outputID)
+	if err != nil {
+		if !ierrors.Is(err, ErrNotFound) || n.ledgerMirror == nil {
+			return nil, err
+		}
+
+		// the node doesn't know the output anymore, try the ledger mirror
+		if output, err = n.ledgerMirror.Output(ctx, outputID); err != nil {
+			return nil, ierrors.Wrapf(err, "unable to resolve output %s via ledger mirror", outputID.ToHex())
+		}
+	}
+
+	existed := output.Metadata.Included != nil && output.Metadata.Included.Slot <= slot
+	spent := output.Metadata.Spent != nil && output.Metadata.Spent.Slot <= slot
+
+	return &OutputSlotState{
+		Output:  output,
+		Slot:    slot,
+		Existed: existed,
+		Unspent: existed && !spent,
+	}, nil
+}
@@ -0,0 +1,181 @@
+package nodebridge
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// DefaultProtocolParametersPollInterval is the default interval in which the node configuration
+// is polled for announced protocol parameters.
+const DefaultProtocolParametersPollInterval = 1 * time.Minute
+
+// ErrProtocolParametersNotFound is returned if the node configuration contains no protocol parameters for an epoch.
+var ErrProtocolParametersNotFound = ierrors.New("protocol parameters not found")
+
+// ProtocolParametersUpdate is an entry of the protocol parameters history of the node.
+type ProtocolParametersUpdate struct {
+	// StartEpoch is the epoch the protocol parameters are activated at.
+	StartEpoch iotago.EpochIndex
+	// ProtocolParameters are the protocol parameters that are valid starting at StartEpoch.
+	ProtocolParameters iotago.ProtocolParameters
+}
+
+// WithProtocolParametersPollInterval sets the interval in which the node configuration is polled for announced protocol parameters.
+// The INX interface has no stream for protocol parameters, so upgrades are detected by re-reading the node configuration.
+// An interval of 0 disables the polling in Run.
+func WithProtocolParametersPollInterval(interval time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.protocolParametersPollInterval = interval
+	}
+}
+
+// ProtocolParametersHistory returns all protocol parameters of the given node configuration, ordered by their start epoch.
+func ProtocolParametersHistory(nodeConfig *inx.NodeConfiguration) ([]*ProtocolParametersUpdate, error) {
+	history := make([]*ProtocolParametersUpdate, 0, len(nodeConfig.GetProtocolParameters()))
+	for _, rawParams := range nodeConfig.GetProtocolParameters() {
+		startEpoch, protocolParameters, err := rawParams.Unwrap()
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "unable to unwrap protocol parameters of version %d", rawParams.GetProtocolVersion())
+		}
+
+		history = append(history, &ProtocolParametersUpdate{
+			StartEpoch:         startEpoch,
+			ProtocolParameters: protocolParameters,
+		})
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].StartEpoch < history[j].StartEpoch
+	})
+
+	return history, nil
+}
+
+// ProtocolParametersHistory returns all protocol parameters known to the node, ordered by their start epoch.
+// This includes the protocol parameters of announced upgrades that are not active yet.
+func (n *nodeBridge) ProtocolParametersHistory() ([]*ProtocolParametersUpdate, error) {
+	return ProtocolParametersHistory(n.NodeConfig())
+}
+
+// ProtocolParametersForEpoch returns the protocol parameters that are valid in the given epoch,
+// based on the protocol parameters history of the node.
+func (n *nodeBridge) ProtocolParametersForEpoch(epoch iotago.EpochIndex) (iotago.ProtocolParameters, error) {
+	history, err := n.ProtocolParametersHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].StartEpoch <= epoch {
+			return history[i].ProtocolParameters, nil
+		}
+	}
+
+	return nil, ierrors.Wrapf(ErrProtocolParametersNotFound, "epoch %d", epoch)
+}
+
+// ListenToProtocolParameterUpdates polls the node configuration in the given interval and passes
+// the protocol parameters that become active in a future epoch to the consumer, before their activation epoch is reached.
+// Protocol parameters that were already announced when the listener started are passed to the consumer as well.
+// Every update is only passed once.
+func (n *nodeBridge) ListenToProtocolParameterUpdates(ctx context.Context, pollInterval time.Duration, consumer func(update *ProtocolParametersUpdate) error) error {
+	type updateKey struct {
+		version    iotago.Version
+		startEpoch iotago.EpochIndex
+	}
+	announced := make(map[updateKey]struct{})
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		history, err := n.refreshProtocolParameters(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				// the context was canceled
+				return nil
+			}
+			n.LogErrorf("ListenToProtocolParameterUpdates failed: %s", err.Error())
+
+			return err
+		}
+
+		currentEpoch := n.currentEpoch()
+		for _, update := range history {
+			if update.StartEpoch <= currentEpoch {
+				continue
+			}
+
+			key := updateKey{version: update.ProtocolParameters.Version(), startEpoch: update.StartEpoch}
+			if _, exists := announced[key]; exists {
+				continue
+			}
+			announced[key] = struct{}{}
+
+			if err := consumer(update); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshProtocolParameters re-reads the node configuration and adds new protocol parameters to the APIProvider,
+// unless a custom APIProvider is used.
+func (n *nodeBridge) refreshProtocolParameters(ctx context.Context) ([]*ProtocolParametersUpdate, error) {
+	nodeConfig, err := n.client.ReadNodeConfiguration(ctx, &inx.NoParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := ProtocolParametersHistory(nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	n.nodeConfigMutex.Lock()
+	n.nodeConfig = nodeConfig
+	n.nodeConfigMutex.Unlock()
+
+	if provider, isEpochBased := n.apiProvider.(*iotago.EpochBasedProvider); isEpochBased && !n.customAPIProvider {
+		for _, update := range history {
+			if provider.ProtocolParameters(update.ProtocolParameters.Version()) != nil {
+				continue
+			}
+
+			provider.AddProtocolParametersAtEpoch(update.ProtocolParameters, update.StartEpoch)
+		}
+	}
+
+	return history, nil
+}
+
+// currentEpoch returns the epoch of the latest commitment.
+func (n *nodeBridge) currentEpoch() iotago.EpochIndex {
+	latestCommitment := n.LatestCommitment()
+	if latestCommitment == nil {
+		return 0
+	}
+
+	return n.apiProvider.CommittedAPI().TimeProvider().EpochFromSlot(latestCommitment.Commitment.Slot)
+}
+
+func (n *nodeBridge) listenToProtocolParameterUpdates(ctx context.Context) error {
+	return n.ListenToProtocolParameterUpdates(ctx, n.protocolParametersPollInterval, func(update *ProtocolParametersUpdate) error {
+		n.LogInfof("Protocol parameters of version %d announced, activation epoch: %d", update.ProtocolParameters.Version(), update.StartEpoch)
+		n.events.ProtocolParametersAnnounced.Trigger(update)
+
+		return nil
+	})
+}
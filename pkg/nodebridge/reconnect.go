@@ -0,0 +1,198 @@
+package nodebridge
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// ErrStreamReconnectAttemptsExceeded is returned if a stream could not be re-subscribed within the maximum amount of reconnect attempts.
+var ErrStreamReconnectAttemptsExceeded = ierrors.New("maximum amount of stream reconnect attempts exceeded")
+
+// watchdogStreams are the streams that are watched by the stream watchdog.
+// Only streams that receive items in every slot while the node is healthy are watched,
+// other streams (e.g. filtered blocks or accepted transactions) are naturally quiet on an idle network.
+var watchdogStreams = map[string]struct{}{
+	"ListenToBlocks":          {},
+	"ListenToAcceptedBlocks":  {},
+	"ListenToConfirmedBlocks": {},
+	"ListenToCommitments":     {},
+}
+
+// WithStreamReconnect enables the automatic reconnect of streams if the connection to the node is lost.
+// The streams are re-subscribed after the given interval and resume from the last seen slot if possible.
+// If maxAttempts is 0, the node bridge tries to reconnect forever.
+func WithStreamReconnect(interval time.Duration, maxAttempts uint) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.streamReconnectInterval = interval
+		n.streamReconnectMaxAttempts = maxAttempts
+	}
+}
+
+// WithStreamReconnectBackoff enables the exponential backoff of stream reconnects.
+// The reconnect interval doubles with every consecutive attempt, up to the given maximum interval.
+// The backoff is disabled if the maximum interval is 0, then streams are re-subscribed in a fixed interval.
+func WithStreamReconnectBackoff(maxInterval time.Duration) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.streamReconnectMaxInterval = maxInterval
+	}
+}
+
+// WithStreamWatchdog enables the staleness detection of the block and commitment streams,
+// which receive items in every slot while the node is healthy.
+// If no item was received on such a stream within the given interval while the node claims to be healthy,
+// the StreamStale event is triggered. If restart is true, the stale stream is re-subscribed afterwards.
+// The watchdog is disabled if the interval is 0.
+func WithStreamWatchdog(interval time.Duration, restart bool) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.streamWatchdogInterval = interval
+		n.streamWatchdogRestart = restart
+	}
+}
+
+// isReconnectableStreamError returns true if the error was caused by a lost connection to the node.
+func isReconnectableStreamError(err error) bool {
+	return ierrors.Is(err, ErrUnavailable) || status.Code(err) == codes.Unavailable
+}
+
+// listenWithReconnect runs the given stream listener and re-subscribes it if the connection to the node is lost.
+// The listener calls delivered with the slot of every item that was passed to the consumer,
+// which resets the amount of consecutive reconnect attempts and is tracked in the Stats of the stream.
+func (n *nodeBridge) listenWithReconnect(ctx context.Context, name string, listenFunc func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error) error {
+	stream, untrack := n.streamStats.track(name, n.latestCommitmentSlot())
+	defer untrack()
+
+	var attempts uint
+	delivered := func(slot iotago.SlotIndex) {
+		attempts = 0
+		stream.processed(slot)
+	}
+
+	for {
+		stale, err := n.listenWithWatchdog(ctx, name, listenFunc, delivered)
+		if stale {
+			n.LogWarnf("%s: re-subscribing stale stream ...", name)
+			continue
+		}
+
+		if err == nil || ctx.Err() != nil || n.streamReconnectInterval == 0 || !isReconnectableStreamError(err) {
+			return err
+		}
+
+		attempts++
+		if n.streamReconnectMaxAttempts != 0 && attempts > n.streamReconnectMaxAttempts {
+			return ierrors.Errorf("%w: %s: %w", ErrStreamReconnectAttemptsExceeded, name, err)
+		}
+
+		reconnectInterval := n.streamReconnectDelay(attempts)
+		n.LogWarnf("%s: connection to node lost, reconnecting in %s (attempt %d) ...", name, reconnectInterval, attempts)
+
+		timer := time.NewTimer(reconnectInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		// trigger the re-dial in case the connection went idle
+		n.conn.Connect()
+	}
+}
+
+// streamReconnectDelay returns the time to wait before the given consecutive reconnect attempt.
+func (n *nodeBridge) streamReconnectDelay(attempt uint) time.Duration {
+	if n.streamReconnectMaxInterval <= n.streamReconnectInterval {
+		return n.streamReconnectInterval
+	}
+
+	delay := n.streamReconnectInterval
+	for i := uint(1); i < attempt && delay < n.streamReconnectMaxInterval; i++ {
+		delay *= 2
+	}
+
+	return min(delay, n.streamReconnectMaxInterval)
+}
+
+// listenWithWatchdog runs the given stream listener and watches it for staleness
+// if the stream watchdog is enabled and the stream is one of the watchdogStreams.
+// It returns true if the stream was canceled by the watchdog to be re-subscribed.
+func (n *nodeBridge) listenWithWatchdog(ctx context.Context, name string, listenFunc func(ctx context.Context, delivered func(slot iotago.SlotIndex)) error, delivered func(slot iotago.SlotIndex)) (bool, error) {
+	if _, watched := watchdogStreams[name]; !watched || n.streamWatchdogInterval == 0 {
+		return false, listenFunc(ctx, delivered)
+	}
+
+	ctxStream, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+
+	var lastActivity atomic.Int64
+	lastActivity.Store(time.Now().UnixNano())
+
+	var stale atomic.Bool
+	watchdogDone := make(chan struct{})
+	go func() {
+		defer close(watchdogDone)
+
+		n.watchStream(ctxStream, name, &lastActivity, func() {
+			stale.Store(true)
+			cancelStream()
+		})
+	}()
+
+	err := listenFunc(ctxStream, func(slot iotago.SlotIndex) {
+		lastActivity.Store(time.Now().UnixNano())
+		delivered(slot)
+	})
+
+	cancelStream()
+	<-watchdogDone
+
+	if stale.Load() && ctx.Err() == nil {
+		// the stream was canceled by the watchdog, so the error is not relevant
+		return true, nil
+	}
+
+	return false, err
+}
+
+// watchStream triggers the StreamStale event if there was no activity on the stream within the watchdog interval
+// while the node is healthy. If the restart of stale streams is enabled, restart is called and the watch ends.
+func (n *nodeBridge) watchStream(ctx context.Context, name string, lastActivity *atomic.Int64, restart func()) {
+	ticker := time.NewTicker(n.streamWatchdogInterval / 2)
+	defer ticker.Stop()
+
+	var reported bool
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		sinceLastActivity := time.Since(time.Unix(0, lastActivity.Load()))
+		if sinceLastActivity < n.streamWatchdogInterval {
+			reported = false
+			continue
+		}
+
+		if reported || !n.IsNodeHealthy() {
+			continue
+		}
+		reported = true
+
+		n.LogWarnf("%s: no item received for %s although the node is healthy", name, sinceLastActivity.Truncate(time.Millisecond))
+		n.events.StreamStale.Trigger(name, sinceLastActivity)
+
+		if n.streamWatchdogRestart {
+			restart()
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package nodebridge
+
+import (
+	"context"
+	"path"
+	"time"
+
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// RetryPolicy defines the retry behavior of an unary INX call.
+type RetryPolicy struct {
+	// MaxRetries is the maximum amount of retries. 0 disables retries.
+	MaxRetries uint
+	// PerAttemptTimeout is the timeout of a single attempt. 0 disables the timeout.
+	PerAttemptTimeout time.Duration
+	// RetryableCodes are the gRPC codes that are retried.
+	// If empty, grpcretry.DefaultRetriableCodes are used.
+	RetryableCodes []codes.Code
+	// Backoff returns the time to wait before the given attempt.
+	// If nil, the default backoff of grpcretry is used.
+	Backoff func(attempt uint) time.Duration
+}
+
+// retryPolicyContextKey is the context key of the retry policy override.
+type retryPolicyContextKey struct{}
+
+// ContextWithRetryPolicy returns a context that overrides the retry policy of all unary INX calls made with it.
+func ContextWithRetryPolicy(ctx context.Context, retryPolicy *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, retryPolicy)
+}
+
+// ContextWithoutRetries returns a context that disables the retries of all unary INX calls made with it,
+// e.g. for one-shot writes that are not idempotent.
+func ContextWithoutRetries(ctx context.Context) context.Context {
+	return ContextWithRetryPolicy(ctx, &RetryPolicy{MaxRetries: 0})
+}
+
+// retryPolicyFromContext returns the retry policy override of the context.
+func retryPolicyFromContext(ctx context.Context) (*RetryPolicy, bool) {
+	retryPolicy, ok := ctx.Value(retryPolicyContextKey{}).(*RetryPolicy)
+
+	return retryPolicy, ok && retryPolicy != nil
+}
+
+// callOptions returns the grpcretry call options of the policy.
+func (p *RetryPolicy) callOptions() []grpc.CallOption {
+	callOptions := []grpc.CallOption{grpcretry.WithMax(p.MaxRetries)}
+
+	if p.PerAttemptTimeout > 0 {
+		callOptions = append(callOptions, grpcretry.WithPerRetryTimeout(p.PerAttemptTimeout))
+	}
+
+	if len(p.RetryableCodes) > 0 {
+		callOptions = append(callOptions, grpcretry.WithCodes(p.RetryableCodes...))
+	}
+
+	if p.Backoff != nil {
+		callOptions = append(callOptions, grpcretry.WithBackoff(p.Backoff))
+	}
+
+	return callOptions
+}
+
+// WithRetryPolicy sets the default retry policy of all unary INX calls without a policy per method.
+func WithRetryPolicy(retryPolicy *RetryPolicy) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.defaultRetryPolicy = retryPolicy
+	}
+}
+
+// WithRetryPolicies sets the retry policies per INX method.
+// The key is the name of the method, e.g. "ReadOutput" or "SubmitBlock".
+// Methods without a policy use the default retry policy, or are not retried if there is none.
+func WithRetryPolicies(retryPolicies map[string]*RetryPolicy) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.retryPolicies = retryPolicies
+	}
+}
+
+// retryPolicyUnaryClientInterceptor applies the retry policy of the called method.
+// It needs to be placed in front of the retry interceptor.
+// The policy of the context takes precedence over the policy of the method, which takes precedence over the default policy.
+// Retry options passed to the call itself take precedence over all policies.
+func (n *nodeBridge) retryPolicyUnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	retryPolicy, exists := retryPolicyFromContext(ctx)
+	if !exists {
+		retryPolicy, exists = n.retryPolicies[path.Base(method)]
+	}
+	if !exists && n.defaultRetryPolicy != nil {
+		retryPolicy, exists = n.defaultRetryPolicy, true
+	}
+	if !exists {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	return invoker(ctx, method, req, reply, cc, append(retryPolicy.callOptions(), opts...)...)
+}
@@ -0,0 +1,169 @@
+package nodebridge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+const (
+	// DefaultRunnerStopTimeout is the default time the Runner waits for a single listener to stop after cancellation.
+	DefaultRunnerStopTimeout = 5 * time.Second
+)
+
+var (
+	// ErrRunnerStopTimeout is returned if a listener of the Runner did not stop within the stop timeout.
+	ErrRunnerStopTimeout = ierrors.New("listener did not stop within the stop timeout")
+	// ErrRunnerAlreadyStarted is returned if a listener is added to or Run is called on an already started Runner.
+	ErrRunnerAlreadyStarted = ierrors.New("runner was already started")
+)
+
+// RunnerListenerError is the error of a listener of the Runner.
+type RunnerListenerError struct {
+	// Name is the name of the listener.
+	Name string
+	// Err is the error returned by the listener.
+	Err error
+}
+
+// Error returns the error message.
+func (e *RunnerListenerError) Error() string {
+	return fmt.Sprintf("listener \"%s\" failed: %s", e.Name, e.Err)
+}
+
+// Unwrap returns the error returned by the listener.
+func (e *RunnerListenerError) Unwrap() error {
+	return e.Err
+}
+
+// runnerListener is a listener that is managed by the Runner.
+type runnerListener struct {
+	name    string
+	runFunc func(ctx context.Context) error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Runner manages the lifecycle of multiple listeners (e.g. the TangleListener, ledger update or commitment consumers).
+// The listeners are started in the order they were added. If the context is canceled or one of the listeners ends,
+// the listeners are stopped in reverse order, so consumers added after the listeners they depend on are stopped first.
+type Runner struct {
+	stopTimeout time.Duration
+
+	mutex     sync.Mutex
+	listeners []*runnerListener
+	started   bool
+}
+
+// WithRunnerStopTimeout sets the time the Runner waits for a single listener to stop after cancellation.
+func WithRunnerStopTimeout(stopTimeout time.Duration) options.Option[Runner] {
+	return func(r *Runner) {
+		r.stopTimeout = stopTimeout
+	}
+}
+
+// NewRunner creates a new Runner.
+func NewRunner(opts ...options.Option[Runner]) *Runner {
+	return options.Apply(&Runner{
+		stopTimeout: DefaultRunnerStopTimeout,
+	}, opts)
+}
+
+// Add adds a listener with the given name. The listener has to return as soon as its context is canceled.
+// Listeners can't be added after the Runner was started.
+func (r *Runner) Add(name string, runFunc func(ctx context.Context) error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.started {
+		return ierrors.Wrapf(ErrRunnerAlreadyStarted, "failed to add listener \"%s\"", name)
+	}
+
+	r.listeners = append(r.listeners, &runnerListener{
+		name:    name,
+		runFunc: runFunc,
+		done:    make(chan struct{}),
+	})
+
+	return nil
+}
+
+// AddTangleListener adds the given TangleListener.
+func (r *Runner) AddTangleListener(name string, tangleListener *TangleListener) error {
+	return r.Add(name, func(ctx context.Context) error {
+		tangleListener.Run(ctx)
+
+		return nil
+	})
+}
+
+// Run starts all listeners and blocks until the context is canceled or one of the listeners ends.
+// Afterwards all listeners are stopped in reverse order.
+// It returns the errors of the failed listeners as RunnerListenerError,
+// including ErrRunnerStopTimeout for listeners that did not stop in time.
+func (r *Runner) Run(ctx context.Context) error {
+	r.mutex.Lock()
+	if r.started {
+		r.mutex.Unlock()
+		return ErrRunnerAlreadyStarted
+	}
+	r.started = true
+	listeners := r.listeners
+	r.mutex.Unlock()
+
+	// ended is notified if any of the listeners ended
+	ended := make(chan struct{}, len(listeners))
+
+	for _, listener := range listeners {
+		// every listener gets its own context that keeps the values of ctx but not its cancellation, so they can be stopped in order
+		listenerCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		listener.cancel = cancel
+
+		go func() {
+			defer close(listener.done)
+			defer func() { ended <- struct{}{} }()
+
+			listener.err = listener.runFunc(listenerCtx)
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-ended:
+	}
+
+	var errs []error
+	for i := len(listeners) - 1; i >= 0; i-- {
+		if err := r.stopListener(listeners[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return ierrors.Join(errs...)
+}
+
+// stopListener cancels the listener and waits for it to stop within the stop timeout.
+func (r *Runner) stopListener(listener *runnerListener) error {
+	listener.cancel()
+
+	timer := time.NewTimer(r.stopTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-listener.done:
+	case <-timer.C:
+		return &RunnerListenerError{Name: listener.name, Err: ErrRunnerStopTimeout}
+	}
+
+	if listener.err != nil && !ierrors.Is(listener.err, context.Canceled) {
+		return &RunnerListenerError{Name: listener.name, Err: listener.err}
+	}
+
+	return nil
+}
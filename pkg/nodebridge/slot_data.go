@@ -0,0 +1,155 @@
+package nodebridge
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// SlotBlock is a block that was accepted in a slot.
+type SlotBlock struct {
+	// Metadata is the metadata of the block, which contains its ID.
+	Metadata *api.BlockMetadataResponse
+	// Block is the block, it is only set if the blocks were requested via WithSlotDataBlocks.
+	Block *iotago.Block
+	// RawData is the raw binary block data, it is only set if the blocks were requested via WithSlotDataBlocks.
+	RawData []byte
+}
+
+// SlotData bundles the data of a committed slot.
+type SlotData struct {
+	// Commitment is the commitment of the slot.
+	Commitment *Commitment
+	// Blocks are the blocks that were accepted in the slot.
+	Blocks []*SlotBlock
+	// LedgerUpdate contains the outputs that were consumed and created in the slot.
+	LedgerUpdate *LedgerUpdate
+}
+
+// BlockIDs returns the IDs of the blocks that were accepted in the slot.
+func (s *SlotData) BlockIDs() iotago.BlockIDs {
+	blockIDs := make(iotago.BlockIDs, 0, len(s.Blocks))
+	for _, block := range s.Blocks {
+		blockIDs = append(blockIDs, block.Metadata.BlockID)
+	}
+
+	return blockIDs
+}
+
+// SlotDataOptions are the options of ReadSlotData.
+type SlotDataOptions struct {
+	includeBlocks bool
+}
+
+// NewSlotDataOptions creates the SlotDataOptions from the given options.
+func NewSlotDataOptions(opts ...options.Option[SlotDataOptions]) *SlotDataOptions {
+	return options.Apply(&SlotDataOptions{}, opts)
+}
+
+// IncludeBlocks returns true if the accepted blocks themselves are requested and not only their metadata.
+func (o *SlotDataOptions) IncludeBlocks() bool {
+	return o.includeBlocks
+}
+
+// WithSlotDataBlocks defines whether the accepted blocks themselves are decoded and returned.
+// By default, only the metadata of the accepted blocks is returned.
+func WithSlotDataBlocks(includeBlocks bool) options.Option[SlotDataOptions] {
+	return func(o *SlotDataOptions) {
+		o.includeBlocks = includeBlocks
+	}
+}
+
+// ReadSlotData returns the commitment, the accepted blocks and the ledger update of the given committed slot.
+// The underlying INX requests are executed concurrently, if one of them fails the others are canceled.
+func (n *nodeBridge) ReadSlotData(ctx context.Context, slot iotago.SlotIndex, opts ...options.Option[SlotDataOptions]) (*SlotData, error) {
+	slotDataOptions := NewSlotDataOptions(opts...)
+	slotData := &SlotData{}
+
+	group, ctxGroup := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		commitment, err := n.Commitment(ctxGroup, slot)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to read commitment of slot %d", slot)
+		}
+		if commitment == nil {
+			return ierrors.Wrapf(ErrNotFound, "commitment of slot %d", slot)
+		}
+		slotData.Commitment = commitment
+
+		return nil
+	})
+	group.Go(func() error {
+		blocks, err := n.readAcceptedBlocks(ctxGroup, slot, slotDataOptions.includeBlocks)
+		if err != nil {
+			return ierrors.Wrapf(err, "failed to read accepted blocks of slot %d", slot)
+		}
+		slotData.Blocks = blocks
+
+		return nil
+	})
+	group.Go(func() error {
+		if err := n.ListenToLedgerUpdates(ctxGroup, slot, slot, func(update *LedgerUpdate) error {
+			slotData.LedgerUpdate = update
+
+			return nil
+		}); err != nil {
+			return ierrors.Wrapf(err, "failed to read ledger update of slot %d", slot)
+		}
+
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	if slotData.LedgerUpdate == nil {
+		// the stream ended without a ledger update, so no outputs were consumed or created in the slot
+		slotData.LedgerUpdate = &LedgerUpdate{
+			API:          n.apiProvider.APIForSlot(slot),
+			CommitmentID: slotData.Commitment.CommitmentID,
+		}
+	}
+
+	return slotData, nil
+}
+
+func (n *nodeBridge) readAcceptedBlocks(ctx context.Context, slot iotago.SlotIndex, includeBlocks bool) ([]*SlotBlock, error) {
+	stream, err := n.client.ReadAcceptedBlocks(ctx, inx.WrapSlotRequest(slot))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*SlotBlock, 0)
+	if err := ListenToStream(ctx, stream.Recv, func(blockWithMetadata *inx.BlockWithMetadata) error {
+		blockMetadata, err := blockWithMetadata.GetMetadata().Unwrap()
+		if err != nil {
+			return err
+		}
+
+		slotBlock := &SlotBlock{Metadata: blockMetadata}
+		if includeBlocks {
+			block, err := blockWithMetadata.GetBlock().UnwrapBlock(n.apiProvider)
+			if err != nil {
+				return err
+			}
+
+			slotBlock.Block = block
+			slotBlock.RawData = blockWithMetadata.GetBlock().GetData()
+		}
+
+		blocks = append(blocks, slotBlock)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
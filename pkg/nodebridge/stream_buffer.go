@@ -0,0 +1,134 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// ErrInvalidBufferSize is returned if the buffer size of a buffered stream is not positive.
+var ErrInvalidBufferSize = ierrors.New("buffer size must be positive")
+
+// BufferDropPolicy defines which items a buffered stream drops if its buffer is full.
+type BufferDropPolicy byte
+
+const (
+	// BufferDropPolicyDropOldest drops the oldest buffered item to make space for the received item.
+	BufferDropPolicyDropOldest BufferDropPolicy = iota
+	// BufferDropPolicyDropNewest drops the received item.
+	BufferDropPolicyDropNewest
+)
+
+// streamBuffer is a ring buffer that decouples the receiving of stream items from their processing.
+type streamBuffer[T any] struct {
+	mutex      sync.Mutex
+	items      []T
+	head       int
+	size       int
+	dropPolicy BufferDropPolicy
+	dropped    uint64
+
+	// available signals that items were pushed, it is only written to if it is empty
+	available chan struct{}
+}
+
+func newStreamBuffer[T any](bufferSize int, dropPolicy BufferDropPolicy) *streamBuffer[T] {
+	return &streamBuffer[T]{
+		items:      make([]T, bufferSize),
+		dropPolicy: dropPolicy,
+		available:  make(chan struct{}, 1),
+	}
+}
+
+// push adds the item to the buffer without blocking.
+// It returns the total amount of dropped items and false if an item was dropped because the buffer was full.
+func (b *streamBuffer[T]) push(item T) (uint64, bool) {
+	b.mutex.Lock()
+	defer func() {
+		b.mutex.Unlock()
+
+		select {
+		case b.available <- struct{}{}:
+		default:
+		}
+	}()
+
+	if b.size < len(b.items) {
+		b.items[(b.head+b.size)%len(b.items)] = item
+		b.size++
+
+		return b.dropped, true
+	}
+
+	b.dropped++
+	if b.dropPolicy == BufferDropPolicyDropOldest {
+		b.items[b.head] = item
+		b.head = (b.head + 1) % len(b.items)
+	}
+
+	return b.dropped, false
+}
+
+// pop removes the oldest item from the buffer.
+// It returns false if the buffer is empty.
+func (b *streamBuffer[T]) pop() (T, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var item T
+	if b.size == 0 {
+		return item, false
+	}
+
+	item, b.items[b.head] = b.items[b.head], item
+	b.head = (b.head + 1) % len(b.items)
+	b.size--
+
+	return item, true
+}
+
+// ListenBuffered receives the items of the stream via listen and passes them to the consumer in a separate goroutine,
+// so a slow consumer doesn't stall the stream and doesn't cause the node to disconnect it.
+// At most bufferSize items are buffered, if the buffer is full, items are dropped according to the drop policy
+// and onDropped is called with the total amount of dropped items.
+// Buffered items that were not passed to the consumer yet are discarded if the stream ends.
+func ListenBuffered[T any](ctx context.Context, bufferSize int, dropPolicy BufferDropPolicy, listen func(ctx context.Context, consumer func(item T) error) error, consumer func(item T) error, onDropped func(dropped uint64)) error {
+	if bufferSize <= 0 {
+		return ierrors.Wrapf(ErrInvalidBufferSize, "buffer size %d", bufferSize)
+	}
+
+	buffer := newStreamBuffer[T](bufferSize, dropPolicy)
+
+	streamGroup := NewStreamGroup(ctx, DefaultStreamGroupDrainTimeout)
+	streamGroup.Go("receive", func(ctx context.Context) error {
+		return listen(ctx, func(item T) error {
+			if dropped, ok := buffer.push(item); !ok && onDropped != nil {
+				onDropped(dropped)
+			}
+
+			return nil
+		})
+	})
+	streamGroup.Go("consume", func(ctx context.Context) error {
+		for ctx.Err() == nil {
+			item, ok := buffer.pop()
+			if !ok {
+				select {
+				case <-ctx.Done():
+				case <-buffer.available:
+				}
+
+				continue
+			}
+
+			if err := consumer(item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return streamGroup.Wait()
+}
@@ -0,0 +1,140 @@
+package nodebridge
+
+import (
+	"context"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+// StreamEventSubscriptions defines the streams that are opened to trigger the StreamEvents.
+// Subscriptions can be combined, e.g. StreamEventBlocks | StreamEventLedgerUpdates.
+type StreamEventSubscriptions uint16
+
+const (
+	// StreamEventBlocks triggers the BlockReceived event.
+	StreamEventBlocks StreamEventSubscriptions = 1 << iota
+	// StreamEventAcceptedBlocks triggers the BlockAccepted event.
+	StreamEventAcceptedBlocks
+	// StreamEventConfirmedBlocks triggers the BlockConfirmed event.
+	StreamEventConfirmedBlocks
+	// StreamEventCommitments triggers the CommitmentCreated event.
+	StreamEventCommitments
+	// StreamEventFinalizedCommitments triggers the CommitmentFinalized event.
+	// It does not open a stream, the event is derived from the node status.
+	StreamEventFinalizedCommitments
+	// StreamEventLedgerUpdates triggers the LedgerUpdated event.
+	StreamEventLedgerUpdates
+	// StreamEventAcceptedTransactions triggers the AcceptedTransaction event.
+	StreamEventAcceptedTransactions
+
+	// StreamEventAll subscribes to all stream events.
+	StreamEventAll = StreamEventBlocks | StreamEventAcceptedBlocks | StreamEventConfirmedBlocks | StreamEventCommitments |
+		StreamEventFinalizedCommitments | StreamEventLedgerUpdates | StreamEventAcceptedTransactions
+)
+
+// StreamEvents contains the events that are triggered for the items of the INX streams.
+// Components can hook to these events instead of opening their own streams.
+// Only the events of the subscribed streams are triggered, see WithStreamEvents.
+type StreamEvents struct {
+	// BlockReceived is triggered for every block the node received.
+	BlockReceived *event.Event1[*StreamedBlock]
+	// BlockAccepted is triggered with the metadata of every accepted block.
+	BlockAccepted *event.Event1[*api.BlockMetadataResponse]
+	// BlockConfirmed is triggered with the metadata of every confirmed block.
+	BlockConfirmed *event.Event1[*api.BlockMetadataResponse]
+	// CommitmentCreated is triggered for every new commitment.
+	CommitmentCreated *event.Event1[*Commitment]
+	// CommitmentFinalized is triggered if the latest finalized commitment changed.
+	CommitmentFinalized *event.Event1[*Commitment]
+	// LedgerUpdated is triggered for the ledger update of every committed slot.
+	LedgerUpdated *event.Event1[*LedgerUpdate]
+	// AcceptedTransaction is triggered for every accepted transaction.
+	AcceptedTransaction *event.Event1[*AcceptedTransaction]
+}
+
+// NewStreamEvents creates a new StreamEvents.
+func NewStreamEvents() *StreamEvents {
+	return &StreamEvents{
+		BlockReceived:       event.New1[*StreamedBlock](),
+		BlockAccepted:       event.New1[*api.BlockMetadataResponse](),
+		BlockConfirmed:      event.New1[*api.BlockMetadataResponse](),
+		CommitmentCreated:   event.New1[*Commitment](),
+		CommitmentFinalized: event.New1[*Commitment](),
+		LedgerUpdated:       event.New1[*LedgerUpdate](),
+		AcceptedTransaction: event.New1[*AcceptedTransaction](),
+	}
+}
+
+// WithStreamEvents subscribes to the given streams, their items trigger the StreamEvents while the NodeBridge is running.
+func WithStreamEvents(subscriptions StreamEventSubscriptions) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.streamEventSubscriptions = subscriptions
+	}
+}
+
+// StreamEvents returns the events that are triggered for the items of the subscribed INX streams.
+func (n *nodeBridge) StreamEvents() *StreamEvents {
+	return n.streamEvents
+}
+
+// RunStreamEvents opens the subscribed streams of the NodeBridge and triggers the StreamEvents for their items
+// until the context is canceled or one of the streams failed.
+func RunStreamEvents(ctx context.Context, nodeBridge NodeBridge, streamEvents *StreamEvents, subscriptions StreamEventSubscriptions) error {
+	if subscriptions&StreamEventFinalizedCommitments != 0 {
+		hook := nodeBridge.Events().LatestFinalizedCommitmentChanged.Hook(streamEvents.CommitmentFinalized.Trigger)
+		defer hook.Unhook()
+	}
+
+	streamGroup := NewStreamGroup(ctx, DefaultStreamGroupDrainTimeout)
+	if subscriptions&StreamEventBlocks != 0 {
+		streamGroup.Go("blocks", func(ctx context.Context) error {
+			return nodeBridge.ListenToBlocks(ctx, func(block *iotago.Block, rawData []byte) error {
+				streamEvents.BlockReceived.Trigger(&StreamedBlock{Block: block, RawData: rawData})
+
+				return nil
+			})
+		})
+	}
+	if subscriptions&StreamEventAcceptedBlocks != 0 {
+		streamGroup.Go("accepted blocks", func(ctx context.Context) error {
+			return nodeBridge.ListenToAcceptedBlocks(ctx, triggerStreamEvent(streamEvents.BlockAccepted))
+		})
+	}
+	if subscriptions&StreamEventConfirmedBlocks != 0 {
+		streamGroup.Go("confirmed blocks", func(ctx context.Context) error {
+			return nodeBridge.ListenToConfirmedBlocks(ctx, triggerStreamEvent(streamEvents.BlockConfirmed))
+		})
+	}
+	if subscriptions&StreamEventCommitments != 0 {
+		streamGroup.Go("commitments", func(ctx context.Context) error {
+			return nodeBridge.ListenToCommitments(ctx, 0, 0, func(commitment *Commitment, _ []byte) error {
+				streamEvents.CommitmentCreated.Trigger(commitment)
+
+				return nil
+			})
+		})
+	}
+	if subscriptions&StreamEventLedgerUpdates != 0 {
+		streamGroup.Go("ledger updates", func(ctx context.Context) error {
+			return nodeBridge.ListenToLedgerUpdates(ctx, 0, 0, triggerStreamEvent(streamEvents.LedgerUpdated))
+		})
+	}
+	if subscriptions&StreamEventAcceptedTransactions != 0 {
+		streamGroup.Go("accepted transactions", func(ctx context.Context) error {
+			return nodeBridge.ListenToAcceptedTransactions(ctx, triggerStreamEvent(streamEvents.AcceptedTransaction))
+		})
+	}
+
+	return streamGroup.Wait()
+}
+
+func triggerStreamEvent[T any](e *event.Event1[T]) func(item T) error {
+	return func(item T) error {
+		e.Trigger(item)
+
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package nodebridge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+const (
+	// DefaultStreamGroupDrainTimeout is the default time a StreamGroup waits for its streams to finish after cancellation.
+	DefaultStreamGroupDrainTimeout = 5 * time.Second
+)
+
+// ErrStreamGroupDrainTimeout is returned if the streams of a StreamGroup did not finish within the drain timeout.
+var ErrStreamGroupDrainTimeout = ierrors.New("streams did not finish within the drain timeout")
+
+// StreamGroupError is the error of a stream of a StreamGroup.
+type StreamGroupError struct {
+	// Name is the name of the stream.
+	Name string
+	// Err is the error returned by the stream.
+	Err error
+}
+
+// Error returns the error message.
+func (e *StreamGroupError) Error() string {
+	return fmt.Sprintf("stream \"%s\" failed: %s", e.Name, e.Err)
+}
+
+// Unwrap returns the error returned by the stream.
+func (e *StreamGroupError) Unwrap() error {
+	return e.Err
+}
+
+// StreamGroup runs multiple stream consumers that share a common lifetime.
+// If one of the streams ends, all other streams are canceled.
+type StreamGroup struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	drainTimeout time.Duration
+
+	wg       sync.WaitGroup
+	errMutex sync.Mutex
+	err      error
+}
+
+// NewStreamGroup creates a new StreamGroup that is canceled if the given context is canceled.
+func NewStreamGroup(ctx context.Context, drainTimeout time.Duration) *StreamGroup {
+	groupCtx, cancel := context.WithCancel(ctx)
+
+	return &StreamGroup{
+		ctx:          groupCtx,
+		cancel:       cancel,
+		drainTimeout: drainTimeout,
+	}
+}
+
+// Context returns the context of the group, which is canceled as soon as one of the streams ends.
+func (g *StreamGroup) Context() context.Context {
+	return g.ctx
+}
+
+// Go runs the given stream consumer in a new goroutine.
+// If the stream ends, the other streams of the group are canceled.
+// The first error returned by a stream is reported by Wait as StreamGroupError.
+func (g *StreamGroup) Go(name string, listenFunc func(ctx context.Context) error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+		defer g.cancel()
+
+		if err := listenFunc(g.ctx); err != nil {
+			g.setErr(&StreamGroupError{Name: name, Err: err})
+		}
+	}()
+}
+
+func (g *StreamGroup) setErr(err error) {
+	g.errMutex.Lock()
+	defer g.errMutex.Unlock()
+
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+// Wait blocks until the group is canceled and waits for all streams to finish within the drain timeout.
+// It returns the first error returned by a stream, or ErrStreamGroupDrainTimeout if the streams did not finish in time.
+func (g *StreamGroup) Wait() error {
+	<-g.ctx.Done()
+
+	drained := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(g.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-drained:
+	case <-timer.C:
+		g.setErr(ErrStreamGroupDrainTimeout)
+	}
+
+	g.errMutex.Lock()
+	defer g.errMutex.Unlock()
+
+	return g.err
+}
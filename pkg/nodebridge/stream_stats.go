@@ -0,0 +1,175 @@
+package nodebridge
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// StreamStats contains the progress of the consumer of an active stream.
+type StreamStats struct {
+	// Name is the name of the stream, e.g. "ListenToLedgerUpdates".
+	// If the same stream is active multiple times, a sequence number is appended, e.g. "ListenToLedgerUpdates#2".
+	Name string
+	// LatestCommitmentSlot is the slot of the latest commitment of the node.
+	LatestCommitmentSlot iotago.SlotIndex
+	// LastProcessedSlot is the slot of the last item that was processed by the consumer.
+	// Until the first item was processed, it is the slot of the latest commitment at the time the stream was opened.
+	LastProcessedSlot iotago.SlotIndex
+	// Lag is the amount of slots the consumer is behind the latest commitment of the node.
+	Lag iotago.SlotIndex
+	// ProcessedItems is the amount of items that were processed by the consumer.
+	ProcessedItems uint64
+	// LastProcessedTime is the time the last item was processed, it is zero if no item was processed yet.
+	LastProcessedTime time.Time
+}
+
+// trackedStream is the progress of an active stream.
+type trackedStream struct {
+	name              string
+	lastProcessedSlot atomic.Uint32
+	processedItems    atomic.Uint64
+	lastProcessedTime atomic.Int64
+}
+
+// processed records that an item of the given slot was processed by the consumer.
+func (s *trackedStream) processed(slot iotago.SlotIndex) {
+	s.lastProcessedSlot.Store(uint32(slot))
+	s.processedItems.Add(1)
+	s.lastProcessedTime.Store(time.Now().UnixNano())
+}
+
+// streamStatsTracker tracks the progress of the active streams.
+type streamStatsTracker struct {
+	mutex   sync.RWMutex
+	streams map[string]*trackedStream
+}
+
+func newStreamStatsTracker() *streamStatsTracker {
+	return &streamStatsTracker{
+		streams: make(map[string]*trackedStream),
+	}
+}
+
+// track adds a stream with the given name, the returned function removes it again.
+func (t *streamStatsTracker) track(name string, latestCommitmentSlot iotago.SlotIndex) (*trackedStream, func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	uniqueName := name
+	for i := 2; ; i++ {
+		if _, exists := t.streams[uniqueName]; !exists {
+			break
+		}
+		uniqueName = fmt.Sprintf("%s#%d", name, i)
+	}
+
+	stream := &trackedStream{name: uniqueName}
+	stream.lastProcessedSlot.Store(uint32(latestCommitmentSlot))
+	t.streams[uniqueName] = stream
+
+	return stream, func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+
+		delete(t.streams, uniqueName)
+	}
+}
+
+// stats returns the stats of the active streams ordered by their name.
+func (t *streamStatsTracker) stats(latestCommitmentSlot iotago.SlotIndex) []*StreamStats {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	stats := make([]*StreamStats, 0, len(t.streams))
+	for _, stream := range t.streams {
+		streamStats := &StreamStats{
+			Name:                 stream.name,
+			LatestCommitmentSlot: latestCommitmentSlot,
+			LastProcessedSlot:    iotago.SlotIndex(stream.lastProcessedSlot.Load()),
+			ProcessedItems:       stream.processedItems.Load(),
+		}
+		if streamStats.LatestCommitmentSlot > streamStats.LastProcessedSlot {
+			streamStats.Lag = streamStats.LatestCommitmentSlot - streamStats.LastProcessedSlot
+		}
+		if lastProcessedTime := stream.lastProcessedTime.Load(); lastProcessedTime != 0 {
+			streamStats.LastProcessedTime = time.Unix(0, lastProcessedTime)
+		}
+
+		stats = append(stats, streamStats)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+
+	return stats
+}
+
+// latestCommitmentSlot returns the slot of the latest commitment of the node, or 0 if it is unknown.
+func (n *nodeBridge) latestCommitmentSlot() iotago.SlotIndex {
+	if latestCommitment := n.LatestCommitment(); latestCommitment != nil {
+		return latestCommitment.CommitmentID.Slot()
+	}
+
+	return 0
+}
+
+// Stats returns the progress of the consumers of the active streams, ordered by the name of the stream.
+func (n *nodeBridge) Stats() []*StreamStats {
+	return n.streamStats.stats(n.latestCommitmentSlot())
+}
+
+// streamStatsCollector exposes the Stats of a NodeBridge as Prometheus metrics.
+type streamStatsCollector struct {
+	nodeBridge NodeBridge
+
+	lagDesc               *prometheus.Desc
+	lastProcessedSlotDesc *prometheus.Desc
+	processedItemsDesc    *prometheus.Desc
+}
+
+// NewStreamStatsCollector creates a Prometheus collector that exposes the Stats of the given NodeBridge
+// with the name of the stream as label, so it can be seen which consumer is falling behind the node.
+func NewStreamStatsCollector(nodeBridge NodeBridge) prometheus.Collector {
+	return &streamStatsCollector{
+		nodeBridge: nodeBridge,
+		lagDesc: prometheus.NewDesc(
+			"inx_stream_lag_slots",
+			"The amount of slots the consumer of the stream is behind the latest commitment of the node.",
+			[]string{"stream"}, nil,
+		),
+		lastProcessedSlotDesc: prometheus.NewDesc(
+			"inx_stream_last_processed_slot",
+			"The slot of the last item that was processed by the consumer of the stream.",
+			[]string{"stream"}, nil,
+		),
+		processedItemsDesc: prometheus.NewDesc(
+			"inx_stream_processed_items_total",
+			"The amount of items that were processed by the consumer of the stream.",
+			[]string{"stream"}, nil,
+		),
+	}
+}
+
+// Describe sends the descriptors of the metrics to the given channel.
+func (c *streamStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lagDesc
+	ch <- c.lastProcessedSlotDesc
+	ch <- c.processedItemsDesc
+}
+
+// Collect sends the metrics of the active streams to the given channel.
+func (c *streamStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range c.nodeBridge.Stats() {
+		ch <- prometheus.MustNewConstMetric(c.lagDesc, prometheus.GaugeValue, float64(stats.Lag), stats.Name)
+		ch <- prometheus.MustNewConstMetric(c.lastProcessedSlotDesc, prometheus.GaugeValue, float64(stats.LastProcessedSlot), stats.Name)
+		ch <- prometheus.MustNewConstMetric(c.processedItemsDesc, prometheus.CounterValue, float64(stats.ProcessedItems), stats.Name)
+	}
+}
@@ -4,12 +4,10 @@ import (
 	"context"
 	"sync"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-
 	"github.com/iotaledger/hive.go/ierrors"
 	"github.com/iotaledger/hive.go/log"
 	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
 	"github.com/iotaledger/hive.go/runtime/valuenotifier"
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
@@ -23,32 +21,140 @@ type TangleListener struct {
 	log.Logger
 
 	nodeBridge                  NodeBridge
+	blockMetadataFunc           BlockMetadataFunc
+	transactionMetadataFunc     TransactionMetadataFunc
+	synchronousCallbacks        bool
 	blockAcceptedNotifier       *valuenotifier.Notifier[iotago.BlockID]
+	transactionAcceptedNotifier *valuenotifier.Notifier[iotago.TransactionID]
 	commitmentConfirmedNotifier *valuenotifier.Notifier[iotago.SlotIndex]
+	blockConfirmedNotifier      *valuenotifier.Notifier[iotago.BlockID]
+	blockFinalizedNotifier      *valuenotifier.Notifier[iotago.BlockID]
 
 	blockAcceptedCallbacks     map[iotago.BlockID]BlockAcceptedCallback
 	blockAcceptedCallbacksLock sync.Mutex
 
+	transactionAcceptedCallbacks     map[iotago.TransactionID]TransactionAcceptedCallback
+	transactionAcceptedCallbacksLock sync.Mutex
+
+	blockConfirmedCallbacks     map[iotago.BlockID]BlockConfirmedCallback
+	blockConfirmedCallbacksLock sync.Mutex
+
+	blockFinalizedCallbacks     map[iotago.BlockID]BlockFinalizedCallback
+	blockFinalizedCallbacksLock sync.Mutex
+
+	// the confirmed and finalized blocks streams are only started if they are needed
+	confirmedBlocksEnabled bool
+	finalizedBlocksEnabled bool
+	confirmedBlocksStream  *optionalStream
+	finalizedBlocksStream  *optionalStream
+
+	// optionalStreamsLock guards the state of the optional streams and the context of the running listener.
+	optionalStreamsLock sync.Mutex
+	// runCtx is the context of Run, it is nil if the listener is not running.
+	runCtx            context.Context
+	optionalStreamsWg sync.WaitGroup
+
 	Events *TangleListenerEvents
 }
 
+// optionalStream is a stream of the TangleListener that is started on demand.
+type optionalStream struct {
+	name      string
+	listen    func(ctx context.Context) error
+	requested bool
+	running   bool
+}
+
 type TangleListenerEvents struct {
-	BlockAccepted *event.Event1[*api.BlockMetadataResponse]
+	BlockAccepted       *event.Event1[*api.BlockMetadataResponse]
+	TransactionAccepted *event.Event1[iotago.TransactionID]
+	// BlockConfirmed is triggered if a block was confirmed.
+	BlockConfirmed *event.Event1[*api.BlockMetadataResponse]
+	// BlockFinalized is triggered if the slot of an accepted block was finalized.
+	BlockFinalized *event.Event1[*api.BlockMetadataResponse]
 }
 
 type BlockAcceptedCallback = func(*api.BlockMetadataResponse)
 
-func NewTangleListener(logger log.Logger, nodeBridge NodeBridge) *TangleListener {
-	return &TangleListener{
-		Logger:                      logger,
-		nodeBridge:                  nodeBridge,
-		blockAcceptedNotifier:       valuenotifier.New[iotago.BlockID](),
-		commitmentConfirmedNotifier: valuenotifier.New[iotago.SlotIndex](),
-		blockAcceptedCallbacks:      map[iotago.BlockID]BlockAcceptedCallback{},
+type BlockConfirmedCallback = func(*api.BlockMetadataResponse)
+
+type BlockFinalizedCallback = func(*api.BlockMetadataResponse)
+
+type TransactionAcceptedCallback = func(iotago.TransactionID)
+
+// BlockMetadataFunc returns the block metadata for the given block ID.
+type BlockMetadataFunc = func(ctx context.Context, blockID iotago.BlockID) (*api.BlockMetadataResponse, error)
+
+// TransactionMetadataFunc returns the transaction metadata for the given transaction ID.
+type TransactionMetadataFunc = func(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error)
+
+// WithBlockMetadataFunc sets the source of the block metadata that is used to check
+// if a block is already accepted when a callback or event is registered.
+// It defaults to NodeBridge.BlockMetadata and allows to inject a fake source in tests.
+func WithBlockMetadataFunc(blockMetadataFunc BlockMetadataFunc) options.Option[TangleListener] {
+	return func(t *TangleListener) {
+		t.blockMetadataFunc = blockMetadataFunc
+	}
+}
+
+// WithTransactionMetadataFunc sets the source of the transaction metadata that is used to check
+// if a transaction is already accepted when a callback or event is registered.
+// It defaults to NodeBridge.TransactionMetadata and allows to inject a fake source in tests.
+func WithTransactionMetadataFunc(transactionMetadataFunc TransactionMetadataFunc) options.Option[TangleListener] {
+	return func(t *TangleListener) {
+		t.transactionMetadataFunc = transactionMetadataFunc
+	}
+}
+
+// WithConfirmedBlocks starts the confirmed blocks stream together with the listener, which is needed if the
+// BlockConfirmed event is used. Otherwise the stream is only started once a confirmed callback or event is registered.
+func WithConfirmedBlocks() options.Option[TangleListener] {
+	return func(t *TangleListener) {
+		t.confirmedBlocksEnabled = true
+	}
+}
+
+// WithFinalizedBlocks starts the finalized blocks stream together with the listener, which is needed if the
+// BlockFinalized event is used. Otherwise the stream is only started once a finalized callback or event is registered.
+func WithFinalizedBlocks() options.Option[TangleListener] {
+	return func(t *TangleListener) {
+		t.finalizedBlocksEnabled = true
+	}
+}
+
+// WithSynchronousCallbacks executes the block and transaction accepted callbacks synchronously instead of in a new goroutine.
+// This allows deterministic tests of the callback flows.
+func WithSynchronousCallbacks() options.Option[TangleListener] {
+	return func(t *TangleListener) {
+		t.synchronousCallbacks = true
+	}
+}
+
+func NewTangleListener(logger log.Logger, nodeBridge NodeBridge, opts ...options.Option[TangleListener]) *TangleListener {
+	return options.Apply(&TangleListener{
+		Logger:                       logger,
+		nodeBridge:                   nodeBridge,
+		blockMetadataFunc:            nodeBridge.BlockMetadata,
+		transactionMetadataFunc:      nodeBridge.TransactionMetadata,
+		blockAcceptedNotifier:        valuenotifier.New[iotago.BlockID](),
+		transactionAcceptedNotifier:  valuenotifier.New[iotago.TransactionID](),
+		commitmentConfirmedNotifier:  valuenotifier.New[iotago.SlotIndex](),
+		blockConfirmedNotifier:       valuenotifier.New[iotago.BlockID](),
+		blockFinalizedNotifier:       valuenotifier.New[iotago.BlockID](),
+		blockAcceptedCallbacks:       map[iotago.BlockID]BlockAcceptedCallback{},
+		transactionAcceptedCallbacks: map[iotago.TransactionID]TransactionAcceptedCallback{},
+		blockConfirmedCallbacks:      map[iotago.BlockID]BlockConfirmedCallback{},
+		blockFinalizedCallbacks:      map[iotago.BlockID]BlockFinalizedCallback{},
 		Events: &TangleListenerEvents{
-			BlockAccepted: event.New1[*api.BlockMetadataResponse](),
+			BlockAccepted:       event.New1[*api.BlockMetadataResponse](),
+			TransactionAccepted: event.New1[iotago.TransactionID](),
+			BlockConfirmed:      event.New1[*api.BlockMetadataResponse](),
+			BlockFinalized:      event.New1[*api.BlockMetadataResponse](),
 		},
-	}
+	}, opts, func(t *TangleListener) {
+		t.confirmedBlocksStream = &optionalStream{name: "confirmed blocks", listen: t.listenToConfirmedBlocks, requested: t.confirmedBlocksEnabled}
+		t.finalizedBlocksStream = &optionalStream{name: "finalized blocks", listen: t.listenToFinalizedBlocks, requested: t.finalizedBlocksEnabled}
+	})
 }
 
 // RegisterBlockAcceptedCallback registers a callback for when a block with blockID becomes accepted.
@@ -58,10 +164,10 @@ func (t *TangleListener) RegisterBlockAcceptedCallback(ctx context.Context, bloc
 		return err
 	}
 
-	metadata, err := t.nodeBridge.BlockMetadata(ctx, blockID)
+	metadata, err := t.blockMetadataFunc(ctx, blockID)
 	if err != nil {
 		// if the block is not found, then it is also not yet accepted
-		if status.Code(err) == codes.NotFound {
+		if ierrors.Is(err, ErrNotFound) {
 			return nil
 		}
 
@@ -106,11 +212,149 @@ func (t *TangleListener) ClearBlockAcceptedCallbacks() {
 
 func (t *TangleListener) triggerBlockAcceptedCallback(metadata *api.BlockMetadataResponse) {
 	t.blockAcceptedCallbacksLock.Lock()
-	defer t.blockAcceptedCallbacksLock.Unlock()
-	if f, ok := t.blockAcceptedCallbacks[metadata.BlockID]; ok {
-		go f(metadata)
+	f, ok := t.blockAcceptedCallbacks[metadata.BlockID]
+	if ok {
 		delete(t.blockAcceptedCallbacks, metadata.BlockID)
 	}
+	t.blockAcceptedCallbacksLock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if t.synchronousCallbacks {
+		f(metadata)
+	} else {
+		go f(metadata)
+	}
+}
+
+// TriggerBlockAccepted processes the given block metadata as if it was received from the accepted blocks stream.
+// This allows to test the acceptance flows without a running stream.
+func (t *TangleListener) TriggerBlockAccepted(metadata *api.BlockMetadataResponse) {
+	t.triggerBlockAcceptedCallback(metadata)
+	t.blockAcceptedNotifier.Notify(metadata.BlockID)
+	t.Events.BlockAccepted.Trigger(metadata)
+}
+
+// TriggerSlotConfirmed processes the given slot as if it was confirmed by the node.
+// This allows to test the confirmation flows without a running node.
+func (t *TangleListener) TriggerSlotConfirmed(slot iotago.SlotIndex) {
+	t.commitmentConfirmedNotifier.Notify(slot)
+}
+
+// RegisterTransactionAcceptedCallback registers a callback for when a transaction with transactionID becomes accepted.
+// If another callback for the same ID has already been registered, an error is returned.
+func (t *TangleListener) RegisterTransactionAcceptedCallback(ctx context.Context, transactionID iotago.TransactionID, f TransactionAcceptedCallback) error {
+	if err := t.registerTransactionAcceptedCallback(transactionID, f); err != nil {
+		return err
+	}
+
+	accepted, err := t.isTransactionAccepted(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if accepted {
+		// trigger the callback, because the transaction is already accepted
+		t.triggerTransactionAcceptedCallback(transactionID)
+	}
+
+	return nil
+}
+
+func (t *TangleListener) registerTransactionAcceptedCallback(transactionID iotago.TransactionID, f TransactionAcceptedCallback) error {
+	t.transactionAcceptedCallbacksLock.Lock()
+	defer t.transactionAcceptedCallbacksLock.Unlock()
+
+	if _, ok := t.transactionAcceptedCallbacks[transactionID]; ok {
+		return ierrors.Wrapf(ErrAlreadyRegistered, "transaction %s", transactionID)
+	}
+	t.transactionAcceptedCallbacks[transactionID] = f
+
+	return nil
+}
+
+// DeregisterTransactionAcceptedCallback removes a previously registered callback for transactionID.
+func (t *TangleListener) DeregisterTransactionAcceptedCallback(transactionID iotago.TransactionID) {
+	t.transactionAcceptedCallbacksLock.Lock()
+	defer t.transactionAcceptedCallbacksLock.Unlock()
+	delete(t.transactionAcceptedCallbacks, transactionID)
+}
+
+func (t *TangleListener) triggerTransactionAcceptedCallback(transactionID iotago.TransactionID) {
+	t.transactionAcceptedCallbacksLock.Lock()
+	f, ok := t.transactionAcceptedCallbacks[transactionID]
+	if ok {
+		delete(t.transactionAcceptedCallbacks, transactionID)
+	}
+	t.transactionAcceptedCallbacksLock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if t.synchronousCallbacks {
+		f(transactionID)
+	} else {
+		go f(transactionID)
+	}
+}
+
+// TriggerTransactionAccepted processes the given transaction ID as if it was received from the accepted transactions stream.
+// This allows to test the acceptance flows without a running stream.
+func (t *TangleListener) TriggerTransactionAccepted(transactionID iotago.TransactionID) {
+	t.triggerTransactionAcceptedCallback(transactionID)
+	t.transactionAcceptedNotifier.Notify(transactionID)
+	t.Events.TransactionAccepted.Trigger(transactionID)
+}
+
+// RegisterTransactionAcceptedEvent registers an event for when the transaction with transactionID becomes accepted.
+// If the transaction is already accepted, the event is triggered immediately.
+func (t *TangleListener) RegisterTransactionAcceptedEvent(ctx context.Context, transactionID iotago.TransactionID) (*valuenotifier.Listener, error) {
+	transactionAcceptedListener := t.transactionAcceptedNotifier.Listener(transactionID)
+
+	accepted, err := t.isTransactionAccepted(ctx, transactionID)
+	if err != nil {
+		// in case of an error, we need to deregister the listener
+		transactionAcceptedListener.Deregister()
+
+		return nil, err
+	}
+
+	if accepted {
+		// trigger the sync event, because the transaction is already accepted
+		t.transactionAcceptedNotifier.Notify(transactionID)
+	}
+
+	return transactionAcceptedListener, nil
+}
+
+// AwaitTransactionAccepted blocks until the transaction with transactionID is accepted or the context is done.
+func (t *TangleListener) AwaitTransactionAccepted(ctx context.Context, transactionID iotago.TransactionID) error {
+	transactionAcceptedListener, err := t.RegisterTransactionAcceptedEvent(ctx, transactionID)
+	if err != nil {
+		return err
+	}
+
+	return transactionAcceptedListener.Wait(ctx)
+}
+
+// isTransactionAccepted checks via the transaction metadata if the transaction is already accepted.
+func (t *TangleListener) isTransactionAccepted(ctx context.Context, transactionID iotago.TransactionID) (bool, error) {
+	metadata, err := t.transactionMetadataFunc(ctx, transactionID)
+	if err != nil {
+		// if the transaction is not found, then it is also not yet accepted
+		if ierrors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return metadata.TransactionState == api.TransactionStateAccepted ||
+		metadata.TransactionState == api.TransactionStateCommitted ||
+		metadata.TransactionState == api.TransactionStateFinalized, nil
 }
 
 // RegisterBlockAcceptedEvent registers an event for when the block with blockID becomes accepted.
@@ -119,10 +363,10 @@ func (t *TangleListener) RegisterBlockAcceptedEvent(ctx context.Context, blockID
 	blockAcceptedListener := t.blockAcceptedNotifier.Listener(blockID)
 
 	// check if the block is already accepted
-	metadata, err := t.nodeBridge.BlockMetadata(ctx, blockID)
+	metadata, err := t.blockMetadataFunc(ctx, blockID)
 	if err != nil {
 		// if the block is not found, then it is also not yet accepted
-		if status.Code(err) == codes.NotFound {
+		if ierrors.Is(err, ErrNotFound) {
 			return blockAcceptedListener, nil
 		}
 
@@ -142,6 +386,225 @@ func (t *TangleListener) RegisterBlockAcceptedEvent(ctx context.Context, blockID
 	return blockAcceptedListener, nil
 }
 
+// RegisterBlockConfirmedCallback registers a callback for when a block with blockID becomes confirmed.
+// If another callback for the same ID has already been registered, an error is returned.
+func (t *TangleListener) RegisterBlockConfirmedCallback(ctx context.Context, blockID iotago.BlockID, f BlockConfirmedCallback) error {
+	if err := t.registerBlockConfirmedCallback(blockID, f); err != nil {
+		return err
+	}
+	t.requestOptionalStream(t.confirmedBlocksStream)
+
+	metadata, reached, err := t.blockStateReached(ctx, blockID, api.BlockStateConfirmed)
+	if err != nil {
+		return err
+	}
+
+	if reached {
+		// trigger the callback, because the block is already confirmed
+		t.triggerBlockConfirmedCallback(metadata)
+	}
+
+	return nil
+}
+
+func (t *TangleListener) registerBlockConfirmedCallback(blockID iotago.BlockID, f BlockConfirmedCallback) error {
+	t.blockConfirmedCallbacksLock.Lock()
+	defer t.blockConfirmedCallbacksLock.Unlock()
+
+	if _, ok := t.blockConfirmedCallbacks[blockID]; ok {
+		return ierrors.Wrapf(ErrAlreadyRegistered, "block %s", blockID)
+	}
+	t.blockConfirmedCallbacks[blockID] = f
+
+	return nil
+}
+
+// DeregisterBlockConfirmedCallback removes a previously registered callback for blockID.
+func (t *TangleListener) DeregisterBlockConfirmedCallback(blockID iotago.BlockID) {
+	t.blockConfirmedCallbacksLock.Lock()
+	defer t.blockConfirmedCallbacksLock.Unlock()
+	delete(t.blockConfirmedCallbacks, blockID)
+}
+
+func (t *TangleListener) triggerBlockConfirmedCallback(metadata *api.BlockMetadataResponse) {
+	t.blockConfirmedCallbacksLock.Lock()
+	f, ok := t.blockConfirmedCallbacks[metadata.BlockID]
+	if ok {
+		delete(t.blockConfirmedCallbacks, metadata.BlockID)
+	}
+	t.blockConfirmedCallbacksLock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if t.synchronousCallbacks {
+		f(metadata)
+	} else {
+		go f(metadata)
+	}
+}
+
+// TriggerBlockConfirmed processes the given block metadata as if it was received from the confirmed blocks stream.
+// This allows to test the confirmation flows without a running stream.
+func (t *TangleListener) TriggerBlockConfirmed(metadata *api.BlockMetadataResponse) {
+	t.triggerBlockConfirmedCallback(metadata)
+	t.blockConfirmedNotifier.Notify(metadata.BlockID)
+	t.Events.BlockConfirmed.Trigger(metadata)
+}
+
+// RegisterBlockConfirmedEvent registers an event for when the block with blockID becomes confirmed.
+// If the block is already confirmed, the event is triggered immediately.
+func (t *TangleListener) RegisterBlockConfirmedEvent(ctx context.Context, blockID iotago.BlockID) (*valuenotifier.Listener, error) {
+	blockConfirmedListener := t.blockConfirmedNotifier.Listener(blockID)
+	t.requestOptionalStream(t.confirmedBlocksStream)
+
+	_, reached, err := t.blockStateReached(ctx, blockID, api.BlockStateConfirmed)
+	if err != nil {
+		// in case of an error, we need to deregister the listener
+		blockConfirmedListener.Deregister()
+
+		return nil, err
+	}
+
+	if reached {
+		// trigger the sync event, because the block is already confirmed
+		t.blockConfirmedNotifier.Notify(blockID)
+	}
+
+	return blockConfirmedListener, nil
+}
+
+// AwaitBlockConfirmed blocks until the block with blockID is confirmed or the context is done.
+func (t *TangleListener) AwaitBlockConfirmed(ctx context.Context, blockID iotago.BlockID) error {
+	blockConfirmedListener, err := t.RegisterBlockConfirmedEvent(ctx, blockID)
+	if err != nil {
+		return err
+	}
+
+	return blockConfirmedListener.Wait(ctx)
+}
+
+// RegisterBlockFinalizedCallback registers a callback for when the slot of the block with blockID becomes finalized.
+// If another callback for the same ID has already been registered, an error is returned.
+func (t *TangleListener) RegisterBlockFinalizedCallback(ctx context.Context, blockID iotago.BlockID, f BlockFinalizedCallback) error {
+	if err := t.registerBlockFinalizedCallback(blockID, f); err != nil {
+		return err
+	}
+	t.requestOptionalStream(t.finalizedBlocksStream)
+
+	metadata, reached, err := t.blockStateReached(ctx, blockID, api.BlockStateFinalized)
+	if err != nil {
+		return err
+	}
+
+	if reached {
+		// trigger the callback, because the block is already finalized
+		t.triggerBlockFinalizedCallback(metadata)
+	}
+
+	return nil
+}
+
+func (t *TangleListener) registerBlockFinalizedCallback(blockID iotago.BlockID, f BlockFinalizedCallback) error {
+	t.blockFinalizedCallbacksLock.Lock()
+	defer t.blockFinalizedCallbacksLock.Unlock()
+
+	if _, ok := t.blockFinalizedCallbacks[blockID]; ok {
+		return ierrors.Wrapf(ErrAlreadyRegistered, "block %s", blockID)
+	}
+	t.blockFinalizedCallbacks[blockID] = f
+
+	return nil
+}
+
+// DeregisterBlockFinalizedCallback removes a previously registered callback for blockID.
+func (t *TangleListener) DeregisterBlockFinalizedCallback(blockID iotago.BlockID) {
+	t.blockFinalizedCallbacksLock.Lock()
+	defer t.blockFinalizedCallbacksLock.Unlock()
+	delete(t.blockFinalizedCallbacks, blockID)
+}
+
+func (t *TangleListener) triggerBlockFinalizedCallback(metadata *api.BlockMetadataResponse) {
+	t.blockFinalizedCallbacksLock.Lock()
+	f, ok := t.blockFinalizedCallbacks[metadata.BlockID]
+	if ok {
+		delete(t.blockFinalizedCallbacks, metadata.BlockID)
+	}
+	t.blockFinalizedCallbacksLock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if t.synchronousCallbacks {
+		f(metadata)
+	} else {
+		go f(metadata)
+	}
+}
+
+// TriggerBlockFinalized processes the given block metadata as if the slot of the accepted block was finalized.
+// This allows to test the finalization flows without a running stream.
+func (t *TangleListener) TriggerBlockFinalized(metadata *api.BlockMetadataResponse) {
+	t.triggerBlockFinalizedCallback(metadata)
+	t.blockFinalizedNotifier.Notify(metadata.BlockID)
+	t.Events.BlockFinalized.Trigger(metadata)
+}
+
+// RegisterBlockFinalizedEvent registers an event for when the slot of the block with blockID becomes finalized.
+// If the block is already finalized, the event is triggered immediately.
+func (t *TangleListener) RegisterBlockFinalizedEvent(ctx context.Context, blockID iotago.BlockID) (*valuenotifier.Listener, error) {
+	blockFinalizedListener := t.blockFinalizedNotifier.Listener(blockID)
+	t.requestOptionalStream(t.finalizedBlocksStream)
+
+	_, reached, err := t.blockStateReached(ctx, blockID, api.BlockStateFinalized)
+	if err != nil {
+		// in case of an error, we need to deregister the listener
+		blockFinalizedListener.Deregister()
+
+		return nil, err
+	}
+
+	if reached {
+		// trigger the sync event, because the block is already finalized
+		t.blockFinalizedNotifier.Notify(blockID)
+	}
+
+	return blockFinalizedListener, nil
+}
+
+// AwaitBlockFinalized blocks until the slot of the block with blockID is finalized or the context is done.
+func (t *TangleListener) AwaitBlockFinalized(ctx context.Context, blockID iotago.BlockID) error {
+	blockFinalizedListener, err := t.RegisterBlockFinalizedEvent(ctx, blockID)
+	if err != nil {
+		return err
+	}
+
+	return blockFinalizedListener.Wait(ctx)
+}
+
+// blockStateReached checks via the block metadata if the block already reached the given state.
+// The states are ordered: accepted < confirmed < finalized.
+func (t *TangleListener) blockStateReached(ctx context.Context, blockID iotago.BlockID, state api.BlockState) (*api.BlockMetadataResponse, bool, error) {
+	metadata, err := t.blockMetadataFunc(ctx, blockID)
+	if err != nil {
+		// if the block is not found, then it also didn't reach the state yet
+		if ierrors.Is(err, ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	switch metadata.BlockState {
+	case api.BlockStateAccepted, api.BlockStateConfirmed, api.BlockStateFinalized:
+		return metadata, metadata.BlockState >= state, nil
+	default:
+		return metadata, false, nil
+	}
+}
+
 // RegisterSlotConfirmedEvent registers an event for when the slot with sIndex gets confirmed.
 // If the slot is already confirmed, the event is triggered immediately.
 func (t *TangleListener) RegisterSlotConfirmedEvent(slot iotago.SlotIndex) *valuenotifier.Listener {
@@ -156,26 +619,78 @@ func (t *TangleListener) RegisterSlotConfirmedEvent(slot iotago.SlotIndex) *valu
 	return slotConfirmedListener
 }
 
+// Run listens to the accepted blocks and transactions until the context is canceled or one of the streams failed.
+// The confirmed and finalized blocks streams are started on demand and run independently,
+// so a failure of one of them does not affect the tracking of the accepted blocks and transactions.
 func (t *TangleListener) Run(ctx context.Context) {
-	c, cancel := context.WithCancel(ctx)
-	defer cancel()
+	optionalStreamsCtx, cancelOptionalStreams := context.WithCancel(ctx)
+	defer func() {
+		t.optionalStreamsLock.Lock()
+		t.runCtx = nil
+		t.optionalStreamsLock.Unlock()
+
+		cancelOptionalStreams()
+		t.optionalStreamsWg.Wait()
+	}()
 
-	go func() {
-		if err := t.listenToAcceptedBlocks(c, cancel); err != nil {
-			t.LogErrorf("Error listening to accepted blocks: %s", err.Error())
+	t.optionalStreamsLock.Lock()
+	t.runCtx = optionalStreamsCtx
+	for _, stream := range []*optionalStream{t.confirmedBlocksStream, t.finalizedBlocksStream} {
+		if stream.requested {
+			t.startOptionalStreamWithoutLocking(stream)
 		}
-	}()
+	}
+	t.optionalStreamsLock.Unlock()
+
+	streamGroup := NewStreamGroup(ctx, DefaultStreamGroupDrainTimeout)
+	streamGroup.Go("accepted blocks", t.listenToAcceptedBlocks)
+	streamGroup.Go("accepted transactions", t.listenToAcceptedTransactions)
 
 	hook := t.nodeBridge.Events().LatestFinalizedCommitmentChanged.Hook(func(c *Commitment) {
-		t.commitmentConfirmedNotifier.Notify(c.Commitment.Slot)
+		t.TriggerSlotConfirmed(c.Commitment.Slot)
 	})
 	defer hook.Unhook()
-	<-c.Done()
+
+	if err := streamGroup.Wait(); err != nil {
+		t.LogErrorf("Error listening to blocks and transactions: %s", err.Error())
+	}
+}
+
+// requestOptionalStream starts the given stream if the listener is running and the stream is not running yet.
+// Otherwise the stream is started once the listener is running.
+func (t *TangleListener) requestOptionalStream(stream *optionalStream) {
+	t.optionalStreamsLock.Lock()
+	defer t.optionalStreamsLock.Unlock()
+
+	stream.requested = true
+	if t.runCtx != nil && !stream.running {
+		t.startOptionalStreamWithoutLocking(stream)
+	}
 }
 
-func (t *TangleListener) listenToAcceptedBlocks(ctx context.Context, cancel context.CancelFunc) error {
-	defer cancel()
+// startOptionalStreamWithoutLocking starts the given stream with the context of the running listener.
+// If the stream fails, it is started again by the next registration that needs it.
+func (t *TangleListener) startOptionalStreamWithoutLocking(stream *optionalStream) {
+	ctx := t.runCtx
+	stream.running = true
 
+	t.optionalStreamsWg.Add(1)
+	go func() {
+		defer t.optionalStreamsWg.Done()
+
+		err := stream.listen(ctx)
+
+		t.optionalStreamsLock.Lock()
+		stream.running = false
+		t.optionalStreamsLock.Unlock()
+
+		if err != nil && ctx.Err() == nil {
+			t.LogWarnf("%s stream stopped, it is restarted by the next registration: %s", stream.name, err.Error())
+		}
+	}()
+}
+
+func (t *TangleListener) listenToAcceptedBlocks(ctx context.Context) error {
 	stream, err := t.nodeBridge.Client().ListenToAcceptedBlocks(ctx, &inx.NoParams{})
 	if err != nil {
 		return err
@@ -187,9 +702,7 @@ func (t *TangleListener) listenToAcceptedBlocks(ctx context.Context, cancel cont
 			return ierrors.Wrap(err, "failed to unwrap metadata in listenToAcceptedBlocks")
 		}
 
-		t.triggerBlockAcceptedCallback(metadata)
-		t.blockAcceptedNotifier.Notify(metadata.BlockID)
-		t.Events.BlockAccepted.Trigger(metadata)
+		t.TriggerBlockAccepted(metadata)
 
 		return nil
 	}); err != nil {
@@ -199,3 +712,42 @@ func (t *TangleListener) listenToAcceptedBlocks(ctx context.Context, cancel cont
 
 	return nil
 }
+
+func (t *TangleListener) listenToAcceptedTransactions(ctx context.Context) error {
+	if err := t.nodeBridge.ListenToAcceptedTransactions(ctx, func(tx *AcceptedTransaction) error {
+		t.TriggerTransactionAccepted(tx.TransactionID)
+
+		return nil
+	}); err != nil {
+		t.LogErrorf("listenToAcceptedTransactions failed: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (t *TangleListener) listenToConfirmedBlocks(ctx context.Context) error {
+	if err := t.nodeBridge.ListenToConfirmedBlocks(ctx, func(metadata *api.BlockMetadataResponse) error {
+		t.TriggerBlockConfirmed(metadata)
+
+		return nil
+	}); err != nil {
+		t.LogErrorf("listenToConfirmedBlocks failed: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (t *TangleListener) listenToFinalizedBlocks(ctx context.Context) error {
+	if err := t.nodeBridge.ListenToFinalizedBlocks(ctx, func(metadata *api.BlockMetadataResponse) error {
+		t.TriggerBlockFinalized(metadata)
+
+		return nil
+	}); err != nil {
+		t.LogErrorf("listenToFinalizedBlocks failed: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package nodebridge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultFallbackTipsCapacity is the default amount of recently accepted blocks that are tracked as fallback tips.
+	DefaultFallbackTipsCapacity = 64
+	// DefaultFallbackTipsMaxAge is the default time after which an accepted block is no longer used as fallback tip.
+	DefaultFallbackTipsMaxAge = 30 * time.Second
+)
+
+// ErrNoFallbackTips is returned if the tips could not be requested from the node and no recently accepted blocks are known.
+var ErrNoFallbackTips = ierrors.New("no recently accepted blocks available as fallback tips")
+
+// TipProvider provides the parents of new blocks.
+type TipProvider interface {
+	// Tips returns up to count strong, weak and shallow like parents.
+	Tips(ctx context.Context, count uint32) (strong iotago.BlockIDs, weak iotago.BlockIDs, shallowLike iotago.BlockIDs, err error)
+}
+
+// nodeTipProvider requests the tips from the tip pool of the node.
+type nodeTipProvider struct {
+	nodeBridge NodeBridge
+}
+
+// NewNodeTipProvider creates a TipProvider that requests the tips from the tip pool of the node via RequestTips.
+func NewNodeTipProvider(nodeBridge NodeBridge) TipProvider {
+	return &nodeTipProvider{
+		nodeBridge: nodeBridge,
+	}
+}
+
+// Tips requests the tips from the node.
+func (p *nodeTipProvider) Tips(ctx context.Context, count uint32) (iotago.BlockIDs, iotago.BlockIDs, iotago.BlockIDs, error) {
+	return p.nodeBridge.RequestTips(ctx, count)
+}
+
+// fallbackTip is a recently accepted block that is offered as parent if the node can't provide tips.
+type fallbackTip struct {
+	blockID    iotago.BlockID
+	acceptedAt time.Time
+}
+
+// FallbackTipProvider requests the tips from the node and falls back to recently accepted blocks
+// if the tip pool of the node is unavailable, so issuers don't stall while the node can't provide tips.
+// The accepted blocks are tracked via the BlockAccepted event of the TangleListener while Run is active.
+type FallbackTipProvider struct {
+	log.Logger
+
+	tipProvider    TipProvider
+	tangleListener *TangleListener
+	capacity       int
+	maxAge         time.Duration
+
+	tipsMutex sync.RWMutex
+	// tips is a ring buffer of the recently accepted blocks.
+	tips []*fallbackTip
+	next int
+}
+
+// WithFallbackTipsCapacity sets the amount of recently accepted blocks that are tracked as fallback tips.
+func WithFallbackTipsCapacity(capacity int) options.Option[FallbackTipProvider] {
+	return func(p *FallbackTipProvider) {
+		p.capacity = capacity
+	}
+}
+
+// WithFallbackTipsMaxAge sets the time after which an accepted block is no longer used as fallback tip.
+func WithFallbackTipsMaxAge(maxAge time.Duration) options.Option[FallbackTipProvider] {
+	return func(p *FallbackTipProvider) {
+		p.maxAge = maxAge
+	}
+}
+
+// WithPrimaryTipProvider sets the TipProvider that is asked first, it defaults to the tip pool of the node.
+func WithPrimaryTipProvider(tipProvider TipProvider) options.Option[FallbackTipProvider] {
+	return func(p *FallbackTipProvider) {
+		p.tipProvider = tipProvider
+	}
+}
+
+// NewFallbackTipProvider creates a new FallbackTipProvider.
+func NewFallbackTipProvider(logger log.Logger, nodeBridge NodeBridge, tangleListener *TangleListener, opts ...options.Option[FallbackTipProvider]) *FallbackTipProvider {
+	return options.Apply(&FallbackTipProvider{
+		Logger:         logger,
+		tipProvider:    NewNodeTipProvider(nodeBridge),
+		tangleListener: tangleListener,
+		capacity:       DefaultFallbackTipsCapacity,
+		maxAge:         DefaultFallbackTipsMaxAge,
+	}, opts, func(p *FallbackTipProvider) {
+		p.tips = make([]*fallbackTip, p.capacity)
+	})
+}
+
+// Run tracks the accepted blocks of the TangleListener until the context is canceled.
+func (p *FallbackTipProvider) Run(ctx context.Context) {
+	hook := p.tangleListener.Events.BlockAccepted.Hook(func(metadata *api.BlockMetadataResponse) {
+		p.addTip(metadata.BlockID, time.Now())
+	})
+	defer hook.Unhook()
+
+	<-ctx.Done()
+}
+
+func (p *FallbackTipProvider) addTip(blockID iotago.BlockID, acceptedAt time.Time) {
+	if p.capacity <= 0 {
+		return
+	}
+
+	p.tipsMutex.Lock()
+	defer p.tipsMutex.Unlock()
+
+	p.tips[p.next] = &fallbackTip{blockID: blockID, acceptedAt: acceptedAt}
+	p.next = (p.next + 1) % p.capacity
+}
+
+// Tips requests the tips from the primary TipProvider.
+// If that fails, up to count of the most recently accepted blocks are returned as strong parents.
+func (p *FallbackTipProvider) Tips(ctx context.Context, count uint32) (iotago.BlockIDs, iotago.BlockIDs, iotago.BlockIDs, error) {
+	strong, weak, shallowLike, err := p.tipProvider.Tips(ctx, count)
+	if err == nil && len(strong) > 0 {
+		return strong, weak, shallowLike, nil
+	}
+	if ctx.Err() != nil {
+		return nil, nil, nil, ierrors.Join(err, ctx.Err())
+	}
+	if err == nil {
+		err = ierrors.New("the node returned no strong tips")
+	}
+
+	fallbackTips := p.FallbackTips(count)
+	if len(fallbackTips) == 0 {
+		return nil, nil, nil, ierrors.Join(ErrNoFallbackTips, err)
+	}
+
+	p.LogWarnf("requesting tips failed, using %d recently accepted blocks as tips: %s", len(fallbackTips), err)
+
+	return fallbackTips, iotago.BlockIDs{}, iotago.BlockIDs{}, nil
+}
+
+// FallbackTips returns up to count of the most recently accepted blocks that are not older than the maximum age.
+// The block IDs are unique and sorted lexically, as required for the parents of a block.
+func (p *FallbackTipProvider) FallbackTips(count uint32) iotago.BlockIDs {
+	p.tipsMutex.RLock()
+	defer p.tipsMutex.RUnlock()
+
+	// the same block can be accepted again after the stream was restarted, so duplicates are skipped
+	seen := make(map[iotago.BlockID]struct{}, min(int(count), p.capacity))
+	tips := make(iotago.BlockIDs, 0, min(int(count), p.capacity))
+	now := time.Now()
+	for i := 1; i <= p.capacity && len(tips) < int(count); i++ {
+		tip := p.tips[(p.next-i+p.capacity)%p.capacity]
+		if tip == nil || now.Sub(tip.acceptedAt) > p.maxAge {
+			// the ring buffer is ordered by the acceptance, so all following tips are older
+			break
+		}
+
+		if _, exists := seen[tip.blockID]; exists {
+			continue
+		}
+		seen[tip.blockID] = struct{}{}
+
+		tips = append(tips, tip.blockID)
+	}
+
+	return tips.RemoveDupsAndSort()
+}
@@ -0,0 +1,107 @@
+package nodebridge_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	"github.com/iotaledger/inx-app/pkg/nodebridge/mock"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/tpkg"
+)
+
+// unavailableTipProvider is a TipProvider of a node whose tip pool is unavailable.
+type unavailableTipProvider struct{}
+
+func (unavailableTipProvider) Tips(_ context.Context, _ uint32) (iotago.BlockIDs, iotago.BlockIDs, iotago.BlockIDs, error) {
+	return nil, nil, nil, ierrors.Wrap(nodebridge.ErrUnavailable, "tip pool unavailable")
+}
+
+func TestFallbackTipProviderTips(t *testing.T) {
+	blockIDs := tpkg.SortedRandBlockIDs(12)
+
+	tests := []struct {
+		name     string
+		accepted []iotago.BlockID
+		count    uint32
+		wantTips iotago.BlockIDs
+	}{
+		{
+			name:     "most recently accepted block is not the first parent",
+			accepted: []iotago.BlockID{blockIDs[0], blockIDs[1], blockIDs[2]},
+			count:    iotago.BasicBlockMaxParents,
+			wantTips: iotago.BlockIDs{blockIDs[0], blockIDs[1], blockIDs[2]},
+		},
+		{
+			name:     "blocks accepted again after a stream restart",
+			accepted: []iotago.BlockID{blockIDs[1], blockIDs[0], blockIDs[1], blockIDs[0], blockIDs[2]},
+			count:    iotago.BasicBlockMaxParents,
+			wantTips: iotago.BlockIDs{blockIDs[0], blockIDs[1], blockIDs[2]},
+		},
+		{
+			name:     "only the most recently accepted blocks",
+			accepted: blockIDs,
+			count:    iotago.BasicBlockMaxParents,
+			wantTips: blockIDs[len(blockIDs)-iotago.BasicBlockMaxParents:],
+		},
+		{
+			name:     "duplicates don't count towards the limit",
+			accepted: []iotago.BlockID{blockIDs[0], blockIDs[1], blockIDs[2], blockIDs[2], blockIDs[2]},
+			count:    2,
+			wantTips: iotago.BlockIDs{blockIDs[1], blockIDs[2]},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tangleListener := nodebridge.NewTangleListener(log.NewLogger(), mock.New(iotago.SingleVersionProvider(tpkg.ZeroCostTestAPI)))
+			tipProvider := nodebridge.NewFallbackTipProvider(log.NewLogger(), nil, tangleListener,
+				nodebridge.WithPrimaryTipProvider(unavailableTipProvider{}),
+				nodebridge.WithFallbackTipsMaxAge(time.Hour),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go tipProvider.Run(ctx)
+
+			// the blocks are only tracked once Run hooked to the TangleListener
+			require.Eventually(t, func() bool {
+				for _, blockID := range test.accepted {
+					tangleListener.TriggerBlockAccepted(&api.BlockMetadataResponse{BlockID: blockID})
+				}
+
+				return len(tipProvider.FallbackTips(1)) > 0
+			}, time.Second, time.Millisecond)
+
+			strong, weak, shallowLike, err := tipProvider.Tips(ctx, test.count)
+			require.NoError(t, err)
+			require.Equal(t, test.wantTips, strong)
+
+			// the fallback tips must be valid parents of a block
+			body := tpkg.RandBasicBlockBody(tpkg.ZeroCostTestAPI, iotago.PayloadTaggedData)
+			body.StrongParents, body.WeakParents, body.ShallowLikeParents = strong, weak, shallowLike
+			block := tpkg.RandBlock(body, tpkg.ZeroCostTestAPI, 0)
+
+			_, err = tpkg.ZeroCostTestAPI.Encode(block, serix.WithValidation())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFallbackTipProviderNoFallbackTips(t *testing.T) {
+	tangleListener := nodebridge.NewTangleListener(log.NewLogger(), mock.New(iotago.SingleVersionProvider(tpkg.ZeroCostTestAPI)))
+	tipProvider := nodebridge.NewFallbackTipProvider(log.NewLogger(), nil, tangleListener,
+		nodebridge.WithPrimaryTipProvider(unavailableTipProvider{}),
+	)
+
+	_, _, _, err := tipProvider.Tips(context.Background(), iotago.BasicBlockMaxParents)
+	require.ErrorIs(t, err, nodebridge.ErrNoFallbackTips)
+	require.ErrorIs(t, err, nodebridge.ErrUnavailable)
+}
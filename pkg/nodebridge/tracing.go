@@ -0,0 +1,85 @@
+package nodebridge
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+
+	"github.com/iotaledger/hive.go/runtime/options"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of the NodeBridge.
+const tracerName = "github.com/iotaledger/inx-app/pkg/nodebridge"
+
+// noopTracer is used if the tracing is disabled.
+var noopTracer = noop.NewTracerProvider().Tracer(tracerName)
+
+// The attribute keys of the spans of the NodeBridge.
+const (
+	AttributeKeySlot          = attribute.Key("iota.slot")
+	AttributeKeyCommitmentID  = attribute.Key("iota.commitment_id")
+	AttributeKeyBlockID       = attribute.Key("iota.block_id")
+	AttributeKeyConsumedCount = attribute.Key("iota.ledger_update.consumed_count")
+	AttributeKeyCreatedCount  = attribute.Key("iota.ledger_update.created_count")
+)
+
+// WithTracing enables the OpenTelemetry tracing of the NodeBridge.
+// Every gRPC call and stream to the node becomes a span, and spans are created around high-level operations
+// like SubmitBlock and every ledger update batch of ListenToLedgerUpdates.
+// The trace context of the calls is propagated to the node.
+// The global tracer provider is used if tracerProvider is nil.
+func WithTracing(tracerProvider trace.TracerProvider) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		if tracerProvider == nil {
+			tracerProvider = otel.GetTracerProvider()
+		}
+
+		n.tracerProvider = tracerProvider
+		n.tracer = tracerProvider.Tracer(tracerName)
+	}
+}
+
+// tracingDialOptions returns the dial options that trace the gRPC calls, if the tracing is enabled.
+func (n *nodeBridge) tracingDialOptions() []grpc.DialOption {
+	if n.tracerProvider == nil {
+		return nil
+	}
+
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(n.tracerProvider))),
+	}
+}
+
+// startSpan starts a span of a high-level operation, it is a no-op if the tracing is disabled.
+func (n *nodeBridge) startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return n.tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan ends the span and records the error, if there is one.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func slotAttribute(slot iotago.SlotIndex) attribute.KeyValue {
+	return AttributeKeySlot.Int64(int64(slot))
+}
+
+func commitmentIDAttribute(commitmentID iotago.CommitmentID) attribute.KeyValue {
+	return AttributeKeyCommitmentID.String(commitmentID.ToHex())
+}
+
+func blockIDAttribute(blockID iotago.BlockID) attribute.KeyValue {
+	return AttributeKeyBlockID.String(blockID.ToHex())
+}
@@ -2,12 +2,32 @@ package nodebridge
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
+	"golang.org/x/sync/errgroup"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v4"
 	"github.com/iotaledger/iota.go/v4/api"
 )
 
+// DefaultTransactionMetadataConcurrency is the default maximum amount of concurrent lookups of TransactionMetadataBatch.
+const DefaultTransactionMetadataConcurrency = 16
+
+// TransactionOutputs contains the outputs created and consumed by a transaction.
+type TransactionOutputs struct {
+	// TransactionID is the ID of the transaction.
+	TransactionID iotago.TransactionID
+	// Created are the outputs created by the transaction, ordered by output index.
+	Created []*Output
+	// Consumed are the outputs consumed by the transaction, ordered like the inputs of the transaction.
+	// Consumed is nil if the consumed outputs are not known to the node anymore.
+	Consumed []*Output
+}
+
 // TransactionMetadata returns the transaction metadata for the given transaction ID.
 func (n *nodeBridge) TransactionMetadata(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error) {
 	inxTransactionMetadata, err := n.client.ReadTransactionMetadata(ctx, inx.NewTransactionId(transactionID))
@@ -17,3 +37,198 @@ func (n *nodeBridge) TransactionMetadata(ctx context.Context, transactionID iota
 
 	return inxTransactionMetadata.Unwrap(), nil
 }
+
+// WithTransactionMetadataConcurrency sets the maximum amount of concurrent lookups of TransactionMetadataBatch.
+func WithTransactionMetadataConcurrency(concurrency int) options.Option[nodeBridge] {
+	return func(n *nodeBridge) {
+		n.transactionMetadataConcurrency = concurrency
+	}
+}
+
+// TransactionMetadataBatchError is returned by TransactionMetadataBatch if some of the lookups failed.
+type TransactionMetadataBatchError struct {
+	// Errors contains the error of every failed lookup.
+	Errors map[iotago.TransactionID]error
+}
+
+// Error returns the error message.
+func (e *TransactionMetadataBatchError) Error() string {
+	return fmt.Sprintf("%d transaction metadata lookups failed", len(e.Errors))
+}
+
+// Unwrap returns the errors of the failed lookups, so ierrors.Is matches them.
+func (e *TransactionMetadataBatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// TransactionMetadataBatch returns the transaction metadata for the given transaction IDs.
+// The lookups are executed concurrently over the INX connection, bounded by the transaction metadata concurrency.
+// A failed lookup doesn't cancel the others: the metadata of all successful lookups is returned together with a
+// TransactionMetadataBatchError that contains the error of every failed lookup.
+func (n *nodeBridge) TransactionMetadataBatch(ctx context.Context, transactionIDs []iotago.TransactionID) (map[iotago.TransactionID]*api.TransactionMetadataResponse, error) {
+	return ReadTransactionMetadataBatch(ctx, transactionIDs, n.transactionMetadataConcurrency, n.TransactionMetadata)
+}
+
+// ReadTransactionMetadataBatch reads the transaction metadata for the given transaction IDs with the given reader,
+// executing at most concurrency lookups at the same time. Duplicate transaction IDs are only looked up once.
+// If the context is canceled, the context error is returned instead of a TransactionMetadataBatchError.
+func ReadTransactionMetadataBatch(ctx context.Context, transactionIDs []iotago.TransactionID, concurrency int, readMetadata func(ctx context.Context, transactionID iotago.TransactionID) (*api.TransactionMetadataResponse, error)) (map[iotago.TransactionID]*api.TransactionMetadataResponse, error) {
+	var mutex sync.Mutex
+	metadata := make(map[iotago.TransactionID]*api.TransactionMetadataResponse, len(transactionIDs))
+	errs := make(map[iotago.TransactionID]error)
+
+	// the group is not bound to a context, because a failed lookup must not cancel the others
+	var group errgroup.Group
+	group.SetLimit(max(concurrency, 1))
+
+	requested := make(map[iotago.TransactionID]struct{}, len(transactionIDs))
+	for _, transactionID := range transactionIDs {
+		if _, exists := requested[transactionID]; exists {
+			continue
+		}
+		requested[transactionID] = struct{}{}
+
+		group.Go(func() error {
+			transactionMetadata, err := readMetadata(ctx, transactionID)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				errs[transactionID] = err
+				return nil
+			}
+			metadata[transactionID] = transactionMetadata
+
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(errs) > 0 {
+		return metadata, &TransactionMetadataBatchError{Errors: errs}
+	}
+
+	return metadata, nil
+}
+
+// TransactionOutputs returns the outputs created by the transaction with the given transaction ID,
+// and the outputs consumed by it if they are still known to the node.
+func (n *nodeBridge) TransactionOutputs(ctx context.Context, transactionID iotago.TransactionID) (*TransactionOutputs, error) {
+	firstOutput, err := n.Output(ctx, iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	// the block is not known to the node anymore if it was pruned
+	var signedTransaction *iotago.SignedTransaction
+	block, err := n.Block(ctx, firstOutput.Metadata.BlockID)
+	switch {
+	case err == nil:
+		if signedTransaction, err = blockSignedTransaction(firstOutput.Metadata.BlockID, block); err != nil {
+			return nil, err
+		}
+	case !ierrors.Is(err, ErrNotFound):
+		return nil, ierrors.Wrapf(err, "unable to read block %s of transaction %s", firstOutput.Metadata.BlockID.ToHex(), transactionID.ToHex())
+	}
+
+	created, err := n.transactionCreatedOutputs(ctx, transactionID, firstOutput, signedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumed []*Output
+	if signedTransaction != nil {
+		consumed, err = n.transactionConsumedOutputs(ctx, signedTransaction)
+		if err != nil {
+			return nil, ierrors.Wrapf(err, "unable to resolve consumed outputs of transaction %s", transactionID.ToHex())
+		}
+	}
+
+	return &TransactionOutputs{
+		TransactionID: transactionID,
+		Created:       created,
+		Consumed:      consumed,
+	}, nil
+}
+
+// blockSignedTransaction returns the signed transaction that is contained in the block with the given block ID.
+func blockSignedTransaction(blockID iotago.BlockID, block *iotago.Block) (*iotago.SignedTransaction, error) {
+	basicBlockBody, isBasicBlock := block.Body.(*iotago.BasicBlockBody)
+	if !isBasicBlock {
+		return nil, ierrors.Errorf("block %s is not a basic block", blockID.ToHex())
+	}
+
+	signedTransaction, isSignedTransaction := basicBlockBody.Payload.(*iotago.SignedTransaction)
+	if !isSignedTransaction {
+		return nil, ierrors.Errorf("block %s does not contain a transaction", blockID.ToHex())
+	}
+
+	return signedTransaction, nil
+}
+
+// transactionCreatedOutputs returns the outputs created by the transaction with the given transaction ID, ordered by output index.
+// The amount of outputs is taken from the signed transaction, so the remaining outputs are read concurrently.
+// If the block of the transaction is not known to the node anymore, the outputs are read one by one until the first missing one.
+func (n *nodeBridge) transactionCreatedOutputs(ctx context.Context, transactionID iotago.TransactionID, firstOutput *Output, signedTransaction *iotago.SignedTransaction) ([]*Output, error) {
+	if signedTransaction != nil {
+		outputIDs := make([]iotago.OutputID, 0, len(signedTransaction.Transaction.Outputs)-1)
+		for index := 1; index < len(signedTransaction.Transaction.Outputs); index++ {
+			outputIDs = append(outputIDs, iotago.OutputIDFromTransactionIDAndIndex(transactionID, uint16(index)))
+		}
+
+		outputs, err := n.Outputs(ctx, outputIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]*Output{firstOutput}, outputs...), nil
+	}
+
+	created := []*Output{firstOutput}
+	for index := uint16(1); index < iotago.MaxOutputsCount; index++ {
+		output, err := n.Output(ctx, iotago.OutputIDFromTransactionIDAndIndex(transactionID, index))
+		if err != nil {
+			if ierrors.Is(err, ErrNotFound) {
+				// we reached the last output of the transaction
+				break
+			}
+
+			return nil, err
+		}
+
+		created = append(created, output)
+	}
+
+	return created, nil
+}
+
+// transactionConsumedOutputs returns the outputs consumed by the given transaction.
+// It returns nil if one of the consumed outputs is not known to the node anymore.
+func (n *nodeBridge) transactionConsumedOutputs(ctx context.Context, signedTransaction *iotago.SignedTransaction) ([]*Output, error) {
+	inputs := signedTransaction.Transaction.Inputs()
+	consumed := make([]*Output, 0, len(inputs))
+	for _, input := range inputs {
+		output, err := n.Output(ctx, input.OutputID())
+		if err != nil {
+			if ierrors.Is(err, ErrNotFound) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		consumed = append(consumed, output)
+	}
+
+	return consumed, nil
+}
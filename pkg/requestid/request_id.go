@@ -0,0 +1,60 @@
+// Package requestid propagates request IDs via contexts, so a request to the REST API of an extension
+// can be correlated with the INX calls it causes on the node.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	// HeaderName is the name of the HTTP header that contains the request ID.
+	HeaderName = "X-Request-ID"
+	// MetadataKey is the key of the gRPC metadata that contains the request ID.
+	MetadataKey = "x-request-id"
+	// MaxLength is the maximum length of a request ID that is accepted from a client.
+	MaxLength = 128
+
+	// idLength is the amount of random bytes of a generated request ID.
+	idLength = 16
+)
+
+type contextKey struct{}
+
+// New generates a new random request ID.
+func New() string {
+	id := make([]byte, idLength)
+	// crypto/rand only fails if the system has no source of randomness at all
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// IsValid returns true if the given request ID can be propagated.
+// Valid request IDs are not empty, at most MaxLength long and only consist of printable ASCII characters without spaces.
+func IsValid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewContext returns a copy of the context that carries the given request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID that is carried by the context.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+
+	return id, ok && id != ""
+}
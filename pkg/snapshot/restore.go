@@ -0,0 +1,78 @@
+package snapshot
+
+import (
+	"context"
+	"io/fs"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+// Checkpoint is a nodebridge.LedgerCheckpointStore that keeps the slot of the last processed ledger update in memory.
+// It is initialized with the slot of the restored snapshot and the slot it returns is the one of the next snapshot.
+type Checkpoint struct {
+	mutex  sync.RWMutex
+	slot   iotago.SlotIndex
+	exists bool
+}
+
+var _ nodebridge.LedgerCheckpointStore = &Checkpoint{}
+
+// Load returns the slot of the last processed ledger update.
+func (c *Checkpoint) Load(_ context.Context) (iotago.SlotIndex, bool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.slot, c.exists, nil
+}
+
+// Store sets the slot of the last processed ledger update.
+func (c *Checkpoint) Store(_ context.Context, slot iotago.SlotIndex) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.slot = slot
+	c.exists = true
+
+	return nil
+}
+
+// Slot returns the slot of the last processed ledger update and false if no ledger update was processed yet.
+func (c *Checkpoint) Slot() (iotago.SlotIndex, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.slot, c.exists
+}
+
+// RestoreAndCatchUp restores the state of the extension from the snapshot at the given path via restore
+// and afterwards processes the ledger updates after the slot of the snapshot via a nodebridge.LedgerUpdateProcessor,
+// so the state catches up with the node and follows it until the context is canceled.
+//
+// If the snapshot does not exist, restore is not called and the processing starts as configured by the options.
+// The checkpoint is updated after every processed ledger update, its slot is the one to write the next snapshot with.
+// The consumer and the snapshot export need to be synchronized by the extension, so a snapshot reflects exactly that slot.
+func RestoreAndCatchUp(ctx context.Context, nodeBridge nodebridge.NodeBridge, path string, version uint32, restore func(snapshot *Snapshot) error, checkpoint *Checkpoint, consumer func(update *nodebridge.LedgerUpdate) error, opts ...options.Option[nodebridge.LedgerUpdateProcessor]) error {
+	snapshot, err := ReadFile(path, version)
+	switch {
+	case ierrors.Is(err, fs.ErrNotExist):
+		// there is no snapshot yet, the state is built from scratch
+
+	case err != nil:
+		return err
+
+	default:
+		if err := restore(snapshot); err != nil {
+			return ierrors.Wrapf(err, "unable to restore snapshot of slot %d", snapshot.Slot)
+		}
+
+		if err := checkpoint.Store(ctx, snapshot.Slot); err != nil {
+			return err
+		}
+	}
+
+	return nodebridge.NewLedgerUpdateProcessor(nodeBridge, checkpoint, consumer, opts...).Run(ctx)
+}
@@ -0,0 +1,265 @@
+// Package snapshot persists and restores the derived state of an extension together with the ledger slot it corresponds to,
+// so the extension doesn't need to re-process the whole ledger after a restart.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	iotago "github.com/iotaledger/iota.go/v4"
+)
+
+const (
+	// FormatVersion is the version of the snapshot file format.
+	FormatVersion byte = 1
+
+	// maxKeyLength is the maximum length of the key of an entry.
+	maxKeyLength = math.MaxUint16
+)
+
+// magic identifies snapshot files.
+var magic = []byte("INXSNAP")
+
+var (
+	// ErrInvalidSnapshot is returned if the data is not a snapshot or is truncated.
+	ErrInvalidSnapshot = ierrors.New("invalid snapshot")
+	// ErrUnsupportedFormatVersion is returned if the snapshot was written in an unknown file format version.
+	ErrUnsupportedFormatVersion = ierrors.New("unsupported snapshot format version")
+	// ErrChecksumMismatch is returned if the checksum of the snapshot does not match its content.
+	ErrChecksumMismatch = ierrors.New("snapshot checksum mismatch")
+	// ErrVersionMismatch is returned if the state version of the snapshot is not the expected one.
+	ErrVersionMismatch = ierrors.New("snapshot state version mismatch")
+	// ErrKeyTooLong is returned if the key of an entry is longer than 65535 bytes.
+	ErrKeyTooLong = ierrors.New("snapshot entry key too long")
+)
+
+// Snapshot is the derived state of an extension at a certain slot.
+type Snapshot struct {
+	// Version is the version of the state, which is defined by the extension.
+	// It needs to be increased if the encoding of the entries changes.
+	Version uint32
+	// Slot is the slot of the last ledger update that is reflected in the state.
+	Slot iotago.SlotIndex
+	// Entries are the keyed blobs of the state.
+	Entries map[string][]byte
+}
+
+// New creates a new empty Snapshot.
+func New(version uint32, slot iotago.SlotIndex) *Snapshot {
+	return &Snapshot{
+		Version: version,
+		Slot:    slot,
+		Entries: make(map[string][]byte),
+	}
+}
+
+// Write writes the snapshot to the writer.
+// The entries are written in the order of their keys, so equal states result in equal snapshots.
+//
+// The format is: magic, format version, state version, slot, amount of entries,
+// the length-prefixed keys and values, and the SHA-256 checksum of everything before.
+func Write(writer io.Writer, snapshot *Snapshot) error {
+	checksum := sha256.New()
+	bufferedWriter := bufio.NewWriter(io.MultiWriter(writer, checksum))
+
+	header := make([]byte, 0, len(magic)+1+4+4+8)
+	header = append(header, magic...)
+	header = append(header, FormatVersion)
+	header = binary.LittleEndian.AppendUint32(header, snapshot.Version)
+	header = binary.LittleEndian.AppendUint32(header, uint32(snapshot.Slot))
+	header = binary.LittleEndian.AppendUint64(header, uint64(len(snapshot.Entries)))
+	if _, err := bufferedWriter.Write(header); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(snapshot.Entries))
+	for key := range snapshot.Entries {
+		if len(key) > maxKeyLength {
+			return ierrors.Wrapf(ErrKeyTooLong, "key length %d", len(key))
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := snapshot.Entries[key]
+
+		entryHeader := binary.LittleEndian.AppendUint16(nil, uint16(len(key)))
+		entryHeader = append(entryHeader, key...)
+		entryHeader = binary.LittleEndian.AppendUint64(entryHeader, uint64(len(value)))
+		if _, err := bufferedWriter.Write(entryHeader); err != nil {
+			return err
+		}
+		if _, err := bufferedWriter.Write(value); err != nil {
+			return err
+		}
+	}
+
+	if err := bufferedWriter.Flush(); err != nil {
+		return err
+	}
+
+	_, err := writer.Write(checksum.Sum(nil))
+
+	return err
+}
+
+// Read reads a snapshot from the reader and verifies its checksum.
+func Read(reader io.Reader) (*Snapshot, error) {
+	checksum := sha256.New()
+	snapshotReader := &checksumReader{reader: bufio.NewReader(reader), checksum: checksum}
+
+	header := make([]byte, len(magic)+1+4+4+8)
+	if err := snapshotReader.read(header); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:len(magic)], magic) {
+		return nil, ierrors.Wrap(ErrInvalidSnapshot, "unknown file type")
+	}
+	offset := len(magic)
+
+	if header[offset] != FormatVersion {
+		return nil, ierrors.Wrapf(ErrUnsupportedFormatVersion, "version %d", header[offset])
+	}
+	offset++
+
+	snapshot := New(binary.LittleEndian.Uint32(header[offset:]), iotago.SlotIndex(binary.LittleEndian.Uint32(header[offset+4:])))
+	entriesCount := binary.LittleEndian.Uint64(header[offset+8:])
+
+	for range entriesCount {
+		var keyLength [2]byte
+		if err := snapshotReader.read(keyLength[:]); err != nil {
+			return nil, err
+		}
+
+		key := make([]byte, binary.LittleEndian.Uint16(keyLength[:]))
+		if err := snapshotReader.read(key); err != nil {
+			return nil, err
+		}
+
+		var valueLength [8]byte
+		if err := snapshotReader.read(valueLength[:]); err != nil {
+			return nil, err
+		}
+
+		// the value is read in chunks, so a corrupted length doesn't allocate huge amounts of memory
+		var value bytes.Buffer
+		if _, err := io.CopyN(&value, snapshotReader, int64(binary.LittleEndian.Uint64(valueLength[:]))); err != nil {
+			return nil, ierrors.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+
+		snapshot.Entries[string(key)] = value.Bytes()
+	}
+
+	expectedChecksum := checksum.Sum(nil)
+
+	// the checksum itself is not part of the checksum
+	storedChecksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(snapshotReader.reader, storedChecksum); err != nil {
+		return nil, ierrors.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if !bytes.Equal(expectedChecksum, storedChecksum) {
+		return nil, ErrChecksumMismatch
+	}
+
+	return snapshot, nil
+}
+
+// checksumReader reads from the reader and adds the read data to the checksum.
+type checksumReader struct {
+	reader   io.Reader
+	checksum hash.Hash
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.checksum.Write(p[:n])
+
+	return n, err
+}
+
+// read fills the buffer and returns ErrInvalidSnapshot if the data is truncated.
+func (r *checksumReader) read(buffer []byte) error {
+	if _, err := io.ReadFull(r, buffer); err != nil {
+		return ierrors.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the snapshot to the file at the given path atomically.
+// The snapshot is written to a temporary file in the same directory, which replaces the file once it was synced,
+// so the file at the path always contains a complete snapshot, even if the process crashes during the write.
+func WriteFile(path string, snapshot *Snapshot) (err error) {
+	dir := filepath.Dir(path)
+
+	tmpFile, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return ierrors.Wrap(err, "unable to create temporary snapshot file")
+	}
+	defer func() {
+		if err != nil {
+			_ = tmpFile.Close()
+			_ = os.Remove(tmpFile.Name())
+		}
+	}()
+
+	if err := Write(tmpFile, snapshot); err != nil {
+		return ierrors.Wrap(err, "unable to write snapshot")
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		return ierrors.Wrap(err, "unable to sync snapshot file")
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return ierrors.Wrap(err, "unable to close snapshot file")
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return ierrors.Wrap(err, "unable to replace snapshot file")
+	}
+
+	// the rename is only durable once the directory was synced
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return ierrors.Wrap(err, "unable to open snapshot directory")
+	}
+	defer dirFile.Close()
+
+	if err := dirFile.Sync(); err != nil {
+		return ierrors.Wrap(err, "unable to sync snapshot directory")
+	}
+
+	return nil
+}
+
+// ReadFile reads the snapshot from the file at the given path and checks that it has the expected state version.
+// If the file does not exist, an error that matches fs.ErrNotExist is returned.
+func ReadFile(path string, version uint32) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	snapshot, err := Read(file)
+	if err != nil {
+		return nil, ierrors.Wrapf(err, "unable to read snapshot %s", path)
+	}
+
+	if snapshot.Version != version {
+		return nil, ierrors.Wrapf(ErrVersionMismatch, "expected version %d, got %d", version, snapshot.Version)
+	}
+
+	return snapshot, nil
+}
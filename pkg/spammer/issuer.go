@@ -0,0 +1,217 @@
+package spammer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+// Issuer issues the blocks of the Spammer.
+type Issuer interface {
+	// IssuePayload issues a block that contains the given payload.
+	IssuePayload(ctx context.Context, payload iotago.ApplicationPayload) (iotago.BlockID, error)
+	// IssueTransaction allots the mana for the issuance from the output with the given index,
+	// signs the transaction and issues a block that contains it.
+	IssueTransaction(ctx context.Context, txBuilder *builder.TransactionBuilder, storedManaOutputIndex int) (*iotago.SignedTransaction, iotago.BlockID, error)
+}
+
+// blockIssuerPluginIssuer issues the blocks via the BlockIssuer plugin of the node.
+type blockIssuerPluginIssuer struct {
+	nodeBridge nodebridge.NodeBridge
+	powWorkers int
+}
+
+// NewBlockIssuerPluginIssuer creates an Issuer that sends the payloads to the BlockIssuer plugin of the node,
+// which issues the blocks with its own account. The proof of work that is required by the plugin
+// is done with the given amount of workers, 0 uses the default of the node client.
+func NewBlockIssuerPluginIssuer(nodeBridge nodebridge.NodeBridge, powWorkers int) Issuer {
+	return &blockIssuerPluginIssuer{
+		nodeBridge: nodeBridge,
+		powWorkers: powWorkers,
+	}
+}
+
+func (i *blockIssuerPluginIssuer) numPoWWorkers() []int {
+	if i.powWorkers == 0 {
+		return nil
+	}
+
+	return []int{i.powWorkers}
+}
+
+// IssuePayload sends the payload to the BlockIssuer plugin of the node.
+func (i *blockIssuerPluginIssuer) IssuePayload(ctx context.Context, payload iotago.ApplicationPayload) (iotago.BlockID, error) {
+	latestCommitment := i.nodeBridge.LatestCommitment()
+	if latestCommitment == nil {
+		return iotago.EmptyBlockID, ierrors.New("latest commitment unknown")
+	}
+
+	blockIssuer, err := i.nodeBridge.BlockIssuer(ctx)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	response, err := blockIssuer.SendPayload(ctx, payload, latestCommitment.CommitmentID, i.numPoWWorkers()...)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	return response.BlockID, nil
+}
+
+// IssueTransaction allots the mana for the account of the BlockIssuer plugin and sends the transaction to it.
+func (i *blockIssuerPluginIssuer) IssueTransaction(ctx context.Context, txBuilder *builder.TransactionBuilder, storedManaOutputIndex int) (*iotago.SignedTransaction, iotago.BlockID, error) {
+	blockIssuer, err := i.nodeBridge.BlockIssuer(ctx)
+	if err != nil {
+		return nil, iotago.EmptyBlockID, err
+	}
+
+	payload, response, err := blockIssuer.SendPayloadWithTransactionBuilder(ctx, txBuilder, storedManaOutputIndex, i.numPoWWorkers()...)
+	if err != nil {
+		return nil, iotago.EmptyBlockID, err
+	}
+
+	signedTransaction, ok := payload.(*iotago.SignedTransaction)
+	if !ok {
+		return nil, iotago.EmptyBlockID, ierrors.Errorf("unexpected payload type %T", payload)
+	}
+
+	return signedTransaction, response.BlockID, nil
+}
+
+// accountIssuer issues the blocks with a block issuer account.
+type accountIssuer struct {
+	nodeBridge nodebridge.NodeBridge
+	accountID  iotago.AccountID
+	signer     iotago.AddressSigner
+	address    iotago.Address
+	submitter  *nodebridge.BlockSubmitter
+
+	// the reference mana cost is cached per slot of the latest commitment
+	referenceManaCostMutex sync.Mutex
+	referenceManaCost      iotago.Mana
+	referenceManaCostSlot  iotago.SlotIndex
+}
+
+// NewAccountIssuer creates an Issuer that issues the blocks with the given block issuer account,
+// signed with the private key of one of its block issuer keys. The blocks are submitted via a BlockSubmitter,
+// which fills in the parents from the tips of the node and can be configured with the given options.
+// The mana for the issuance is burned from the block issuance credits of the account.
+func NewAccountIssuer(logger log.Logger, nodeBridge nodebridge.NodeBridge, accountID iotago.AccountID, privateKey ed25519.PrivateKey, opts ...options.Option[nodebridge.BlockSubmitter]) Issuer {
+	//nolint:forcetypeassert // the public key of an ed25519 private key is always an ed25519 public key
+	address := iotago.Ed25519AddressFromPubKey(privateKey.Public().(ed25519.PublicKey))
+
+	issuer := &accountIssuer{
+		nodeBridge: nodeBridge,
+		accountID:  accountID,
+		signer:     iotago.NewInMemoryAddressSigner(iotago.NewAddressKeysForEd25519Address(address, privateKey)),
+		address:    address,
+	}
+	issuer.submitter = nodebridge.NewBlockSubmitter(logger, nodeBridge, append([]options.Option[nodebridge.BlockSubmitter]{nodebridge.WithBlockSignerFunc(issuer.signBlock)}, opts...)...)
+
+	return issuer
+}
+
+// signBlock signs the block after its parents were filled in by the BlockSubmitter.
+func (i *accountIssuer) signBlock(block *iotago.Block) error {
+	signature, err := block.Sign(i.signer, i.address)
+	if err != nil {
+		return err
+	}
+
+	ed25519Signature, ok := signature.(*iotago.Ed25519Signature)
+	if !ok {
+		return ierrors.Errorf("unsupported signature type %T", signature)
+	}
+	block.Signature = ed25519Signature
+
+	return nil
+}
+
+// latestReferenceManaCost returns the reference mana cost of the latest commitment and the commitment itself.
+func (i *accountIssuer) latestReferenceManaCost(ctx context.Context) (iotago.Mana, *nodebridge.Commitment, error) {
+	latestCommitment := i.nodeBridge.LatestCommitment()
+	if latestCommitment == nil {
+		return 0, nil, ierrors.New("latest commitment unknown")
+	}
+
+	i.referenceManaCostMutex.Lock()
+	defer i.referenceManaCostMutex.Unlock()
+
+	if i.referenceManaCostSlot != latestCommitment.CommitmentID.Slot() {
+		congestion, err := i.nodeBridge.Congestion(ctx, i.accountID)
+		if err != nil {
+			return 0, nil, ierrors.Wrap(err, "failed to read the reference mana cost")
+		}
+
+		i.referenceManaCost = congestion.ReferenceManaCost
+		i.referenceManaCostSlot = latestCommitment.CommitmentID.Slot()
+	}
+
+	return i.referenceManaCost, latestCommitment, nil
+}
+
+// IssuePayload issues a block with the payload that burns the mana from the block issuance credits of the account.
+func (i *accountIssuer) IssuePayload(ctx context.Context, payload iotago.ApplicationPayload) (iotago.BlockID, error) {
+	referenceManaCost, latestCommitment, err := i.latestReferenceManaCost(ctx)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	var latestFinalizedSlot iotago.SlotIndex
+	if latestFinalizedCommitment := i.nodeBridge.LatestFinalizedCommitment(); latestFinalizedCommitment != nil {
+		latestFinalizedSlot = latestFinalizedCommitment.CommitmentID.Slot()
+	}
+
+	issuingTime := time.Now()
+	api := i.nodeBridge.APIProvider().APIForTime(issuingTime)
+
+	block, err := builder.NewBasicBlockBuilder(api).
+		ProtocolVersion(api.Version()).
+		IssuingTime(issuingTime).
+		SlotCommitmentID(latestCommitment.CommitmentID).
+		LatestFinalizedSlot(latestFinalizedSlot).
+		Payload(payload).
+		CalculateAndSetMaxBurnedMana(referenceManaCost).
+		Build()
+	if err != nil {
+		return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to build the block")
+	}
+	block.Header.IssuerID = i.accountID
+
+	return i.submitter.Submit(ctx, block)
+}
+
+// IssueTransaction allots the mana for the issuance of the block to the account, so the account is refunded
+// for the burned block issuance credits, signs the transaction and issues a block that contains it.
+func (i *accountIssuer) IssueTransaction(ctx context.Context, txBuilder *builder.TransactionBuilder, storedManaOutputIndex int) (*iotago.SignedTransaction, iotago.BlockID, error) {
+	referenceManaCost, latestCommitment, err := i.latestReferenceManaCost(ctx)
+	if err != nil {
+		return nil, iotago.EmptyBlockID, err
+	}
+
+	if txBuilder.CreationSlot() == 0 {
+		txBuilder.SetCreationSlot(latestCommitment.CommitmentID.Slot())
+	}
+	txBuilder.AllotMinRequiredManaAndStoreRemainingManaInOutput(txBuilder.CreationSlot(), referenceManaCost, i.accountID, storedManaOutputIndex)
+
+	signedTransaction, err := txBuilder.Build()
+	if err != nil {
+		return nil, iotago.EmptyBlockID, ierrors.Wrap(err, "failed to build the signed transaction")
+	}
+
+	blockID, err := i.IssuePayload(ctx, signedTransaction)
+	if err != nil {
+		return nil, iotago.EmptyBlockID, err
+	}
+
+	return signedTransaction, blockID, nil
+}
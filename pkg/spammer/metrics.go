@@ -0,0 +1,75 @@
+package spammer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// spammerCollector exposes the Stats of a Spammer as Prometheus metrics.
+type spammerCollector struct {
+	spammer *Spammer
+
+	blocksIssuedDesc *prometheus.Desc
+	errorsDesc       *prometheus.Desc
+	rateDesc         *prometheus.Desc
+	targetRateDesc   *prometheus.Desc
+	congestedDesc    *prometheus.Desc
+}
+
+// NewSpammerCollector creates a Prometheus collector that exposes the Stats of the given Spammer
+// with the spam mode as label.
+func NewSpammerCollector(spammer *Spammer) prometheus.Collector {
+	return &spammerCollector{
+		spammer: spammer,
+		blocksIssuedDesc: prometheus.NewDesc(
+			"inx_spammer_blocks_total",
+			"The amount of blocks that were issued by the spammer.",
+			[]string{"mode"}, nil,
+		),
+		errorsDesc: prometheus.NewDesc(
+			"inx_spammer_errors_total",
+			"The amount of blocks that could not be issued by the spammer.",
+			[]string{"mode"}, nil,
+		),
+		rateDesc: prometheus.NewDesc(
+			"inx_spammer_rate_blocks_per_second",
+			"The current rate of the spammer, which is throttled after failed issuances.",
+			[]string{"mode"}, nil,
+		),
+		targetRateDesc: prometheus.NewDesc(
+			"inx_spammer_target_rate_blocks_per_second",
+			"The configured rate of the spammer.",
+			[]string{"mode"}, nil,
+		),
+		congestedDesc: prometheus.NewDesc(
+			"inx_spammer_congested",
+			"Whether the spammer is paused because the issuing account is congested.",
+			[]string{"mode"}, nil,
+		),
+	}
+}
+
+// Describe sends the descriptors of the metrics to the given channel.
+func (c *spammerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.blocksIssuedDesc
+	ch <- c.errorsDesc
+	ch <- c.rateDesc
+	ch <- c.targetRateDesc
+	ch <- c.congestedDesc
+}
+
+// Collect sends the metrics of the Spammer to the given channel.
+func (c *spammerCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.spammer.Stats()
+
+	var congested float64
+	if stats.Congested {
+		congested = 1
+	}
+
+	mode := string(stats.Mode)
+	ch <- prometheus.MustNewConstMetric(c.blocksIssuedDesc, prometheus.CounterValue, float64(stats.BlocksIssued), mode)
+	ch <- prometheus.MustNewConstMetric(c.errorsDesc, prometheus.CounterValue, float64(stats.Errors), mode)
+	ch <- prometheus.MustNewConstMetric(c.rateDesc, prometheus.GaugeValue, stats.Rate, mode)
+	ch <- prometheus.MustNewConstMetric(c.targetRateDesc, prometheus.GaugeValue, stats.TargetRate, mode)
+	ch <- prometheus.MustNewConstMetric(c.congestedDesc, prometheus.GaugeValue, congested, mode)
+}
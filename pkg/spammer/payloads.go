@@ -0,0 +1,96 @@
+package spammer
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/builder"
+)
+
+// ErrNoValueSpamOutputs is returned if the value spam mode is used without outputs to spend.
+var ErrNoValueSpamOutputs = ierrors.New("no outputs for the value spam configured")
+
+// taggedDataPayload returns a tagged data payload with the tag of the spammer,
+// the data contains the sequence number of the block and the issuing time to make every payload unique.
+func taggedDataPayload(tag []byte, sequenceNumber uint64) *iotago.TaggedData {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint64(data[:8], sequenceNumber)
+	binary.LittleEndian.PutUint64(data[8:], uint64(time.Now().UnixNano()))
+
+	return &iotago.TaggedData{
+		Tag:  tag,
+		Data: data,
+	}
+}
+
+// valueChain is a chain of transactions that each spend the output of the previous transaction
+// back to the same address, so the value spam doesn't need any other funds than the initial output.
+type valueChain struct {
+	output  *nodebridge.Output
+	address iotago.Address
+}
+
+func newValueChain(output *nodebridge.Output) (*valueChain, error) {
+	addressUnlockCondition := output.Output.UnlockConditionSet().Address()
+	if addressUnlockCondition == nil {
+		return nil, ierrors.Errorf("output %s has no address unlock condition", output.OutputID.ToHex())
+	}
+
+	return &valueChain{
+		output:  output,
+		address: addressUnlockCondition.Address,
+	}, nil
+}
+
+// transactionBuilder returns the builder of the transaction that spends the latest output of the chain.
+// The remaining mana after the allotment for the block issuance is stored in the new output.
+func (c *valueChain) transactionBuilder(api iotago.API, signer iotago.AddressSigner, commitmentID iotago.CommitmentID) (*builder.TransactionBuilder, error) {
+	output, err := builder.NewBasicOutputBuilder(c.address, c.output.Output.BaseTokenAmount()).Build()
+	if err != nil {
+		return nil, ierrors.Wrap(err, "failed to build the output of the value spam")
+	}
+
+	return builder.NewTransactionBuilder(api, signer).
+		AddCommitmentInput(&iotago.CommitmentInput{CommitmentID: commitmentID}).
+		AddInput(&builder.TxInput{
+			UnlockTarget: c.address,
+			InputID:      c.output.OutputID,
+			Input:        c.output.Output,
+		}).
+		AddOutput(output), nil
+}
+
+// issue issues the next transaction of the chain and continues the chain with its output.
+// If the transaction could not be issued, the chain continues with the same output.
+func (c *valueChain) issue(ctx context.Context, nodeBridge nodebridge.NodeBridge, issuer Issuer, signer iotago.AddressSigner) (iotago.BlockID, error) {
+	latestCommitment := nodeBridge.LatestCommitment()
+	if latestCommitment == nil {
+		return iotago.EmptyBlockID, ierrors.New("latest commitment unknown")
+	}
+
+	txBuilder, err := c.transactionBuilder(nodeBridge.APIProvider().LatestAPI(), signer, latestCommitment.CommitmentID)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	signedTransaction, blockID, err := issuer.IssueTransaction(ctx, txBuilder, 0)
+	if err != nil {
+		return iotago.EmptyBlockID, err
+	}
+
+	transactionID, err := signedTransaction.Transaction.ID()
+	if err != nil {
+		return iotago.EmptyBlockID, ierrors.Wrap(err, "failed to compute the transaction ID")
+	}
+
+	c.output = &nodebridge.Output{
+		OutputID: iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0),
+		Output:   signedTransaction.Transaction.Outputs[0],
+	}
+
+	return blockID, nil
+}
@@ -0,0 +1,315 @@
+// Package spammer provides a load generator for extensions that issue blocks at a configurable rate,
+// e.g. to test a network or to benchmark a node. It supports tagged data and value spam, adapts the rate to the node
+// and pauses while the issuing account is congested.
+package spammer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+)
+
+const (
+	// DefaultSpamRate is the default target rate in blocks per second.
+	DefaultSpamRate = 1.0
+	// DefaultSpamWorkers is the default amount of blocks that are issued concurrently.
+	DefaultSpamWorkers = 1
+	// DefaultSpamTag is the default tag of the tagged data payloads.
+	DefaultSpamTag = "SPAM"
+)
+
+// SpamMode is the kind of blocks the Spammer issues.
+type SpamMode string
+
+const (
+	// SpamModeTaggedData issues blocks with tagged data payloads.
+	SpamModeTaggedData SpamMode = "tagged_data"
+	// SpamModeValue issues blocks with transactions that spend the configured outputs back to their addresses.
+	SpamModeValue SpamMode = "value"
+)
+
+// SpammerEvents are the events of the Spammer.
+type SpammerEvents struct {
+	// BlockIssued is triggered with the ID of every issued block.
+	BlockIssued *event.Event1[iotago.BlockID]
+	// IssuanceFailed is triggered if a block could not be issued.
+	IssuanceFailed *event.Event1[error]
+	// CongestionChanged is triggered if the issuance is paused or resumed because of the congestion of the account.
+	CongestionChanged *event.Event1[bool]
+}
+
+// SpammerStats contains the statistics of a Spammer.
+type SpammerStats struct {
+	// Mode is the kind of blocks the Spammer issues.
+	Mode SpamMode
+	// BlocksIssued is the amount of blocks that were issued.
+	BlocksIssued uint64
+	// Errors is the amount of blocks that could not be issued.
+	Errors uint64
+	// Rate is the current rate in blocks per second, which is lower than the target rate after failed issuances.
+	Rate float64
+	// TargetRate is the configured rate in blocks per second.
+	TargetRate float64
+	// Congested is true while the issuance is paused because of the congestion of the account.
+	Congested bool
+}
+
+// Spammer issues blocks at a given rate.
+type Spammer struct {
+	log.Logger
+
+	Events *SpammerEvents
+
+	nodeBridge nodebridge.NodeBridge
+	issuer     Issuer
+
+	rate                 float64
+	workers              int
+	mode                 SpamMode
+	tag                  []byte
+	valueSpamSigner      iotago.AddressSigner
+	valueSpamOutputs     []*nodebridge.Output
+	congestionAccountID  iotago.AccountID
+	congestionThrottling bool
+
+	throttle       *throttle
+	sequenceNumber atomic.Uint64
+	blocksIssued   atomic.Uint64
+	errors         atomic.Uint64
+	// valueChainsAvailable contains the chains of the value spam that are not used by a worker.
+	valueChainsAvailable chan *valueChain
+}
+
+// WithSpamRate sets the target rate in blocks per second.
+func WithSpamRate(blocksPerSecond float64) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.rate = blocksPerSecond
+	}
+}
+
+// WithSpamWorkers sets the amount of blocks that are issued concurrently,
+// which should be increased if the issuance of a single block takes longer than the interval of the target rate.
+func WithSpamWorkers(workers int) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.workers = workers
+	}
+}
+
+// WithSpamMode sets the kind of blocks the Spammer issues.
+func WithSpamMode(mode SpamMode) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.mode = mode
+	}
+}
+
+// WithSpamTag sets the tag of the tagged data payloads.
+func WithSpamTag(tag []byte) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.tag = tag
+	}
+}
+
+// WithValueSpamOutputs sets the outputs that are spent by the value spam, which are unlocked with the signer.
+// Every output is the start of a chain of transactions that spend it back to its address,
+// so at most as many transactions are issued concurrently as outputs are given.
+func WithValueSpamOutputs(signer iotago.AddressSigner, outputs ...*nodebridge.Output) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.valueSpamSigner = signer
+		s.valueSpamOutputs = outputs
+	}
+}
+
+// WithCongestionThrottling pauses the issuance while the given block issuer account is congested,
+// which is if the node reports that the account is not ready to issue or if its block issuance credits are negative.
+func WithCongestionThrottling(accountID iotago.AccountID) options.Option[Spammer] {
+	return func(s *Spammer) {
+		s.congestionAccountID = accountID
+		s.congestionThrottling = true
+	}
+}
+
+// NewSpammer creates a new Spammer that issues the blocks with the given Issuer.
+func NewSpammer(logger log.Logger, nodeBridge nodebridge.NodeBridge, issuer Issuer, opts ...options.Option[Spammer]) *Spammer {
+	return options.Apply(&Spammer{
+		Logger: logger,
+		Events: &SpammerEvents{
+			BlockIssued:       event.New1[iotago.BlockID](),
+			IssuanceFailed:    event.New1[error](),
+			CongestionChanged: event.New1[bool](),
+		},
+		nodeBridge: nodeBridge,
+		issuer:     issuer,
+		rate:       DefaultSpamRate,
+		workers:    DefaultSpamWorkers,
+		mode:       SpamModeTaggedData,
+		tag:        []byte(DefaultSpamTag),
+	}, opts, func(s *Spammer) {
+		s.throttle = newThrottle(s.rate)
+	})
+}
+
+// SetRate sets the target rate in blocks per second.
+func (s *Spammer) SetRate(blocksPerSecond float64) {
+	s.throttle.setTarget(blocksPerSecond)
+}
+
+// Stats returns the statistics of the Spammer.
+func (s *Spammer) Stats() *SpammerStats {
+	return &SpammerStats{
+		Mode:         s.mode,
+		BlocksIssued: s.blocksIssued.Load(),
+		Errors:       s.errors.Load(),
+		Rate:         s.throttle.rate(),
+		TargetRate:   s.throttle.target(),
+		Congested:    s.throttle.isCongested(),
+	}
+}
+
+// Run issues blocks until the context is canceled.
+// If the congestion throttling is enabled and the congestion of the account can't be followed anymore,
+// Run stops and returns the error, since the issuance could stay paused forever otherwise.
+func (s *Spammer) Run(ctx context.Context) error {
+	if err := s.initValueChains(); err != nil {
+		return err
+	}
+
+	runCtx, runCancel := context.WithCancelCause(ctx)
+	defer runCancel(nil)
+
+	var wg sync.WaitGroup
+
+	if s.congestionThrottling {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := s.nodeBridge.ListenToCongestion(runCtx, s.congestionAccountID, s.congestionChanged)
+			if runCtx.Err() != nil {
+				return
+			}
+
+			if err == nil {
+				err = ierrors.New("congestion stream ended")
+			}
+			runCancel(ierrors.Wrapf(err, "listening to the congestion of account %s failed", s.congestionAccountID))
+		}()
+	}
+
+	s.LogInfof("spammer started, mode: %s, rate: %.2f blocks/s, workers: %d", s.mode, s.throttle.target(), s.workers)
+
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for s.throttle.wait(runCtx) == nil {
+				s.issueBlock(runCtx)
+			}
+		}()
+	}
+
+	<-runCtx.Done()
+	wg.Wait()
+
+	// the congestion is unknown until the next run follows it again
+	s.throttle.setCongested(false)
+
+	s.LogInfof("spammer stopped, issued blocks: %d, errors: %d", s.blocksIssued.Load(), s.errors.Load())
+
+	if ctx.Err() == nil {
+		return context.Cause(runCtx)
+	}
+
+	return nil
+}
+
+// initValueChains creates the chains of the value spam.
+// The chains are kept if the Spammer is run again, since the configured outputs were spent already.
+func (s *Spammer) initValueChains() error {
+	if s.mode != SpamModeValue || s.valueChainsAvailable != nil {
+		return nil
+	}
+
+	if len(s.valueSpamOutputs) == 0 || s.valueSpamSigner == nil {
+		return ErrNoValueSpamOutputs
+	}
+
+	valueChainsAvailable := make(chan *valueChain, len(s.valueSpamOutputs))
+	for _, output := range s.valueSpamOutputs {
+		chain, err := newValueChain(output)
+		if err != nil {
+			return err
+		}
+		valueChainsAvailable <- chain
+	}
+	s.valueChainsAvailable = valueChainsAvailable
+
+	return nil
+}
+
+// congestionChanged pauses or resumes the issuance depending on the congestion of the account.
+func (s *Spammer) congestionChanged(congestion *api.CongestionResponse) error {
+	congested := !congestion.Ready || congestion.BlockIssuanceCredits < 0
+	if !s.throttle.setCongested(congested) {
+		return nil
+	}
+
+	if congested {
+		s.LogWarnf("spammer paused, account %s is congested (ready: %t, block issuance credits: %d)", s.congestionAccountID, congestion.Ready, congestion.BlockIssuanceCredits)
+	} else {
+		s.LogInfof("spammer resumed, account %s is not congested anymore", s.congestionAccountID)
+	}
+	s.Events.CongestionChanged.Trigger(congested)
+
+	return nil
+}
+
+// issueBlock issues a single block of the configured mode and adapts the rate to the result.
+func (s *Spammer) issueBlock(ctx context.Context) {
+	blockID, err := s.issue(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.errors.Add(1)
+		s.throttle.failed()
+		s.LogDebugf("issuing block failed, rate decreased to %.2f blocks/s: %s", s.throttle.rate(), err)
+		s.Events.IssuanceFailed.Trigger(err)
+
+		return
+	}
+
+	s.blocksIssued.Add(1)
+	s.throttle.succeeded()
+	s.Events.BlockIssued.Trigger(blockID)
+}
+
+func (s *Spammer) issue(ctx context.Context) (iotago.BlockID, error) {
+	switch s.mode {
+	case SpamModeTaggedData:
+		return s.issuer.IssuePayload(ctx, taggedDataPayload(s.tag, s.sequenceNumber.Add(1)))
+
+	case SpamModeValue:
+		var chain *valueChain
+		select {
+		case <-ctx.Done():
+			return iotago.EmptyBlockID, ctx.Err()
+		case chain = <-s.valueChainsAvailable:
+		}
+		defer func() { s.valueChainsAvailable <- chain }()
+
+		return chain.issue(ctx, s.nodeBridge, s.issuer, s.valueSpamSigner)
+
+	default:
+		return iotago.EmptyBlockID, ierrors.Errorf("unknown spam mode: %s", s.mode)
+	}
+}
@@ -0,0 +1,119 @@
+package spammer
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minRateFactor is the factor of the target rate the rate is at least throttled to.
+	minRateFactor = 0.01
+	// rateIncreaseFactor is the factor of the target rate the rate is increased by after every successfully issued block.
+	rateIncreaseFactor = 0.01
+)
+
+// throttle limits the rate of the issued blocks.
+// The rate is adapted to the node via additive increase and multiplicative decrease: it is halved on every failed
+// issuance and increased by a fraction of the target rate on every issued block, until the target rate is reached.
+// Additionally, the issuance is paused while the block issuer account is congested.
+type throttle struct {
+	mutex      sync.Mutex
+	limiter    *rate.Limiter
+	targetRate float64
+	congested  bool
+	// resumedChan is closed while the issuance is not paused.
+	resumedChan chan struct{}
+}
+
+func newThrottle(targetRate float64) *throttle {
+	resumedChan := make(chan struct{})
+	close(resumedChan)
+
+	return &throttle{
+		limiter:     rate.NewLimiter(rate.Limit(targetRate), 1),
+		targetRate:  targetRate,
+		resumedChan: resumedChan,
+	}
+}
+
+// wait blocks until the next block may be issued or the context is canceled.
+func (t *throttle) wait(ctx context.Context) error {
+	t.mutex.Lock()
+	resumedChan := t.resumedChan
+	t.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumedChan:
+	}
+
+	return t.limiter.Wait(ctx)
+}
+
+// rate returns the current rate in blocks per second.
+func (t *throttle) rate() float64 {
+	return float64(t.limiter.Limit())
+}
+
+// target returns the target rate in blocks per second.
+func (t *throttle) target() float64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.targetRate
+}
+
+// setTarget sets the target rate and resets the current rate to it.
+func (t *throttle) setTarget(targetRate float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.targetRate = targetRate
+	t.limiter.SetLimit(rate.Limit(targetRate))
+}
+
+// succeeded increases the rate after a block was issued.
+func (t *throttle) succeeded() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.limiter.SetLimit(rate.Limit(min(t.targetRate, t.rate()+t.targetRate*rateIncreaseFactor)))
+}
+
+// failed decreases the rate after a block could not be issued.
+func (t *throttle) failed() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.limiter.SetLimit(rate.Limit(max(t.targetRate*minRateFactor, t.rate()/2)))
+}
+
+// isCongested returns true if the issuance is paused.
+func (t *throttle) isCongested() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.congested
+}
+
+// setCongested pauses or resumes the issuance, it returns true if the state changed.
+func (t *throttle) setCongested(congested bool) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if congested == t.congested {
+		return false
+	}
+	t.congested = congested
+
+	if congested {
+		t.resumedChan = make(chan struct{})
+	} else {
+		close(t.resumedChan)
+	}
+
+	return true
+}
@@ -0,0 +1,114 @@
+package tagindex
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/api"
+	"github.com/iotaledger/iota.go/v4/hexutil"
+)
+
+const (
+	// RouteTagEntries is the route to get the indexed blocks with the given hex encoded tag.
+	// The blocks are ordered by their slot and the order they were indexed in,
+	// the results are paginated via the "cursor" and "pageSize" query parameters.
+	RouteTagEntries = "/tags/:" + api.ParameterTag + "/blocks"
+	// RouteBlockEntry is the route to get the entry of an indexed block.
+	RouteBlockEntry = "/blocks/:" + api.ParameterBlockID
+
+	// DefaultMaxPageSize is the default maximum amount of entries per page.
+	DefaultMaxPageSize = 1000
+
+	// maxTagLength is the maximum length of the tag of a tagged data payload.
+	maxTagLength = 64
+)
+
+// EntryResponse defines the response of an indexed block.
+type EntryResponse struct {
+	// BlockID is the hex encoded ID of the block.
+	BlockID string `json:"blockId"`
+	// Tag is the hex encoded tag of the tagged data payload.
+	Tag string `json:"tag"`
+	// Slot is the slot of the block.
+	Slot uint32 `json:"slot"`
+	// Index is the position of the block among the blocks with the same tag in the slot.
+	Index uint32 `json:"index"`
+}
+
+func newEntryResponse(entry *Entry) *EntryResponse {
+	return &EntryResponse{
+		BlockID: entry.BlockID.ToHex(),
+		Tag:     hexutil.EncodeHex(entry.Tag),
+		Slot:    uint32(entry.Slot()),
+		Index:   entry.Index,
+	}
+}
+
+// RegisterRoutes registers the routes of the TagIndex below the given prefix, e.g. "/api/participation/v1".
+// The maximum page size of the paginated routes defaults to DefaultMaxPageSize if it is 0.
+func RegisterRoutes(e *echo.Echo, prefix string, tagIndex *TagIndex, maxPageSize uint32) *echo.Group {
+	if maxPageSize == 0 {
+		maxPageSize = DefaultMaxPageSize
+	}
+
+	group := e.Group(prefix)
+
+	group.GET(RouteTagEntries, func(c echo.Context) error {
+		tag, err := hexutil.DecodeHex(c.Param(api.ParameterTag))
+		if err != nil {
+			return ierrors.Errorf("%w: invalid tag: %s: %w", httpserver.ErrInvalidParameter, c.Param(api.ParameterTag), err)
+		}
+		if len(tag) > maxTagLength {
+			return ierrors.Wrapf(httpserver.ErrInvalidParameter, "tag too long, max. %d bytes but is %d", maxTagLength, len(tag))
+		}
+
+		paginator, err := httpserver.NewPaginator(c, httpserver.CursorTypeSlot, api.ParameterCursor, api.ParameterPageSize, maxPageSize, func(entry *EntryResponse) httpserver.Cursor {
+			return httpserver.NewSlotCursor(iotago.SlotIndex(entry.Slot), entry.Index)
+		})
+		if err != nil {
+			return err
+		}
+
+		var startSlot iotago.SlotIndex
+		var startIndex uint32
+		if cursor, ok := paginator.Cursor(); ok {
+			startSlot, startIndex = cursor.Slot(), cursor.Index
+		}
+
+		entries, err := tagIndex.Entries(tag, startSlot, startIndex, int(paginator.QueryLimit()))
+		if err != nil {
+			return err
+		}
+
+		responses := make([]*EntryResponse, len(entries))
+		for i, entry := range entries {
+			responses[i] = newEntryResponse(entry)
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, paginator.Page(responses))
+	})
+
+	group.GET(RouteBlockEntry, func(c echo.Context) error {
+		blockID, err := httpserver.ParseBlockIDParam(c, api.ParameterBlockID)
+		if err != nil {
+			return err
+		}
+
+		entry, err := tagIndex.Entry(blockID)
+		if err != nil {
+			if ierrors.Is(err, ErrKeyNotFound) {
+				return ierrors.Wrapf(echo.ErrNotFound, "block %s is not indexed", blockID)
+			}
+
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, newEntryResponse(entry))
+	})
+
+	return group
+}
@@ -0,0 +1,90 @@
+package tagindex
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+)
+
+// ErrKeyNotFound is returned by a Store if the key does not exist.
+var ErrKeyNotFound = ierrors.New("key not found")
+
+// Store is the ordered key-value store the TagIndex keeps its entries in.
+// It can be implemented on top of any persistent key-value store that iterates the keys in lexicographical order,
+// e.g. a RocksDB or Pebble database.
+type Store interface {
+	// Get returns the value of the key, or ErrKeyNotFound if the key does not exist.
+	Get(key []byte) ([]byte, error)
+	// Set sets the value of the key.
+	Set(key []byte, value []byte) error
+	// Iterate passes the keys with the given prefix that are equal to or greater than the start key
+	// to the consumer in lexicographical order, until the consumer returns false.
+	// The start key is ignored if it is nil.
+	Iterate(prefix []byte, start []byte, consumer func(key []byte, value []byte) bool) error
+}
+
+// memoryStore is a Store that keeps the entries in memory.
+type memoryStore struct {
+	mutex sync.RWMutex
+	// keys are the sorted keys of the values.
+	keys   []string
+	values map[string][]byte
+}
+
+// NewMemoryStore creates a Store that keeps the entries in memory, e.g. for tests or for short-living indexes
+// that are rebuilt on every start.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		values: make(map[string][]byte),
+	}
+}
+
+func (s *memoryStore) Get(key []byte) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	value, exists := s.values[string(key)]
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+
+	return bytes.Clone(value), nil
+}
+
+func (s *memoryStore) Set(key []byte, value []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.values[string(key)]; !exists {
+		position := sort.SearchStrings(s.keys, string(key))
+		s.keys = append(s.keys, "")
+		copy(s.keys[position+1:], s.keys[position:])
+		s.keys[position] = string(key)
+	}
+	s.values[string(key)] = bytes.Clone(value)
+
+	return nil
+}
+
+func (s *memoryStore) Iterate(prefix []byte, start []byte, consumer func(key []byte, value []byte) bool) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if start == nil || bytes.Compare(start, prefix) < 0 {
+		start = prefix
+	}
+
+	for _, key := range s.keys[sort.SearchStrings(s.keys, string(start)):] {
+		if !bytes.HasPrefix([]byte(key), prefix) {
+			break
+		}
+
+		if !consumer([]byte(key), bytes.Clone(s.values[key])) {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+// Package tagindex provides an index of the blocks with tagged data payloads, which is the core of extensions
+// that react to data that was published with a specific tag, e.g. participation or indexer like extensions.
+// The index consumes the blocks of the node, stores references to the blocks whose tag matches one of the configured
+// prefixes in a pluggable key-value store and exposes query methods and REST routes to look them up by their tag.
+package tagindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/iotaledger/hive.go/ierrors"
+	"github.com/iotaledger/hive.go/log"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/inx-app/pkg/nodebridge"
+	iotago "github.com/iotaledger/iota.go/v4"
+	"github.com/iotaledger/iota.go/v4/hexutil"
+)
+
+const (
+	// keyPrefixTag is the prefix of the keys of the entries by their tag:
+	// keyPrefixTag (1 byte), tag length (1 byte), tag, slot (4 bytes), index (4 bytes) -> block ID.
+	keyPrefixTag byte = iota
+	// keyPrefixBlock is the prefix of the keys of the entries by their block ID:
+	// keyPrefixBlock (1 byte), block ID -> index (4 bytes), tag.
+	keyPrefixBlock
+)
+
+// Entry is a reference to a block with a tagged data payload.
+type Entry struct {
+	// Tag is the tag of the tagged data payload.
+	Tag []byte
+	// BlockID is the ID of the block that contains the tagged data payload.
+	BlockID iotago.BlockID
+	// Index is the position of the entry among the entries with the same tag in the slot of the block,
+	// in the order the blocks were indexed.
+	Index uint32
+}
+
+// Slot returns the slot of the block.
+func (e *Entry) Slot() iotago.SlotIndex {
+	return e.BlockID.Slot()
+}
+
+// TagIndexEvents are the events of the TagIndex.
+type TagIndexEvents struct {
+	// EntryAdded is triggered after a block with a matching tag was added to the index.
+	EntryAdded *event.Event1[*Entry]
+}
+
+// TagIndex indexes the blocks with tagged data payloads whose tag matches one of the configured prefixes.
+// The blocks are indexed once they are attached by the node, independent of their acceptance.
+type TagIndex struct {
+	log.Logger
+
+	Events *TagIndexEvents
+
+	nodeBridge  nodebridge.NodeBridge
+	store       Store
+	tagPrefixes [][]byte
+
+	indexTransactionPayloads bool
+
+	// mutex serializes the writes, so the indexes of the entries are unique.
+	mutex sync.Mutex
+	// nextIndexes caches the index of the next entry per tag and slot.
+	nextIndexes map[string]uint32
+	// nextIndexesPrunedSlot is the slot up to which the nextIndexes were pruned.
+	nextIndexesPrunedSlot iotago.SlotIndex
+}
+
+// WithTransactionPayloads sets whether the tagged data payloads that are contained in transactions are indexed as well.
+// They are indexed by default.
+func WithTransactionPayloads(enabled bool) options.Option[TagIndex] {
+	return func(t *TagIndex) {
+		t.indexTransactionPayloads = enabled
+	}
+}
+
+// NewTagIndex creates a new TagIndex that stores the blocks whose tag starts with one of the given prefixes.
+// An empty prefix matches all tags.
+func NewTagIndex(logger log.Logger, nodeBridge nodebridge.NodeBridge, store Store, tagPrefixes [][]byte, opts ...options.Option[TagIndex]) *TagIndex {
+	return options.Apply(&TagIndex{
+		Logger: logger,
+		Events: &TagIndexEvents{
+			EntryAdded: event.New1[*Entry](),
+		},
+		nodeBridge:               nodeBridge,
+		store:                    store,
+		tagPrefixes:              tagPrefixes,
+		indexTransactionPayloads: true,
+		nextIndexes:              make(map[string]uint32),
+	}, opts)
+}
+
+// Run indexes the blocks of the node until the context is canceled.
+func (t *TagIndex) Run(ctx context.Context) error {
+	if err := t.nodeBridge.ListenToBlocks(ctx, t.processBlock); err != nil && ctx.Err() == nil {
+		return ierrors.Wrap(err, "failed to listen to the blocks")
+	}
+
+	return nil
+}
+
+// processBlock adds the block to the index if it contains a tagged data payload with a matching tag.
+func (t *TagIndex) processBlock(block *iotago.Block, _ []byte) error {
+	tag, ok := t.matchingTag(block)
+	if !ok {
+		return nil
+	}
+
+	blockID, err := block.ID()
+	if err != nil {
+		return ierrors.Wrap(err, "failed to compute the block ID")
+	}
+
+	entry, err := t.Add(tag, blockID)
+	if err != nil {
+		return err
+	}
+	if entry != nil {
+		t.LogDebugf("indexed block %s with tag %s", blockID, hexutil.EncodeHex(tag))
+	}
+
+	return nil
+}
+
+// matchingTag returns the tag of the tagged data payload of the block if it matches one of the tag prefixes.
+func (t *TagIndex) matchingTag(block *iotago.Block) ([]byte, bool) {
+	basicBlockBody, ok := block.Body.(*iotago.BasicBlockBody)
+	if !ok {
+		return nil, false
+	}
+
+	var taggedData *iotago.TaggedData
+	switch payload := basicBlockBody.Payload.(type) {
+	case *iotago.TaggedData:
+		taggedData = payload
+	case *iotago.SignedTransaction:
+		if !t.indexTransactionPayloads {
+			return nil, false
+		}
+		if taggedData, ok = payload.Transaction.Payload.(*iotago.TaggedData); !ok {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	for _, tagPrefix := range t.tagPrefixes {
+		if bytes.HasPrefix(taggedData.Tag, tagPrefix) {
+			return taggedData.Tag, true
+		}
+	}
+
+	return nil, false
+}
+
+// Add adds the block with the given tag to the index, e.g. to index blocks that were not received via the block stream.
+// It returns nil if the block is already indexed.
+func (t *TagIndex) Add(tag []byte, blockID iotago.BlockID) (*Entry, error) {
+	entry, err := t.addEntry(tag, blockID)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	t.Events.EntryAdded.Trigger(entry)
+
+	return entry, nil
+}
+
+// addEntry stores the entry of the block, it returns nil if the block is already indexed.
+func (t *TagIndex) addEntry(tag []byte, blockID iotago.BlockID) (*Entry, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, err := t.store.Get(blockKey(blockID)); err == nil {
+		return nil, nil
+	} else if !ierrors.Is(err, ErrKeyNotFound) {
+		return nil, ierrors.Wrapf(err, "failed to read the entry of block %s", blockID)
+	}
+
+	index, err := t.nextIndex(tag, blockID.Slot())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{
+		Tag:     bytes.Clone(tag),
+		BlockID: blockID,
+		Index:   index,
+	}
+
+	if err := t.store.Set(entryKey(entry.Tag, entry.Slot(), entry.Index), blockID[:]); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to store the entry of block %s", blockID)
+	}
+
+	blockValue := make([]byte, 4+len(entry.Tag))
+	binary.BigEndian.PutUint32(blockValue[:4], entry.Index)
+	copy(blockValue[4:], entry.Tag)
+
+	// the entry by the block ID is stored last, so the block is indexed again if storing the entry failed
+	if err := t.store.Set(blockKey(blockID), blockValue); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to store the entry of block %s", blockID)
+	}
+
+	t.nextIndexes[string(slotKey(entry.Tag, entry.Slot()))] = index + 1
+	t.pruneNextIndexes()
+
+	return entry, nil
+}
+
+// nextIndex returns the index of the next entry with the given tag in the slot.
+func (t *TagIndex) nextIndex(tag []byte, slot iotago.SlotIndex) (uint32, error) {
+	prefix := slotKey(tag, slot)
+	if index, exists := t.nextIndexes[string(prefix)]; exists {
+		return index, nil
+	}
+
+	// the index is unknown after a restart or if a block of an old slot is indexed
+	var index uint32
+	if err := t.store.Iterate(prefix, nil, func(key []byte, _ []byte) bool {
+		index = binary.BigEndian.Uint32(key[len(key)-4:]) + 1
+
+		return true
+	}); err != nil {
+		return 0, ierrors.Wrap(err, "failed to read the entries of the slot")
+	}
+
+	return index, nil
+}
+
+// pruneNextIndexes removes the cached indexes of the committed slots, since blocks are rarely attached to them.
+func (t *TagIndex) pruneNextIndexes() {
+	latestCommitment := t.nodeBridge.LatestCommitment()
+	if latestCommitment == nil || latestCommitment.CommitmentID.Slot() <= t.nextIndexesPrunedSlot {
+		return
+	}
+	t.nextIndexesPrunedSlot = latestCommitment.CommitmentID.Slot()
+
+	for key := range t.nextIndexes {
+		if slotFromSlotKey([]byte(key)) <= t.nextIndexesPrunedSlot {
+			delete(t.nextIndexes, key)
+		}
+	}
+}
+
+// Entry returns the entry of the given block, or ErrKeyNotFound if the block is not indexed.
+func (t *TagIndex) Entry(blockID iotago.BlockID) (*Entry, error) {
+	value, err := t.store.Get(blockKey(blockID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) < 4 {
+		return nil, ierrors.Errorf("invalid entry of block %s", blockID)
+	}
+
+	return &Entry{
+		Tag:     value[4:],
+		BlockID: blockID,
+		Index:   binary.BigEndian.Uint32(value[:4]),
+	}, nil
+}
+
+// Entries returns up to limit entries with the given tag, ordered by the slot and the index of the entries,
+// starting at the entry with the given slot and index.
+func (t *TagIndex) Entries(tag []byte, startSlot iotago.SlotIndex, startIndex uint32, limit int) ([]*Entry, error) {
+	entries := make([]*Entry, 0)
+
+	var innerErr error
+	if err := t.store.Iterate(tagKey(tag), entryKey(tag, startSlot, startIndex), func(key []byte, value []byte) bool {
+		blockID, _, err := iotago.BlockIDFromBytes(value)
+		if err != nil {
+			innerErr = ierrors.Wrapf(err, "invalid entry with tag %s", hexutil.EncodeHex(tag))
+
+			return false
+		}
+
+		entries = append(entries, &Entry{
+			Tag:     bytes.Clone(tag),
+			BlockID: blockID,
+			Index:   binary.BigEndian.Uint32(key[len(key)-4:]),
+		})
+
+		return len(entries) < limit
+	}); err != nil {
+		return nil, ierrors.Wrapf(err, "failed to read the entries with tag %s", hexutil.EncodeHex(tag))
+	}
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return entries, nil
+}
+
+// tagKey returns the prefix of the keys of the entries with the given tag.
+func tagKey(tag []byte) []byte {
+	key := make([]byte, 0, 2+len(tag)+8)
+	key = append(key, keyPrefixTag, byte(len(tag)))
+
+	return append(key, tag...)
+}
+
+// slotKey returns the prefix of the keys of the entries with the given tag in the slot.
+func slotKey(tag []byte, slot iotago.SlotIndex) []byte {
+	return binary.BigEndian.AppendUint32(tagKey(tag), uint32(slot))
+}
+
+// slotFromSlotKey returns the slot of the given slot key.
+func slotFromSlotKey(key []byte) iotago.SlotIndex {
+	return iotago.SlotIndex(binary.BigEndian.Uint32(key[len(key)-4:]))
+}
+
+// entryKey returns the key of the entry with the given tag, slot and index.
+func entryKey(tag []byte, slot iotago.SlotIndex, index uint32) []byte {
+	return binary.BigEndian.AppendUint32(slotKey(tag, slot), index)
+}
+
+// blockKey returns the key of the entry of the given block.
+func blockKey(blockID iotago.BlockID) []byte {
+	return append([]byte{keyPrefixBlock}, blockID[:]...)
+}